@@ -58,6 +58,7 @@ var flagInit struct {
 var flagInitFollower struct {
 	GenesisDoc string
 	ListenIP   string
+	StateSync  bool
 }
 
 var flagInitDevnet struct {
@@ -84,6 +85,7 @@ func init() {
 
 	cmdInitFollower.Flags().StringVar(&flagInitFollower.GenesisDoc, "genesis-doc", "", "Genesis doc for the target network")
 	cmdInitFollower.Flags().StringVarP(&flagInitFollower.ListenIP, "listen", "l", "", "Address and port to listen on, e.g. tcp://1.2.3.4:5678")
+	cmdInitFollower.Flags().BoolVar(&flagInitFollower.StateSync, "state-sync", false, "Catch up with state sync snapshots instead of replaying every block")
 	cmdInitFollower.MarkFlagRequired("network")
 	cmdInitFollower.MarkFlagRequired("listen")
 
@@ -195,8 +197,11 @@ func initFollower(cmd *cobra.Command, _ []string) {
 	}
 
 	peers := make([]string, len(subnet.Nodes))
+	rpcServers := make([]string, len(subnet.Nodes))
+	var trustBlock *types.Block
 	for i, n := range subnet.Nodes {
-		client, err := rpchttp.New(fmt.Sprintf("tcp://%s:%d", n.IP, subnet.Port+networks.TmRpcPortOffset))
+		rpcServers[i] = fmt.Sprintf("tcp://%s:%d", n.IP, subnet.Port+networks.TmRpcPortOffset)
+		client, err := rpchttp.New(rpcServers[i])
 		checkf(err, "failed to connect to %s", n.IP)
 
 		if genDoc == nil {
@@ -215,11 +220,29 @@ func initFollower(cmd *cobra.Command, _ []string) {
 		checkf(err, "failed to get status of %s", n)
 
 		peers[i] = fmt.Sprintf("%s@%s:%d", status.NodeInfo.NodeID, n.IP, subnet.Port)
+
+		if flagInitFollower.StateSync && trustBlock == nil {
+			// Trust the latest block of the first node
+			block, err := client.Block(context.Background(), nil)
+			checkf(err, "failed to get the latest block of %s", n.IP)
+			trustBlock = block.Block
+		}
 	}
 
 	config := config.Default(subnet.Type, cfg.Follower, subnet.Name)
 	config.P2P.PersistentPeers = strings.Join(peers, ",")
 
+	if flagInitFollower.StateSync {
+		config.StateSync.Enable = true
+		config.StateSync.RPCServers = rpcServers
+		if len(rpcServers) == 1 {
+			// Tendermint requires at least two RPC servers
+			config.StateSync.RPCServers = append(rpcServers, rpcServers[0])
+		}
+		config.StateSync.TrustHeight = trustBlock.Height
+		config.StateSync.TrustHash = trustBlock.Hash().String()
+	}
+
 	if flagInit.Reset {
 		nodeReset()
 	}
@@ -328,23 +351,23 @@ func initDevNet(cmd *cobra.Command, args []string) {
 	}
 
 	if !flagInitDevnet.Compose {
-		check(node.Init(node.InitOptions{
+		subnets := []node.InitOptions{{
 			WorkDir:  filepath.Join(flagMain.WorkDir, "dn"),
 			Port:     flagInitDevnet.BasePort,
 			Config:   dnConfig,
 			RemoteIP: dnRemote,
 			ListenIP: dnListen,
-		}))
+		}}
 		for bvn := range bvnConfig {
-			bvnConfig, bvnRemote, bvnListen := bvnConfig[bvn], bvnRemote[bvn], bvnListen[bvn]
-			check(node.Init(node.InitOptions{
+			subnets = append(subnets, node.InitOptions{
 				WorkDir:  filepath.Join(flagMain.WorkDir, fmt.Sprintf("bvn%d", bvn)),
 				Port:     flagInitDevnet.BasePort,
-				Config:   bvnConfig,
-				RemoteIP: bvnRemote,
-				ListenIP: bvnListen,
-			}))
+				Config:   bvnConfig[bvn],
+				RemoteIP: bvnRemote[bvn],
+				ListenIP: bvnListen[bvn],
+			})
 		}
+		check(node.InitNetwork(subnets))
 	}
 
 	if !flagInitDevnet.Compose {
@@ -33,14 +33,24 @@ func testCase2_1(t *testing.T, tc *testCmd) {
 	//faucet the lite account to make sure there are tokens available
 	testCase5_1(t, tc)
 
+	//buy credits so the lite account can pay for the ADI
+	_, err := tc.executeTx(t, fmt.Sprintf("credits %s %s 50000", liteAccounts[0], liteAccounts[0]))
+	require.NoError(t, err)
+
 	commandLine := fmt.Sprintf("adi create %s acc://RedWagon red1", liteAccounts[0])
-	_, err := tc.executeTx(t, commandLine)
+	_, err = tc.executeTx(t, commandLine)
 	require.NoError(t, err)
 
 	//if this doesn't fail, then adi is created
 	_, err = tc.execute(t, "adi directory acc://RedWagon")
 	require.NoError(t, err)
 
+	//fund the ADI's key page so it can pay for the transactions it signs
+	for i := 1; i <= 2; i++ {
+		_, err = tc.executeTx(t, fmt.Sprintf("credits %s acc://RedWagon/page0 90000", liteAccounts[i]))
+		require.NoError(t, err)
+	}
+
 }
 
 //testCase2_2
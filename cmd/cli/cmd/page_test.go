@@ -45,6 +45,10 @@ func testCase4_2(t *testing.T, tc *testCmd) {
 	require.NoError(t, err)
 
 	t.Log(r)
+
+	//fund the first page of the new book so it can pay for the transactions it signs
+	_, err = tc.executeTx(t, fmt.Sprintf("credits %s acc://RedWagon/page1 50000", liteAccounts[3]))
+	require.NoError(t, err)
 }
 
 //testCase4_3 Add a key to a key page
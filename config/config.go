@@ -33,15 +33,38 @@ const (
 
 const DefaultLogLevels = "error;main=info;state=info;statesync=info;accumulate=debug;executor=info"
 
+// DefaultScratchRetention is the number of blocks the bodies of scratch data
+// entries are kept for, about two weeks at one block per second.
+const DefaultScratchRetention = 14 * 24 * 60 * 60
+
+// DefaultPendingRetention is the number of blocks the signatures of
+// transactions are kept for, about two weeks at one block per second.
+const DefaultPendingRetention = 14 * 24 * 60 * 60
+
+// DefaultSnapshotInterval is the number of blocks between state sync
+// snapshots, about an hour at one block per second.
+const DefaultSnapshotInterval = 60 * 60
+
+// DefaultSnapshotRetention is the number of state sync snapshots that are kept.
+const DefaultSnapshotRetention = 2
+
+// DefaultMajorBlockSchedule is the interval between major blocks.
+const DefaultMajorBlockSchedule = time.Hour
+
 func Default(net NetworkType, node NodeType, netId string) *Config {
 	c := new(Config)
 	c.Accumulate.Network.Type = net
 	c.Accumulate.Network.ID = netId
+	c.Accumulate.Network.MajorBlockSchedule = DefaultMajorBlockSchedule
 	c.Accumulate.API.PrometheusServer = "http://18.119.26.7:9090"
 	c.Accumulate.SentryDSN = "https://glet_78c3bf45d009794a4d9b0c990a1f1ed5@gitlab.com/api/v4/error_tracking/collector/29762666"
 	c.Accumulate.Website.Enabled = true
 	c.Accumulate.API.TxMaxWaitTime = 10 * time.Second
 	c.Accumulate.API.EnableDebugMethods = true
+	c.Accumulate.Storage.ScratchRetention = DefaultScratchRetention
+	c.Accumulate.Storage.PendingRetention = DefaultPendingRetention
+	c.Accumulate.Storage.SnapshotInterval = DefaultSnapshotInterval
+	c.Accumulate.Storage.SnapshotRetention = DefaultSnapshotRetention
 	switch node {
 	case Validator:
 		c.Config = *tm.DefaultValidatorConfig()
@@ -65,6 +88,9 @@ type Accumulate struct {
 	Network Network `toml:"network" mapstructure:"network"`
 	API     API     `toml:"api" mapstructure:"api"`
 	Website Website `toml:"website" mapstructure:"website"`
+	Storage Storage `toml:"storage" mapstructure:"storage"`
+
+	Anchoring Anchoring `toml:"anchoring" mapstructure:"anchoring"`
 }
 
 type Network struct {
@@ -72,6 +98,10 @@ type Network struct {
 	ID        string              `toml:"id" mapstructure:"id"`
 	BvnNames  []string            `toml:"bvn-names" mapstructure:"bvn-names"`
 	Addresses map[string][]string `toml:"addresses" mapstructure:"addresses"`
+
+	// MajorBlockSchedule is the interval between major blocks. The first block
+	// of each interval is a major block. Zero disables major blocks.
+	MajorBlockSchedule time.Duration `toml:"major-block-schedule" mapstructure:"major-block-schedule"`
 }
 
 type API struct {
@@ -83,6 +113,60 @@ type API struct {
 	EnableDebugMethods bool          `toml:"enable-debug-methods" mapstructure:"enable-debug-methods"`
 }
 
+type Storage struct {
+	// ScratchRetention is the number of blocks the bodies of scratch data
+	// entries are kept for. Zero keeps them forever.
+	ScratchRetention uint64 `toml:"scratch-retention" mapstructure:"scratch-retention"`
+
+	// PendingRetention is the number of blocks the signatures and validation
+	// material of transactions are kept for. The hash and status of a
+	// transaction are kept forever. Zero keeps them forever. The signatures of
+	// a transaction that is still waiting for signatures are kept until it
+	// expires, which is set at genesis.
+	PendingRetention uint64 `toml:"pending-retention" mapstructure:"pending-retention"`
+
+	// SnapshotInterval is the number of blocks between state sync snapshots.
+	// Zero disables snapshots.
+	SnapshotInterval uint64 `toml:"snapshot-interval" mapstructure:"snapshot-interval"`
+
+	// SnapshotRetention is the number of state sync snapshots that are kept.
+	// Zero keeps every snapshot.
+	SnapshotRetention int `toml:"snapshot-retention" mapstructure:"snapshot-retention"`
+
+	// RetainBlocks is the number of Tendermint blocks that are kept. Older
+	// blocks are pruned, but never past the oldest snapshot. Zero keeps every
+	// block, and so does disabling snapshots.
+	RetainBlocks uint64 `toml:"retain-blocks" mapstructure:"retain-blocks"`
+}
+
+// AnchorBackend is a backend used to anchor the roots of major blocks to an
+// external ledger.
+type AnchorBackend string
+
+const (
+	// NoAnchoring disables external anchoring.
+	NoAnchoring AnchorBackend = ""
+
+	// FactomAnchoring writes anchors as entries of a Factom chain.
+	FactomAnchoring AnchorBackend = "factom"
+
+	// FileAnchoring appends anchors to a local file. It is intended for
+	// testing.
+	FileAnchoring AnchorBackend = "file"
+)
+
+// Anchoring configures how the DN anchors the roots of major blocks to an
+// external ledger.
+type Anchoring struct {
+	Backend AnchorBackend `toml:"backend" mapstructure:"backend"`
+
+	FactomdServer   string `toml:"factomd-server" mapstructure:"factomd-server"`
+	FactomChainID   string `toml:"factom-chain-id" mapstructure:"factom-chain-id"`
+	FactomEsAddress string `toml:"factom-es-address" mapstructure:"factom-es-address"`
+
+	File string `toml:"file" mapstructure:"file"`
+}
+
 type Website struct {
 	Enabled       bool   `toml:"website-enabled" mapstructure:"website-enabled"`
 	ListenAddress string `toml:"website-listen-address" mapstructure:"website-listen-address"`
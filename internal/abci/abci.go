@@ -12,6 +12,7 @@
 package abci
 
 import (
+	"io"
 	"time"
 
 	"github.com/AccumulateNetwork/accumulate/protocol"
@@ -47,6 +48,18 @@ type SynthTxnReference struct {
 // EndBlockRequest is the input parameter to Chain.EndBlock
 type EndBlockRequest struct{}
 
+// EndBlockResponse is the return value of Chain.EndBlock.
+type EndBlockResponse struct {
+	ValidatorUpdates []ValidatorUpdate
+}
+
+// ValidatorUpdate is a change to the validator set. A power of zero removes the
+// validator.
+type ValidatorUpdate struct {
+	PubKey []byte
+	Power  int64
+}
+
 // Chain is the interface for the Accumulate transaction (chain) validator.
 type Chain interface {
 	Query(*apiQuery.Query) (k, v []byte, err *protocol.Error)
@@ -56,7 +69,7 @@ type Chain interface {
 	BeginBlock(BeginBlockRequest) (BeginBlockResponse, error)
 	CheckTx(*transactions.GenTransaction) *protocol.Error
 	DeliverTx(*transactions.GenTransaction) *protocol.Error
-	EndBlock(EndBlockRequest)
+	EndBlock(EndBlockRequest) EndBlockResponse
 	Commit() ([]byte, error)
 }
 
@@ -69,4 +82,13 @@ type State interface {
 
 	// RootHash returns the root hash of the chain
 	RootHash() []byte
+
+	// Snapshot captures the state and returns a function that writes it. The
+	// function may be called while later blocks are committed, and must be
+	// called exactly once.
+	Snapshot() (func(io.Writer) error, error)
+
+	// ReadSnapshot replaces the state with a snapshot read from the reader. If
+	// the root hash of the snapshot does not match, the state is cleared.
+	ReadSnapshot(r io.Reader, rootHash []byte) error
 }
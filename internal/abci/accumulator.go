@@ -30,33 +30,56 @@ import (
 type Accumulator struct {
 	abci.BaseApplication
 
-	subnetID string
-	state    State
-	address  crypto.Address
-	txct     int64
-	timer    time.Time
-	chain    Chain
-	logger   log.Logger
-	didPanic bool
+	subnetID  string
+	state     State
+	address   crypto.Address
+	txct      int64
+	timer     time.Time
+	chain     Chain
+	logger    log.Logger
+	didPanic  bool
+	snapshots SnapshotOptions
+	restore   *snapshotRestore
+	retain    uint64
+
+	// snapshotting is set while a snapshot is being written
+	snapshotting int32
 
 	onFatal func(error)
 }
 
+// AccumulatorOptions are the options for creating an Accumulator.
+type AccumulatorOptions struct {
+	DB      State
+	Address crypto.Address
+	Chain   Chain
+	Logger  log.Logger
+
+	// Snapshots configures the state sync snapshots taken by the node.
+	Snapshots SnapshotOptions
+
+	// RetainBlocks is the number of Tendermint blocks that are kept. Zero
+	// keeps every block.
+	RetainBlocks uint64
+}
+
 // NewAccumulator returns a new Accumulator.
-func NewAccumulator(db State, address crypto.Address, chain Chain, logger log.Logger) (*Accumulator, error) {
-	logger = logger.With("module", "accumulate")
+func NewAccumulator(opts AccumulatorOptions) (*Accumulator, error) {
+	logger := opts.Logger.With("module", "accumulate")
 
 	app := &Accumulator{
-		state:  db,
-		chain:  chain,
-		logger: logger,
+		state:     opts.DB,
+		chain:     opts.Chain,
+		logger:    logger,
+		snapshots: opts.Snapshots,
+		retain:    opts.RetainBlocks,
 	}
 
-	app.address = make([]byte, len(address))
-	copy(app.address, address)
+	app.address = make([]byte, len(opts.Address))
+	copy(app.address, opts.Address)
 
 	var err error
-	app.subnetID, err = db.SubnetID()
+	app.subnetID, err = opts.DB.SubnetID()
 	switch {
 	case err == nil:
 		logger = logger.With("subnet", app.subnetID)
@@ -232,11 +255,6 @@ func (app *Accumulator) InitChain(req abci.RequestInitChain) abci.ResponseInitCh
 	app.logger = app.logger.With("subnet", req.ChainId)
 	app.logger.Info("Initializing")
 
-	//register a list of the validators.
-	for _, v := range req.Validators {
-		app.updateValidator(v)
-	}
-
 	return abci.ResponseInitChain{AppHash: app.state.RootHash()}
 }
 
@@ -404,26 +422,13 @@ func (app *Accumulator) DeliverTx(req abci.RequestDeliverTx) (rdt abci.ResponseD
 func (app *Accumulator) EndBlock(req abci.RequestEndBlock) (resp abci.ResponseEndBlock) {
 	defer app.recover(nil)
 
-	// Select our leader who will initiate consensus on dbvc chain.
-	//resp.ConsensusParamUpdates
-	//for _, ev := range req.ByzantineValidators {
-	//	if ev.Type == types.EvidenceType_DUPLICATE_VOTE {
-	//		addr := string(ev.Validator.Address)
-	//		if pubKey, ok := app.valAddrToPubKeyMap[addr]; ok {
-	//			app.updateValidator(types.ValidatorUpdate{
-	//				PubKey: pubKey,
-	//				Power:  ev.Validator.Power - 1,
-	//			})
-	//			app.logger.Info("Decreased val power by 1 because of the equivocation",
-	//				"val", addr)
-	//		} else {
-	//			app.logger.Error("Wanted to punish val, but can't find it",
-	//				"val", addr)
-	//		}
-	//	}
-	//}
-
-	return abci.ResponseEndBlock{} //ValidatorUpdates: app.ValUpdates}
+	r := app.chain.EndBlock(EndBlockRequest{})
+
+	// Apply changes to the validator set made by governance transactions
+	for _, v := range r.ValidatorUpdates {
+		resp.ValidatorUpdates = append(resp.ValidatorUpdates, app.updateValidator(v))
+	}
+	return resp
 }
 
 // Commit implements github.com/tendermint/tendermint/abci/types.Application.
@@ -442,11 +447,18 @@ func (app *Accumulator) Commit() (resp abci.ResponseCommit) {
 		return
 	}
 
-	//this will truncate what tendermint stores since we only care about current state
-	//todo: uncomment the next line when we have smt state syncing complete. For now, we are retaining everything for test net
-	// if app.RetainBlocks > 0 && app.Height >= app.RetainBlocks {
-	// 	resp.RetainHeight = app.Height - app.RetainBlocks + 1
-	// }
+	// Take a snapshot of the state for state sync
+	err = app.maybeTakeSnapshot()
+	if err != nil {
+		app.logger.Error("Failed to take a snapshot", "error", err)
+	}
+
+	// Truncate what Tendermint stores, since we only care about the current
+	// state
+	resp.RetainHeight, err = app.retainHeight()
+	if err != nil {
+		app.logger.Error("Failed to calculate the retain height", "error", err)
+	}
 
 	duration := time.Since(app.timer)
 	app.logger.Info("Committed", "transactions", app.txct, "duration", duration.String(), "tps", float64(app.txct)/duration.Seconds())
@@ -454,75 +466,47 @@ func (app *Accumulator) Commit() (resp abci.ResponseCommit) {
 	return resp
 }
 
-// ListSnapshots implements github.com/tendermint/tendermint/abci/types.Application.
-func (app *Accumulator) ListSnapshots(
-	req abci.RequestListSnapshots) abci.ResponseListSnapshots {
-
-	req.ProtoMessage()
-	return abci.ResponseListSnapshots{}
-}
-
-// OfferSnapshot implements github.com/tendermint/tendermint/abci/types.Application.
-func (app *Accumulator) OfferSnapshot(
-	req abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
-	return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ABORT}
-}
+// retainHeight returns the height of the oldest Tendermint block that must be
+// kept. Blocks are never pruned past the oldest snapshot, so that nodes can
+// still state sync from it, and they are not pruned at all if snapshots are
+// disabled. Pruning Tendermint blocks does not affect the chains of the state,
+// so receipts are not affected.
+func (app *Accumulator) retainHeight() (int64, error) {
+	if app.retain == 0 || app.snapshots.Dir == "" || app.snapshots.Interval == 0 {
+		return 0, nil
+	}
 
-// LoadSnapshotChunk implements github.com/tendermint/tendermint/abci/types.Application.
-func (app *Accumulator) LoadSnapshotChunk(
-	req abci.RequestLoadSnapshotChunk) abci.ResponseLoadSnapshotChunk {
+	height, err := app.state.BlockIndex()
+	if err != nil {
+		return 0, err
+	}
+	if height <= 0 || uint64(height) <= app.retain {
+		return 0, nil
+	}
+	retain := height - int64(app.retain) + 1
 
-	//req.Height
-	//resp := abcitypes.ResponseLoadSnapshotChunk{}
-	//need to get a block of data between markers.
-	//resp.Chunk = app.mm.GetState(req.Height)
-	return abci.ResponseLoadSnapshotChunk{}
-}
+	snapshots, err := app.listSnapshots()
+	if err != nil {
+		return 0, err
+	}
 
-// ApplySnapshotChunk implements github.com/tendermint/tendermint/abci/types.Application.
-func (app *Accumulator) ApplySnapshotChunk(
-	req abci.RequestApplySnapshotChunk) abci.ResponseApplySnapshotChunk {
-	return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ABORT}
+	// Do not prune until the first snapshot has been taken
+	if len(snapshots) == 0 {
+		return 0, nil
+	}
+	if oldest := int64(snapshots[0].Height); oldest < retain {
+		return oldest, nil
+	}
+	return retain, nil
 }
 
-//updateValidator add, update, or remove a validator
-func (app *Accumulator) updateValidator(v abci.ValidatorUpdate) {
-	// pubkey, _ := encoding.PubKeyFromProto(v.PubKey)
-	// app.logger.Info("Val Pub Key", "address", pubkey.Address())
-	/*
-	   	if err != nil {
-	   		panic(fmt.Errorf("can't decode public key: %w", err))
-	   	}
-	   	//key := []byte("val:" + string(pubkey.Bytes()))
-	   	if v.Power == 0 {
-	   		// remove validator
-	   		_, found := app.tmvalidators[string(pubkey.Address())]// app.app.state.db.Has(key)
-	   		if !found {
-	   			pubStr := base64.StdEncoding.EncodeToString(pubkey.Bytes())
-	   			return abcitypes.ResponseDeliverTx{
-	   				Code: code.CodeTypeUnauthorized,
-	   				Log:  fmt.Sprintf("Cannot remove non-existent validator %s", pubStr)}
-	   		}
-	   //		if !hasKey
-	   		//if err = app.app.state.db.Delete(key); err != nil {
-	   		//	panic(err)
-	   		//}
-	   		delete(app.tmvalidators, string(pubkey.Address()))
-	   	} else {
-	   		// add or update validator
-	   		//value := bytes.NewBuffer(make([]byte, 0))
-	   		//if err := types.WriteMessage(&v, value); err != nil {
-	   		//	return types.ResponseDeliverTx{
-	   		//		Code: code.CodeTypeEncodingError,
-	   		//		Log:  fmt.Sprintf("Error encoding validator: %v", err)}
-	   		//}
-	   		//if err = app.app.state.db.Set(key, value.Bytes()); err != nil {
-	   		//	panic(err)
-	   		//}
-	   		app.tmvalidators[string(pubkey.Address())] = pubkey
-	   	}
-	*/
-
-	// we only update the changes array if we successfully updated the tree
-	//app.ValUpdates = append(app.ValUpdates, v)
+// updateValidator converts a change to the validator set to its Tendermint
+// form.
+func (app *Accumulator) updateValidator(v ValidatorUpdate) abci.ValidatorUpdate {
+	if v.Power == 0 {
+		app.logger.Info("Removing validator", "key", logging.AsHex(v.PubKey))
+	} else {
+		app.logger.Info("Updating validator", "key", logging.AsHex(v.PubKey), "power", v.Power)
+	}
+	return abci.Ed25519ValidatorUpdate(v.PubKey, v.Power)
 }
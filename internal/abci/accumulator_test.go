@@ -2,13 +2,22 @@ package abci_test
 
 import (
 	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/AccumulateNetwork/accumulate/internal/abci"
 	mock_abci "github.com/AccumulateNetwork/accumulate/internal/mock/abci"
 	testing2 "github.com/AccumulateNetwork/accumulate/internal/testing"
 	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
 	tmabci "github.com/tendermint/tendermint/abci/types"
@@ -78,7 +87,7 @@ func (s *AccumulatorTestSuite) TestCheckTx() {
 		amount := uint64(1000000000)
 		tx, err := testing2.BuildTestTokenTxGenTx(origin, destAddr, amount)
 		//now corrupt the validation for the signature
-		tx.Signature[0].Nonce = 9999999
+		tx.Signature[0].(*transactions.ED25519Sig).Nonce = 9999999
 
 		s.Require().NoError(err)
 
@@ -135,7 +144,13 @@ func (s *AccumulatorTestSuite) TestDeliverTx() {
 }
 
 func (s *AccumulatorTestSuite) TestEndBlock() {
-	s.T().Skip("EndBlock does nothing")
+	pubKey, _, _ := ed25519.GenerateKey(nil)
+	s.Chain().EXPECT().EndBlock(gomock.Any()).Return(abci.EndBlockResponse{
+		ValidatorUpdates: []abci.ValidatorUpdate{{PubKey: pubKey, Power: 1}},
+	})
+
+	resp := s.App(nil).EndBlock(tmabci.RequestEndBlock{})
+	s.Require().Equal([]tmabci.ValidatorUpdate{tmabci.Ed25519ValidatorUpdate(pubKey, 1)}, resp.ValidatorUpdates)
 }
 
 func (s *AccumulatorTestSuite) TestCommit() {
@@ -146,6 +161,151 @@ func (s *AccumulatorTestSuite) TestCommit() {
 	s.Require().Equal(hash, resp.Data)
 }
 
+func (s *AccumulatorTestSuite) TestRetainHeight() {
+	const height int64 = 100
+	expect := func() {
+		s.State().EXPECT().BlockIndex().AnyTimes().Return(height, nil)
+		s.Chain().EXPECT().Commit().AnyTimes()
+	}
+
+	s.Run("Keeps every block by default", func() {
+		expect()
+		resp := s.App(nil).Commit()
+		s.Require().Zero(resp.RetainHeight)
+	})
+
+	s.Run("Keeps every block without snapshots", func() {
+		expect()
+		app := s.newApp(abci.AccumulatorOptions{DB: s.State(), Chain: s.Chain(), RetainBlocks: 10})
+		resp := app.Commit()
+		s.Require().Zero(resp.RetainHeight)
+	})
+
+	s.Run("Keeps the last N blocks", func() {
+		expect()
+		dir := s.T().TempDir()
+		snapshots := abci.SnapshotOptions{Dir: dir, Interval: 1000}
+		app := s.newApp(abci.AccumulatorOptions{DB: s.State(), Chain: s.Chain(), RetainBlocks: 10, Snapshots: snapshots})
+
+		data, err := json.Marshal(tmabci.Snapshot{Height: 95})
+		s.Require().NoError(err)
+		s.Require().NoError(os.Mkdir(filepath.Join(dir, "95"), 0700))
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "95", "snapshot.json"), data, 0600))
+
+		resp := app.Commit()
+		s.Require().Equal(height-9, resp.RetainHeight)
+	})
+
+	s.Run("Keeps blocks since the oldest snapshot", func() {
+		expect()
+		dir := s.T().TempDir()
+		snapshots := abci.SnapshotOptions{Dir: dir, Interval: 1000}
+		app := s.newApp(abci.AccumulatorOptions{DB: s.State(), Chain: s.Chain(), RetainBlocks: 10, Snapshots: snapshots})
+
+		// No snapshot has been taken yet
+		resp := app.Commit()
+		s.Require().Zero(resp.RetainHeight)
+
+		data, err := json.Marshal(tmabci.Snapshot{Height: 50})
+		s.Require().NoError(err)
+		s.Require().NoError(os.Mkdir(filepath.Join(dir, "50"), 0700))
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "50", "snapshot.json"), data, 0600))
+
+		resp = app.Commit()
+		s.Require().Equal(int64(50), resp.RetainHeight)
+	})
+}
+
+func (s *AccumulatorTestSuite) TestSnapshots() {
+	// Create a state with a record in it
+	src := new(state.StateDB)
+	s.Require().NoError(src.Open("", true, false, nil))
+	src.WriteParameter("SubnetID", []byte("foo"))
+
+	data, err := state.NewIdentityState("foo").MarshalBinary()
+	s.Require().NoError(err)
+	chainId := types.Bytes32(sha256.Sum256([]byte("foo")))
+	tx := src.Begin()
+	tx.AddStateEntry(&chainId, new(types.Bytes32), &state.Object{Entry: data})
+	_, err = tx.Commit(1, time.Unix(0, 0), nil)
+	s.Require().NoError(err)
+
+	// Committing a block on the interval takes a snapshot
+	s.Chain().EXPECT().Commit().Return(src.RootHash(), nil)
+	app := s.newApp(abci.AccumulatorOptions{DB: src, Chain: s.Chain(), Snapshots: abci.SnapshotOptions{Dir: s.T().TempDir(), Interval: 1, Retention: 1}})
+	app.Commit()
+
+	// The snapshot is written in the background
+	var list tmabci.ResponseListSnapshots
+	s.Require().Eventually(func() bool {
+		list = app.ListSnapshots(tmabci.RequestListSnapshots{})
+		return len(list.Snapshots) > 0
+	}, time.Second, time.Millisecond)
+	s.Require().Len(list.Snapshots, 1)
+	snapshot := list.Snapshots[0]
+	s.Require().Equal(uint64(1), snapshot.Height)
+
+	var chunks [][]byte
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		resp := app.LoadSnapshotChunk(tmabci.RequestLoadSnapshotChunk{Height: snapshot.Height, Format: snapshot.Format, Chunk: i})
+		s.Require().NotEmpty(resp.Chunk)
+		chunks = append(chunks, resp.Chunk)
+	}
+
+	restore := func(appHash []byte) (*state.StateDB, *abci.Accumulator) {
+		dst := new(state.StateDB)
+		s.Require().NoError(dst.Open("", true, false, nil))
+		app := s.newApp(abci.AccumulatorOptions{DB: dst, Chain: s.Chain(), Snapshots: abci.SnapshotOptions{Dir: s.T().TempDir()}})
+
+		offer := app.OfferSnapshot(tmabci.RequestOfferSnapshot{Snapshot: snapshot, AppHash: appHash})
+		s.Require().Equal(tmabci.ResponseOfferSnapshot_ACCEPT, offer.Result)
+		return dst, app
+	}
+
+	s.Run("Restores the state", func() {
+		dst, app := restore(src.RootHash())
+		for i, chunk := range chunks {
+			resp := app.ApplySnapshotChunk(tmabci.RequestApplySnapshotChunk{Index: uint32(i), Chunk: chunk})
+			s.Require().Equal(tmabci.ResponseApplySnapshotChunk_ACCEPT, resp.Result)
+		}
+		s.Require().Equal(src.RootHash(), dst.RootHash())
+
+		height, err := dst.BlockIndex()
+		s.Require().NoError(err)
+		s.Require().Equal(int64(1), height)
+	})
+
+	s.Run("Refetches a corrupted chunk", func() {
+		_, app := restore(src.RootHash())
+		chunk := append([]byte{}, chunks[0]...)
+		chunk[0]++
+		resp := app.ApplySnapshotChunk(tmabci.RequestApplySnapshotChunk{Index: 0, Chunk: chunk, Sender: "bad"})
+		s.Require().Equal(tmabci.ResponseApplySnapshotChunk_RETRY, resp.Result)
+		s.Require().Equal([]uint32{0}, resp.RefetchChunks)
+		s.Require().Equal([]string{"bad"}, resp.RejectSenders)
+	})
+
+	s.Run("Rejects a snapshot that does not match the app hash", func() {
+		dst, app := restore(make([]byte, 32))
+		var resp tmabci.ResponseApplySnapshotChunk
+		for i, chunk := range chunks {
+			resp = app.ApplySnapshotChunk(tmabci.RequestApplySnapshotChunk{Index: uint32(i), Chunk: chunk})
+		}
+		s.Require().Equal(tmabci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT, resp.Result)
+
+		// The rejected state is cleared
+		_, err := dst.BlockIndex()
+		s.Require().ErrorIs(err, storage.ErrNotFound)
+	})
+
+	s.Run("Rejects an unknown format", func() {
+		bad := *snapshot
+		bad.Format++
+		resp := app.OfferSnapshot(tmabci.RequestOfferSnapshot{Snapshot: &bad})
+		s.Require().Equal(tmabci.ResponseOfferSnapshot_REJECT_FORMAT, resp.Result)
+	})
+}
+
 type AccumulatorTestSuite struct {
 	suite.Suite
 	varMap map[*testing.T]*accVars
@@ -185,11 +345,16 @@ func (s *AccumulatorTestSuite) State() *mock_abci.MockState { return s.vars().St
 func (s *AccumulatorTestSuite) Chain() *mock_abci.MockChain { return s.vars().Chain }
 
 func (s *AccumulatorTestSuite) App(addr crypto.Address) *abci.Accumulator {
-	if addr == nil {
-		addr = crypto.Address{}
+	return s.newApp(abci.AccumulatorOptions{Address: addr, DB: s.State(), Chain: s.Chain()})
+}
+
+func (s *AccumulatorTestSuite) newApp(opts abci.AccumulatorOptions) *abci.Accumulator {
+	if opts.Address == nil {
+		opts.Address = crypto.Address{}
 	}
+	opts.Logger = log.MustNewDefaultLogger("plain", "error", false)
 
-	app, err := abci.NewAccumulator(s.State(), addr, s.Chain(), log.MustNewDefaultLogger("plain", "error", false))
+	app, err := abci.NewAccumulator(opts)
 	s.Require().NoError(err)
 	return app
 }
@@ -39,12 +39,25 @@ func TestProofADI(t *testing.T) {
 	})
 	require.Equal(t, keyHash[:], n.GetKeyPage("RoadRunner/page0").Keys[0].PublicKey)
 
+	// Add credits to the ADI's key page
+	n.Batch(func(send func(*Tx)) {
+		ac := new(protocol.AddCredits)
+		ac.Recipient = "RoadRunner/page0"
+		ac.Amount = 1e6
+
+		sponsorUrl := acctesting.AcmeLiteAddressTmPriv(liteKey).String()
+		tx, err := transactions.New(sponsorUrl, 1, edSigner(liteKey, 2), ac)
+		require.NoError(t, err)
+
+		send(tx)
+	})
+
 	// Create ADI token account
 	n.Batch(func(send func(*transactions.GenTransaction)) {
 		tac := new(protocol.TokenAccountCreate)
 		tac.Url = "RoadRunner/Baz"
 		tac.TokenUrl = protocol.AcmeUrl().String()
-		tx, err := transactions.New("RoadRunner", 1, edSigner(adiKey, 1), tac)
+		tx, err := transactions.New("RoadRunner", 2, edSigner(adiKey, 1), tac)
 		require.NoError(t, err)
 		send(tx)
 	})
@@ -1,6 +1,7 @@
 package abci_test
 
 import (
+	"crypto/ed25519"
 	"fmt"
 	"path/filepath"
 	"testing"
@@ -27,7 +28,8 @@ func TestStateDBConsistency(t *testing.T) {
 	sdb := new(state.StateDB)
 	require.NoError(t, sdb.Load(db, true))
 
-	n := createApp(t, sdb, crypto.Address{}, true)
+	_, bvcKey, _ := ed25519.GenerateKey(rand)
+	n := createApp(t, sdb, bvcKey, crypto.Address{}, true)
 	n.testLiteTx(10)
 
 	height, err := sdb.BlockIndex()
@@ -46,6 +48,6 @@ func TestStateDBConsistency(t *testing.T) {
 	require.Equal(t, fmt.Sprintf("%X", rootHash), fmt.Sprintf("%X", sdb.RootHash()), "Hash does not match after load from disk")
 
 	// Recreate the app and try to do more transactions
-	n = createApp(t, sdb, crypto.Address{}, false)
+	n = createApp(t, sdb, bvcKey, crypto.Address{}, false)
 	n.testLiteTx(10)
 }
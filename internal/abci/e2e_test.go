@@ -2,6 +2,7 @@ package abci_test
 
 import (
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -10,12 +11,16 @@ import (
 	"github.com/AccumulateNetwork/accumulate/internal/testing/e2e"
 	"github.com/AccumulateNetwork/accumulate/internal/url"
 	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
 	"github.com/AccumulateNetwork/accumulate/types"
 	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/tendermint/tendermint/crypto"
+	tmed25519 "github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	randpkg "golang.org/x/exp/rand"
 )
 
@@ -36,11 +41,9 @@ func BenchmarkFaucetAndLiteTx(b *testing.B) {
 
 	recipient := generateKey()
 
-	n.Batch(func(send func(*Tx)) {
-		tx, err := acctesting.CreateFakeSyntheticDepositTx(recipient)
-		require.NoError(b, err)
-		send(tx)
-	})
+	dbTx := n.db.Begin()
+	require.NoError(b, acctesting.CreateLiteTokenAccount(dbTx, recipient, 5e4))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
 
 	origin := acctesting.NewWalletEntry()
 	origin.Nonce = 1
@@ -54,7 +57,7 @@ func BenchmarkFaucetAndLiteTx(b *testing.B) {
 		for i := 0; i < b.N; i++ {
 			exch := new(protocol.SendTokens)
 			exch.AddRecipient(n.ParseUrl(rwallet.Addr), 1000)
-			tx, err := transactions.New(origin.Addr, 1, func(hash []byte) (*transactions.ED25519Sig, error) {
+			tx, err := transactions.New(origin.Addr, 1, func(hash []byte) (transactions.Signature, error) {
 				return origin.Sign(hash), nil
 			}, exch)
 			require.NoError(b, err)
@@ -67,20 +70,23 @@ func TestCreateLiteAccount(t *testing.T) {
 	var count = 11
 	n := createAppWithMemDB(t, crypto.Address{}, true)
 	originAddr, balances := n.testLiteTx(count)
-	require.Equal(t, int64(5e4*acctesting.TokenMx-count*1000), n.GetLiteTokenAccount(originAddr).Balance.Int64())
+	// testLiteTx converts 1 ACME into credits
+	require.Equal(t, int64(5e4*acctesting.TokenMx-acctesting.TokenMx-count*1000), n.GetLiteTokenAccount(originAddr).Balance.Int64())
 	for addr, bal := range balances {
 		require.Equal(t, bal, n.GetLiteTokenAccount(addr).Balance.Int64())
 	}
 }
 
 func (n *fakeNode) testLiteTx(count int) (string, map[string]int64) {
-	_, recipient, gtx, err := acctesting.BuildTestSynthDepositGenTx()
-	require.NoError(n.t, err)
+	recipient := generateKey()
+	dbTx := n.db.Begin()
+	require.NoError(n.t, acctesting.CreateLiteTokenAccount(dbTx, recipient, 5e4))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
 
 	origin := acctesting.NewWalletEntry()
 	origin.Nonce = 1
-	origin.PrivateKey = recipient
-	origin.Addr = acctesting.AcmeLiteAddressStdPriv(recipient).String()
+	origin.PrivateKey = recipient.Bytes()
+	origin.Addr = acctesting.AcmeLiteAddressTmPriv(recipient).String()
 
 	recipients := make([]*transactions.WalletEntry, 10)
 	for i := range recipients {
@@ -88,7 +94,14 @@ func (n *fakeNode) testLiteTx(count int) (string, map[string]int64) {
 	}
 
 	n.Batch(func(send func(*transactions.GenTransaction)) {
-		send(gtx)
+		ac := new(protocol.AddCredits)
+		ac.Recipient = origin.Addr
+		ac.Amount = protocol.CreditsPerFiatUnit * protocol.InitialAcmeOracleValue / protocol.AcmeOraclePrecision
+		tx, err := transactions.New(origin.Addr, 1, func(hash []byte) (transactions.Signature, error) {
+			return origin.Sign(hash), nil
+		}, ac)
+		require.NoError(n.t, err)
+		send(tx)
 	})
 
 	balance := map[string]int64{}
@@ -99,7 +112,7 @@ func (n *fakeNode) testLiteTx(count int) (string, map[string]int64) {
 
 			exch := new(protocol.SendTokens)
 			exch.AddRecipient(n.ParseUrl(recipient.Addr), 1000)
-			tx, err := transactions.New(origin.Addr, 1, func(hash []byte) (*transactions.ED25519Sig, error) {
+			tx, err := transactions.New(origin.Addr, 1, func(hash []byte) (transactions.Signature, error) {
 				return origin.Sign(hash), nil
 			}, exch)
 			require.NoError(n.t, err)
@@ -118,7 +131,7 @@ func TestFaucet(t *testing.T) {
 	n.Batch(func(send func(*transactions.GenTransaction)) {
 		body := new(protocol.AcmeFaucet)
 		body.Url = aliceUrl
-		tx, err := transactions.New(protocol.FaucetUrl.String(), 1, func(hash []byte) (*transactions.ED25519Sig, error) {
+		tx, err := transactions.New(protocol.FaucetUrl.String(), 1, func(hash []byte) (transactions.Signature, error) {
 			return protocol.FaucetWallet.Sign(hash), nil
 		}, body)
 		require.NoError(t, err)
@@ -126,6 +139,9 @@ func TestFaucet(t *testing.T) {
 	})
 
 	require.Equal(t, int64(10*protocol.AcmePrecision), n.GetLiteTokenAccount(aliceUrl).Balance.Int64())
+
+	// Faucet transactions are free
+	require.Equal(t, int64(protocol.FaucetCredits), n.GetLiteTokenAccount(protocol.FaucetUrl.String()).CreditBalance.Int64())
 }
 
 func TestAnchorChain(t *testing.T) {
@@ -167,13 +183,15 @@ func TestAnchorChain(t *testing.T) {
 	// Check each anchor
 	first := anchor.Height() - int64(len(head.Chains))
 	for i, chain := range head.Chains {
-		mgr, err := n.db.ManageChain(chain)
+		obj, err := n.db.GetPersistentEntry(chain[:], false)
+		require.NoError(t, err)
+		expected, err := obj.Anchor()
 		require.NoError(t, err)
 
 		root, err := anchor.Chain.Entry(first + int64(i))
 		require.NoError(t, err)
 
-		assert.Equal(t, mgr.Anchor(), root, "wrong anchor for %X", chain)
+		assert.Equal(t, expected, root, "wrong anchor for %X", chain)
 	}
 }
 
@@ -200,7 +218,7 @@ func TestCreateADI(t *testing.T) {
 		adi.KeyPageName = "bar-page"
 
 		sponsorUrl := acctesting.AcmeLiteAddressTmPriv(liteAccount).String()
-		tx, err := transactions.New(sponsorUrl, 1, func(hash []byte) (*transactions.ED25519Sig, error) {
+		tx, err := transactions.New(sponsorUrl, 1, func(hash []byte) (transactions.Signature, error) {
 			return wallet.Sign(hash), nil
 		}, adi)
 		require.NoError(t, err)
@@ -383,6 +401,208 @@ func TestCreateAdiDataAccount(t *testing.T) {
 	})
 }
 
+func TestScratchDataAccount(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	adiKey := generateKey()
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, adiKey, "FooBar"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	// Creating a scratch account is charged the scratch rate
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		cda := new(protocol.CreateDataAccount)
+		cda.Url = "FooBar/scratch"
+		cda.Scratch = true
+		tx, err := transactions.New("FooBar", 1, edSigner(adiKey, 1), cda)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.True(t, n.GetDataAccount("FooBar/scratch").Scratch)
+	credits := n.GetKeyPage("FooBar/page0").CreditBalance.Int64()
+	require.Equal(t, int64(acctesting.TestCredits-protocol.FeeCreateScratchChain.AsInt()), credits)
+
+	// Write one entry per block, each charged the scratch rate
+	var entries []*protocol.DataEntry
+	for i := 0; i < testScratchRetention+1; i++ {
+		wd := new(protocol.WriteData)
+		wd.Entry.Data = []byte(fmt.Sprintf("telemetry %d", i))
+		entries = append(entries, &wd.Entry)
+
+		n.Batch(func(send func(*transactions.GenTransaction)) {
+			tx, err := transactions.New("FooBar/scratch", 1, edSigner(adiKey, uint64(i+2)), wd)
+			require.NoError(t, err)
+			send(tx)
+		})
+
+		credits -= int64(protocol.FeeWriteScratchData)
+		require.Equal(t, credits, n.GetKeyPage("FooBar/page0").CreditBalance.Int64())
+	}
+
+	// The body of the oldest entry has been pruned, but its hash is still on
+	// the chain
+	_, err := n.query.GetDataByEntryHash("FooBar/scratch", entries[0].Hash())
+	require.Error(t, err)
+
+	r := n.GetChainDataSet("FooBar/scratch", 0, uint64(len(entries)), true)
+	require.Equal(t, int64(len(entries)), r.Total)
+
+	latest := entries[len(entries)-1]
+	rde := protocol.ResponseDataEntry{}
+	require.NoError(t, rde.UnmarshalJSON(*n.GetChainDataByEntryHash("FooBar/scratch", latest.Hash()).Data))
+	require.True(t, rde.Entry.Equal(latest))
+}
+
+func TestSegWitDataEntry(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	adiKey := generateKey()
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, adiKey, "FooBar"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		cda := new(protocol.CreateDataAccount)
+		cda.Url = "FooBar/data"
+		tx, err := transactions.New("FooBar", 1, edSigner(adiKey, 1), cda)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	wd := new(protocol.WriteData)
+	wd.Entry.ExtIds = [][]byte{[]byte("foo")}
+	wd.Entry.Data = []byte("thequickbrownfoxjumpsoverthelazydog")
+	var txid []byte
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.New("FooBar/data", 1, edSigner(adiKey, 2), wd)
+		require.NoError(t, err)
+		send(tx)
+		txid = tx.TransactionHash()
+	})
+
+	// The transaction history only has a reference to the entry
+	r, err := n.query.GetTransaction(txid)
+	require.NoError(t, err)
+	require.Equal(t, types.TxTypeSegWitDataEntry.Name(), string(r.Type))
+	sw := new(protocol.SegWitDataEntry)
+	require.NoError(t, json.Unmarshal(*r.Data, sw))
+	require.Equal(t, txid, sw.Cause[:])
+	require.Equal(t, wd.Entry.Hash(), sw.EntryHash[:])
+	require.Equal(t, n.ParseUrl("FooBar/data").String(), sw.EntryUrl)
+
+	// The body of the entry can be fetched by its hash
+	rde := protocol.ResponseDataEntry{}
+	require.NoError(t, rde.UnmarshalJSON(*n.GetChainDataByEntryHash("FooBar/data", sw.EntryHash[:]).Data))
+	require.True(t, rde.Entry.Equal(&wd.Entry))
+}
+
+func TestPrunePendingTransactions(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	adiKey := generateKey()
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, adiKey, "FooBar"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	// Create token accounts until the first transaction is outside of the
+	// retention window
+	var txids [][]byte
+	var first int64
+	for i := 0; first == 0 || n.height <= first+testPendingRetention; i++ {
+		tac := new(protocol.TokenAccountCreate)
+		tac.Url = fmt.Sprintf("FooBar/tokens%d", i)
+		tac.TokenUrl = protocol.AcmeUrl().String()
+		n.Batch(func(send func(*transactions.GenTransaction)) {
+			tx, err := transactions.New("FooBar", 1, edSigner(adiKey, uint64(i+1)), tac)
+			require.NoError(t, err)
+			send(tx)
+			txids = append(txids, tx.TransactionHash())
+		})
+
+		if first == 0 {
+			first = n.height
+		}
+	}
+
+	// The signatures of the oldest transaction have been pruned, but its
+	// status is still reported
+	r, err := n.query.GetTransaction(txids[0])
+	require.NoError(t, err)
+	require.Nil(t, r.Signer)
+	require.NotNil(t, r.Status)
+	var status struct {
+		Code   string `json:"code"`
+		Pruned bool   `json:"pruned"`
+	}
+	require.NoError(t, json.Unmarshal(*r.Status, &status))
+	require.Equal(t, "0", status.Code)
+	require.True(t, status.Pruned)
+
+	// The signatures of the latest transaction are kept
+	r, err = n.query.GetTransaction(txids[len(txids)-1])
+	require.NoError(t, err)
+	require.NotNil(t, r.Signer)
+	status.Pruned = false
+	require.NoError(t, json.Unmarshal(*r.Status, &status))
+	require.False(t, status.Pruned)
+}
+
+func TestLiteDataAccount(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	adiKey := generateKey()
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, adiKey, "foo"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	firstEntry := new(protocol.DataEntry)
+	firstEntry.ExtIds = [][]byte{[]byte("Factom"), []byte("PRO")}
+	firstEntry.Data = []byte("first entry")
+
+	liteUrl, err := protocol.LiteDataAddress(protocol.ComputeLiteDataAccountId(firstEntry))
+	require.NoError(t, err)
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		wd := new(protocol.WriteDataTo)
+		wd.Recipient = liteUrl.String()
+		wd.Entry = *firstEntry
+		tx, err := transactions.New("foo", 1, edSigner(adiKey, 1), wd)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	secondEntry := new(protocol.DataEntry)
+	secondEntry.Data = []byte("second entry")
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		wd := new(protocol.WriteDataTo)
+		wd.Recipient = liteUrl.String()
+		wd.Entry = *secondEntry
+		tx, err := transactions.New("foo", 1, edSigner(adiKey, 2), wd)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	account := new(protocol.LiteDataAccount)
+	n.GetChainAs(liteUrl.String(), account)
+	require.Equal(t, types.ChainTypeLiteDataAccount, account.Type)
+	require.Equal(t, types.String(liteUrl.String()), account.ChainUrl)
+
+	// The latest entry
+	rde := new(protocol.ResponseDataEntry)
+	require.NoError(t, rde.UnmarshalJSON(*n.GetChainDataByUrl(liteUrl.String()).Data))
+	require.True(t, rde.Entry.Equal(secondEntry))
+
+	// The first entry by hash
+	rde = new(protocol.ResponseDataEntry)
+	require.NoError(t, rde.UnmarshalJSON(*n.GetChainDataByEntryHash(liteUrl.String(), firstEntry.Hash()).Data))
+	require.True(t, rde.Entry.Equal(firstEntry))
+
+	// Both entries
+	set := n.GetChainDataSet(liteUrl.String(), 0, 10, true)
+	require.Len(t, set.Data, 2)
+	rde = new(protocol.ResponseDataEntry)
+	require.NoError(t, rde.UnmarshalJSON(*set.Data[0].Data))
+	require.True(t, rde.Entry.Equal(firstEntry))
+}
+
 func TestCreateAdiTokenAccount(t *testing.T) {
 	t.Run("Default Key Book", func(t *testing.T) {
 		n := createAppWithMemDB(t, crypto.Address{}, true)
@@ -442,206 +662,904 @@ func TestCreateAdiTokenAccount(t *testing.T) {
 	})
 }
 
-func TestLiteAccountTx(t *testing.T) {
+func TestCreateToken(t *testing.T) {
 	n := createAppWithMemDB(t, crypto.Address{}, true)
-	alice, bob, charlie := generateKey(), generateKey(), generateKey()
+	adiKey := generateKey()
 	dbTx := n.db.Begin()
-	require.NoError(n.t, acctesting.CreateLiteTokenAccount(dbTx, alice, 5e4))
-	require.NoError(n.t, acctesting.CreateLiteTokenAccount(dbTx, bob, 0))
-	require.NoError(n.t, acctesting.CreateLiteTokenAccount(dbTx, charlie, 0))
+	require.NoError(t, acctesting.CreateADI(dbTx, adiKey, "foo"))
 	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
 
-	aliceUrl := acctesting.AcmeLiteAddressTmPriv(alice).String()
-	bobUrl := acctesting.AcmeLiteAddressTmPriv(bob).String()
-	charlieUrl := acctesting.AcmeLiteAddressTmPriv(charlie).String()
-
 	n.Batch(func(send func(*transactions.GenTransaction)) {
-		exch := new(protocol.SendTokens)
-		exch.AddRecipient(acctesting.MustParseUrl(bobUrl), 1000)
-		exch.AddRecipient(acctesting.MustParseUrl(charlieUrl), 2000)
-
-		tx, err := transactions.New(aliceUrl, 2, edSigner(alice, 1), exch)
+		body := new(protocol.CreateToken)
+		body.Url = "foo/tokens"
+		body.Symbol = "FOO"
+		body.Precision = 10
+		body.SupplyLimit.SetInt64(1e12)
+		tx, err := transactions.New("foo", 1, edSigner(adiKey, 1), body)
 		require.NoError(t, err)
 		send(tx)
 	})
 
-	require.Equal(t, int64(5e4*acctesting.TokenMx-3000), n.GetLiteTokenAccount(aliceUrl).Balance.Int64())
-	require.Equal(t, int64(1000), n.GetLiteTokenAccount(bobUrl).Balance.Int64())
-	require.Equal(t, int64(2000), n.GetLiteTokenAccount(charlieUrl).Balance.Int64())
+	r := n.GetTokenIssuer("foo/tokens")
+	require.Equal(t, types.ChainTypeTokenIssuer, r.Type)
+	require.Equal(t, types.String("acc://foo/tokens"), r.ChainUrl)
+	require.Equal(t, "FOO", r.Symbol)
+	require.Equal(t, uint64(10), r.Precision)
+	require.Equal(t, int64(1e12), r.SupplyLimit.Int64())
+	require.Equal(t, n.GetADI("foo").KeyBook, r.KeyBook)
+
+	require.Equal(t, []string{
+		n.ParseUrl("foo/book0").String(),
+		n.ParseUrl("foo/page0").String(),
+		n.ParseUrl("foo/tokens").String(),
+	}, n.GetDirectory("foo"))
 }
 
-func TestAdiAccountTx(t *testing.T) {
+func TestIssueTokens(t *testing.T) {
 	n := createAppWithMemDB(t, crypto.Address{}, true)
-	fooKey, barKey := generateKey(), generateKey()
+	adiKey, liteKey := generateKey(), generateKey()
 	dbTx := n.db.Begin()
-	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
-	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/tokens", protocol.AcmeUrl().String(), 1, false))
-	require.NoError(t, acctesting.CreateADI(dbTx, barKey, "bar"))
-	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "bar/tokens", protocol.AcmeUrl().String(), 0, false))
+	require.NoError(t, acctesting.CreateADI(dbTx, adiKey, "foo"))
+	require.NoError(t, acctesting.CreateTokenIssuer(dbTx, "foo/tokens", "FOO", 10))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/account", "foo/tokens", 0, false))
 	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
 
-	n.Batch(func(send func(*transactions.GenTransaction)) {
-		exch := new(protocol.SendTokens)
-		exch.AddRecipient(n.ParseUrl("bar/tokens"), 68)
+	liteAddr, err := protocol.LiteAddress(liteKey.PubKey().Bytes(), "foo/tokens")
+	require.NoError(t, err)
 
-		tx, err := transactions.New("foo/tokens", 1, edSigner(fooKey, 1), exch)
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.IssueTokens)
+		body.Recipient = "foo/account"
+		body.Amount.SetUint64(123)
+		tx, err := transactions.New("foo/tokens", 1, edSigner(adiKey, 1), body)
 		require.NoError(t, err)
 		send(tx)
 	})
 
-	require.Equal(t, int64(acctesting.TokenMx-68), n.GetTokenAccount("foo/tokens").Balance.Int64())
-	require.Equal(t, int64(68), n.GetTokenAccount("bar/tokens").Balance.Int64())
-}
-
-func TestSendCreditsFromAdiAccountToMultiSig(t *testing.T) {
-	n := createAppWithMemDB(t, crypto.Address{}, true)
-	fooKey := generateKey()
-	dbTx := n.db.Begin()
-	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
-	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/tokens", protocol.AcmeUrl().String(), 1e2, false))
-	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
-
 	n.Batch(func(send func(*transactions.GenTransaction)) {
-		ac := new(protocol.AddCredits)
-		ac.Amount = 55
-		ac.Recipient = "foo/page0"
-
-		tx, err := transactions.New("foo/tokens", 1, edSigner(fooKey, 1), ac)
+		body := new(protocol.IssueTokens)
+		body.Recipient = liteAddr.String()
+		body.Amount.SetUint64(456)
+		tx, err := transactions.New("foo/tokens", 1, edSigner(adiKey, 2), body)
 		require.NoError(t, err)
 		send(tx)
 	})
 
-	ks := n.GetKeyPage("foo/page0")
-	acct := n.GetTokenAccount("foo/tokens")
-	require.Equal(t, int64(55), ks.CreditBalance.Int64())
-	require.Equal(t, int64(protocol.AcmePrecision*1e2-protocol.AcmePrecision/protocol.CreditsPerFiatUnit*55), acct.Balance.Int64())
+	require.Equal(t, int64(123), n.GetTokenAccount("foo/account").Balance.Int64())
+	require.Equal(t, int64(456), n.GetLiteTokenAccount(liteAddr.String()).Balance.Int64())
+	require.Equal(t, int64(579), n.GetTokenIssuer("foo/tokens").Issued.Int64())
 }
 
-func TestCreateKeyPage(t *testing.T) {
+func TestRefundFailedIssuance(t *testing.T) {
 	n := createAppWithMemDB(t, crypto.Address{}, true)
-	fooKey, testKey := generateKey(), generateKey()
+	adiKey := generateKey()
 	dbTx := n.db.Begin()
-	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateADI(dbTx, adiKey, "foo"))
+	require.NoError(t, acctesting.CreateTokenIssuer(dbTx, "foo/tokens", "FOO", 10))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/account", "foo/tokens", 0, false))
 	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
 
+	// The deposit into the key page fails
+	var failed []error
+	n.onError = func(err error) { failed = append(failed, err) }
+
 	n.Batch(func(send func(*transactions.GenTransaction)) {
-		cms := new(protocol.CreateKeyPage)
-		cms.Url = "foo/keyset1"
-		cms.Keys = append(cms.Keys, &protocol.KeySpecParams{
-			PublicKey: testKey.PubKey().Bytes(),
-		})
+		body := new(protocol.IssueTokens)
+		body.Recipient = "foo/account"
+		body.Amount.SetUint64(123)
+		tx, err := transactions.New("foo/tokens", 1, edSigner(adiKey, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
 
-		tx, err := transactions.New("foo", 1, edSigner(fooKey, 1), cms)
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.IssueTokens)
+		body.Recipient = "foo/page0"
+		body.Amount.SetUint64(456)
+		tx, err := transactions.New("foo/tokens", 1, edSigner(adiKey, 2), body)
 		require.NoError(t, err)
 		send(tx)
 	})
 
-	spec := n.GetKeyPage("foo/keyset1")
-	require.Len(t, spec.Keys, 1)
-	key := spec.Keys[0]
-	require.Equal(t, types.Bytes32{}, spec.KeyBook)
-	require.Equal(t, uint64(0), key.Nonce)
-	require.Equal(t, testKey.PubKey().Bytes(), key.PublicKey)
+	require.Len(t, failed, 1)
+	require.Contains(t, failed[0].Error(), "invalid origin record")
+
+	// The failed deposit is un-issued
+	require.Equal(t, int64(123), n.GetTokenAccount("foo/account").Balance.Int64())
+	require.Equal(t, int64(123), n.GetTokenIssuer("foo/tokens").Issued.Int64())
 }
 
-func TestCreateKeyBook(t *testing.T) {
+func TestBurnTokens(t *testing.T) {
 	n := createAppWithMemDB(t, crypto.Address{}, true)
-	fooKey, testKey := generateKey(), generateKey()
+	adiKey, liteKey := generateKey(), generateKey()
 	dbTx := n.db.Begin()
-	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
-	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page1", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateADI(dbTx, adiKey, "foo"))
+	require.NoError(t, acctesting.CreateTokenIssuer(dbTx, "foo/tokens", "FOO", 10))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/account", "foo/tokens", 0, false))
 	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
 
-	specUrl := n.ParseUrl("foo/page1")
-	specChainId := types.Bytes(specUrl.ResourceChain()).AsBytes32()
+	liteAddr, err := protocol.LiteAddress(liteKey.PubKey().Bytes(), "foo/tokens")
+	require.NoError(t, err)
 
-	groupUrl := n.ParseUrl("foo/book1")
-	groupChainId := types.Bytes(groupUrl.ResourceChain()).AsBytes32()
+	dbTx = n.db.Begin()
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, liteAddr.String(), "foo/tokens", 0, true))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
 
 	n.Batch(func(send func(*transactions.GenTransaction)) {
-		csg := new(protocol.CreateKeyBook)
-		csg.Url = "foo/book1"
-		csg.Pages = append(csg.Pages, specChainId)
+		body := new(protocol.IssueTokens)
+		body.Recipient = "foo/account"
+		body.Amount.SetUint64(100)
+		tx, err := transactions.New("foo/tokens", 1, edSigner(adiKey, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
 
-		tx, err := transactions.New("foo", 1, edSigner(fooKey, 1), csg)
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.IssueTokens)
+		body.Recipient = liteAddr.String()
+		body.Amount.SetUint64(100)
+		tx, err := transactions.New("foo/tokens", 1, edSigner(adiKey, 2), body)
 		require.NoError(t, err)
 		send(tx)
 	})
 
-	group := n.GetKeyBook("foo/book1")
-	require.Len(t, group.Pages, 1)
-	require.Equal(t, specChainId, types.Bytes32(group.Pages[0]))
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.BurnTokens)
+		body.Amount.SetUint64(30)
+		tx, err := transactions.New("foo/account", 1, edSigner(adiKey, 3), body)
+		require.NoError(t, err)
+		send(tx)
 
-	spec := n.GetKeyPage("foo/page1")
-	require.Equal(t, spec.KeyBook, groupChainId)
-}
+		body = new(protocol.BurnTokens)
+		body.Amount.SetUint64(40)
+		tx, err = transactions.New(liteAddr.String(), 1, edSigner(liteKey, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
 
-func TestAddKeyPage(t *testing.T) {
-	n := createAppWithMemDB(t, crypto.Address{}, true)
-	fooKey, testKey1, testKey2 := generateKey(), generateKey(), generateKey()
+	account := n.GetTokenAccount("foo/account")
+	require.Equal(t, int64(70), account.Balance.Int64())
+	require.Equal(t, uint64(3), account.TxCount)
 
-	u := n.ParseUrl("foo/book1")
-	groupChainId := types.Bytes(u.ResourceChain()).AsBytes32()
+	lite := n.GetLiteTokenAccount(liteAddr.String())
+	require.Equal(t, int64(60), lite.Balance.Int64())
+	require.Equal(t, uint64(3), lite.TxCount)
+
+	require.Equal(t, int64(130), n.GetTokenIssuer("foo/tokens").Issued.Int64())
+}
 
+func TestRefundFailedBurn(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	adiKey := generateKey()
 	dbTx := n.db.Begin()
-	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
-	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page1", testKey1.PubKey().Bytes()))
-	require.NoError(t, acctesting.CreateKeyBook(dbTx, "foo/book1", "foo/page1"))
+	require.NoError(t, acctesting.CreateADI(dbTx, adiKey, "foo"))
+	require.NoError(t, acctesting.CreateTokenIssuer(dbTx, "foo/tokens", "FOO", 10))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/account", "foo/tokens", 1, false))
 	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
 
-	// Sanity check
-	require.Equal(t, groupChainId, n.GetKeyPage("foo/page1").KeyBook)
+	// The burn fails because the tokens were never issued
+	var failed []error
+	n.onError = func(err error) { failed = append(failed, err) }
 
+	var txid []byte
 	n.Batch(func(send func(*transactions.GenTransaction)) {
-		cms := new(protocol.CreateKeyPage)
-		cms.Url = "foo/page2"
-		cms.Keys = append(cms.Keys, &protocol.KeySpecParams{
-			PublicKey: testKey2.PubKey().Bytes(),
-		})
-
-		tx, err := transactions.New("foo/book1", 2, edSigner(testKey1, 1), cms)
+		body := new(protocol.BurnTokens)
+		body.Amount.SetUint64(30)
+		tx, err := transactions.New("foo/account", 1, edSigner(adiKey, 1), body)
 		require.NoError(t, err)
 		send(tx)
+		txid = tx.TransactionHash()
 	})
 
-	spec := n.GetKeyPage("foo/page2")
-	require.Len(t, spec.Keys, 1)
-	key := spec.Keys[0]
-	require.Equal(t, groupChainId, spec.KeyBook)
-	require.Equal(t, uint64(0), key.Nonce)
-	require.Equal(t, testKey2.PubKey().Bytes(), key.PublicKey)
+	require.Len(t, failed, 1)
+	require.Contains(t, failed[0].Error(), "cannot burn 30 FOO")
+
+	// The tokens are returned to the account
+	require.Equal(t, int64(acctesting.TokenMx), n.GetTokenAccount("foo/account").Balance.Int64())
+	require.Zero(t, n.GetTokenIssuer("foo/tokens").Burned.Int64())
+
+	// The transaction is marked as bounced
+	data, err := n.db.GetPendingTx(txid)
+	require.NoError(t, err)
+	obj := new(state.Object)
+	require.NoError(t, obj.UnmarshalBinary(data))
+	txPending := new(state.PendingTransaction)
+	require.NoError(t, obj.As(txPending))
+	require.Contains(t, string(txPending.Status), `"bounced":true`)
 }
 
-func TestAddKey(t *testing.T) {
+func TestLiteAccountTx(t *testing.T) {
 	n := createAppWithMemDB(t, crypto.Address{}, true)
-	fooKey, testKey := generateKey(), generateKey()
-
+	alice, bob, charlie := generateKey(), generateKey(), generateKey()
 	dbTx := n.db.Begin()
-	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
-	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page1", testKey.PubKey().Bytes()))
-	require.NoError(t, acctesting.CreateKeyBook(dbTx, "foo/book1", "foo/page1"))
+	require.NoError(n.t, acctesting.CreateLiteTokenAccount(dbTx, alice, 5e4))
+	require.NoError(n.t, acctesting.CreateLiteTokenAccount(dbTx, bob, 0))
+	require.NoError(n.t, acctesting.CreateLiteTokenAccount(dbTx, charlie, 0))
 	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
 
-	newKey := generateKey()
+	aliceUrl := acctesting.AcmeLiteAddressTmPriv(alice).String()
+	bobUrl := acctesting.AcmeLiteAddressTmPriv(bob).String()
+	charlieUrl := acctesting.AcmeLiteAddressTmPriv(charlie).String()
+
 	n.Batch(func(send func(*transactions.GenTransaction)) {
-		body := new(protocol.UpdateKeyPage)
-		body.Operation = protocol.AddKey
-		body.NewKey = newKey.PubKey().Bytes()
+		exch := new(protocol.SendTokens)
+		exch.AddRecipient(acctesting.MustParseUrl(bobUrl), 1000)
+		exch.AddRecipient(acctesting.MustParseUrl(charlieUrl), 2000)
 
-		tx, err := transactions.New("foo/page1", 2, edSigner(testKey, 1), body)
+		tx, err := transactions.New(aliceUrl, 2, edSigner(alice, 1), exch)
 		require.NoError(t, err)
 		send(tx)
 	})
 
-	spec := n.GetKeyPage("foo/page1")
-	require.Len(t, spec.Keys, 2)
-	require.Equal(t, newKey.PubKey().Bytes(), spec.Keys[1].PublicKey)
+	require.Equal(t, int64(5e4*acctesting.TokenMx-3000), n.GetLiteTokenAccount(aliceUrl).Balance.Int64())
+	require.Equal(t, int64(1000), n.GetLiteTokenAccount(bobUrl).Balance.Int64())
+	require.Equal(t, int64(2000), n.GetLiteTokenAccount(charlieUrl).Balance.Int64())
 }
 
-func TestUpdateKey(t *testing.T) {
+func TestRefundFailedDeposit(t *testing.T) {
 	n := createAppWithMemDB(t, crypto.Address{}, true)
-	fooKey, testKey := generateKey(), generateKey()
-
+	alice, bob, fooKey := generateKey(), generateKey(), generateKey()
+	dbTx := n.db.Begin()
+	require.NoError(n.t, acctesting.CreateLiteTokenAccount(dbTx, alice, 5e4))
+	require.NoError(n.t, acctesting.CreateLiteTokenAccount(dbTx, bob, 0))
+	require.NoError(n.t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	aliceUrl := acctesting.AcmeLiteAddressTmPriv(alice).String()
+	bobUrl := acctesting.AcmeLiteAddressTmPriv(bob).String()
+
+	// The deposit into the key page fails
+	var failed []error
+	n.onError = func(err error) { failed = append(failed, err) }
+
+	var txid []byte
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		exch := new(protocol.SendTokens)
+		exch.AddRecipient(acctesting.MustParseUrl(bobUrl), 1000)
+		exch.AddRecipient(acctesting.MustParseUrl("foo/page0"), 2000)
+
+		tx, err := transactions.New(aliceUrl, 2, edSigner(alice, 1), exch)
+		require.NoError(t, err)
+		send(tx)
+		txid = tx.TransactionHash()
+	})
+
+	require.Len(t, failed, 1)
+	require.Contains(t, failed[0].Error(), "invalid origin record")
+
+	// The failed deposit is returned to the sender
+	require.Equal(t, int64(5e4*acctesting.TokenMx-1000), n.GetLiteTokenAccount(aliceUrl).Balance.Int64())
+	require.Equal(t, int64(1000), n.GetLiteTokenAccount(bobUrl).Balance.Int64())
+
+	// The transaction is marked as bounced
+	r := n.GetChainStateByTxId(txid)
+	require.NotNil(t, r.Status)
+	require.Contains(t, string(*r.Status), `"bounced":true`)
+}
+
+func TestForgedSyntheticDeposit(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	alice := generateKey()
+	aliceUrl := acctesting.AcmeLiteAddressTmPriv(alice)
+
+	var failed []error
+	n.onError = func(err error) { failed = append(failed, err) }
+
+	// A deposit signed by someone other than a validator is rejected
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := acctesting.CreateFakeSyntheticDepositTx(alice)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Len(t, failed, 1)
+	require.Contains(t, failed[0].Error(), "synthetic transaction is not signed by a validator")
+
+	// A deposit signed by a validator that was not produced by the subnet is
+	// rejected
+	failed = nil
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := acctesting.CreateFakeSyntheticDepositTx(alice)
+		require.NoError(t, err)
+		sig, err := edSigner(tmed25519.PrivKey(n.key), tx.SigInfo.Nonce)(tx.TransactionHash())
+		require.NoError(t, err)
+		tx.Signature = []transactions.Signature{sig}
+		send(tx)
+	})
+
+	require.Len(t, failed, 1)
+	require.Contains(t, failed[0].Error(), "was not produced by")
+
+	_, err := n.db.GetPersistentEntry(aliceUrl.ResourceChain(), false)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestUpdateValidator(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	subnet := reAlphaNum.ReplaceAllString(t.Name(), "-")
+	nodeUrl := protocol.BvnUrl(subnet)
+	pageUrl := nodeUrl.JoinPath("validators0").String()
+	validator := tmed25519.PrivKey(n.key)
+	newValidator := generateKey()
+
+	var nonce uint64
+	updateValidator := func(height uint64, pubKey []byte, power int64, keys ...tmed25519.PrivKey) {
+		n.Batch(func(send func(*transactions.GenTransaction)) {
+			body := new(protocol.UpdateValidator)
+			body.PublicKey = pubKey
+			body.Power = power
+
+			nonce++
+			tx, err := transactions.New(nodeUrl.String(), height, edSigner(keys[0], nonce), body)
+			require.NoError(t, err)
+			for _, key := range keys[1:] {
+				sig, err := edSigner(key, nonce)(tx.TransactionHash())
+				require.NoError(t, err)
+				tx.Signature = append(tx.Signature, sig)
+			}
+			send(tx)
+		})
+	}
+
+	// The validators must reach a supermajority
+	page := n.GetKeyPage(pageUrl)
+	require.Equal(t, uint64(1), page.Threshold)
+
+	// Add a validator
+	updateValidator(1, newValidator.PubKey().Bytes(), 1, validator)
+	page = n.GetKeyPage(pageUrl)
+	require.Len(t, page.Keys, 2)
+	require.Equal(t, newValidator.PubKey().Bytes(), page.Keys[1].PublicKey)
+	require.Equal(t, uint64(2), page.Threshold)
+
+	// Only validators can change the validator set
+	var failed []error
+	n.onError = func(err error) { failed = append(failed, err) }
+	updateValidator(2, generateKey().PubKey().Bytes(), 1, generateKey())
+	require.Len(t, failed, 1)
+	require.Len(t, n.GetKeyPage(pageUrl).Keys, 2)
+
+	// UpdateValidator is a system transaction, it cannot be sent to another
+	// ADI
+	fooKey := generateKey()
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	failed = nil
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.UpdateValidator)
+		body.PublicKey = fooKey.PubKey().Bytes()
+		body.Power = 1
+
+		tx, err := transactions.New("foo", 1, edSigner(fooKey, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+	require.Len(t, failed, 1)
+	require.Contains(t, failed[0].Error(), "updateValidator transactions can only be sent to "+nodeUrl.String())
+
+	// A single validator is not enough
+	n.onError = nil
+	updateValidator(2, newValidator.PubKey().Bytes(), 0, newValidator)
+	require.Len(t, n.GetKeyPage(pageUrl).Keys, 2)
+
+	// Remove a validator
+	updateValidator(2, newValidator.PubKey().Bytes(), 0, newValidator, validator)
+	page = n.GetKeyPage(pageUrl)
+	require.Len(t, page.Keys, 1)
+	require.Equal(t, validator.PubKey().Bytes(), page.Keys[0].PublicKey)
+	require.Equal(t, uint64(1), page.Threshold)
+
+	// The validators cannot be changed with UpdateKeyPage, even if the page
+	// can pay for it
+	page.CreditBalance.SetUint64(1e6)
+	dbTx = n.db.Begin()
+	require.NoError(t, acctesting.WriteStates(dbTx, page))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	failed = nil
+	n.onError = func(err error) { failed = append(failed, err) }
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.UpdateKeyPage)
+		body.Operation = protocol.AddKey
+		body.NewKey = newValidator.PubKey().Bytes()
+
+		nonce++
+		tx, err := transactions.New(pageUrl, 4, edSigner(validator, nonce), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+	require.Len(t, failed, 1)
+	require.Contains(t, failed[0].Error(), "the validators can only be changed with updateValidator")
+	require.Len(t, n.GetKeyPage(pageUrl).Keys, 1)
+}
+
+func TestUpdateValidator_SameKeyTwice(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	subnet := reAlphaNum.ReplaceAllString(t.Name(), "-")
+	nodeUrl := protocol.BvnUrl(subnet)
+	validator := tmed25519.PrivKey(n.key)
+
+	// Updating the nonce of a page changes its height, so add a second page
+	// to sign the second update with
+	book := n.GetKeyBook(nodeUrl.JoinPath("validators").String())
+	page := n.GetKeyPage(nodeUrl.JoinPath("validators0").String())
+	page.ChainUrl = types.String(nodeUrl.JoinPath("validators1").String())
+	book.Pages = append(book.Pages, types.Bytes(nodeUrl.JoinPath("validators1").ResourceChain()).AsBytes32())
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.WriteStates(dbTx, page, book))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	// Change the power of a validator twice in the same block. Tendermint
+	// rejects the block if a validator is updated twice.
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		for i := 0; i < 2; i++ {
+			body := new(protocol.UpdateValidator)
+			body.PublicKey = validator.PubKey().Bytes()
+			body.Power = int64(i + 2)
+
+			sigInfo := &transactions.SignatureInfo{URL: nodeUrl.String(), KeyPageHeight: 1, KeyPageIndex: uint64(i), Nonce: uint64(i + 1)}
+			tx, err := transactions.NewWith(sigInfo, edSigner(validator, uint64(i+1)), body)
+			require.NoError(t, err)
+			send(tx)
+		}
+	})
+
+	page = n.GetKeyPage(nodeUrl.JoinPath("validators0").String())
+	require.Len(t, page.Keys, 1)
+}
+
+func TestLiteAccountTx_Secp256k1(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	alice, bob := secp256k1.GenPrivKey(), generateKey()
+	aliceUrl, err := protocol.LiteAddressFor(protocol.ECDSA, alice.PubKey().Bytes(), protocol.ACME)
+	require.NoError(t, err)
+	bobUrl := acctesting.AcmeLiteAddressTmPriv(bob).String()
+
+	dbTx := n.db.Begin()
+	require.NoError(n.t, acctesting.CreateTokenAccount(dbTx, aliceUrl.String(), protocol.AcmeUrl().String(), 5e4, true))
+	require.NoError(n.t, acctesting.CreateLiteTokenAccount(dbTx, bob, 0))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		exch := new(protocol.SendTokens)
+		exch.AddRecipient(acctesting.MustParseUrl(bobUrl), 1000)
+
+		tx, err := transactions.New(aliceUrl.String(), 2, secpSigner(alice, 1), exch)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Equal(t, int64(5e4*acctesting.TokenMx-1000), n.GetLiteTokenAccount(aliceUrl.String()).Balance.Int64())
+	require.Equal(t, int64(1000), n.GetLiteTokenAccount(bobUrl).Balance.Int64())
+
+	// The lite address of a secp256k1 key uses HASH160, not SHA-256
+	edUrl, err := protocol.LiteAddress(alice.PubKey().Bytes(), protocol.ACME)
+	require.NoError(t, err)
+	require.NotEqual(t, edUrl.String(), aliceUrl.String())
+}
+
+func TestKeyPageSecp256k1(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, testKey := generateKey(), generateKey()
+	secpKey := secp256k1.GenPrivKey()
+
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page1", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyBook(dbTx, "foo/book1", "foo/page1"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.UpdateKeyPage)
+		body.Operation = protocol.AddKey
+		body.NewKey = secpKey.PubKey().Bytes()
+		body.KeyAlgorithm = protocol.ECDSA
+
+		tx, err := transactions.New("foo/page1", 2, edSigner(testKey, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	spec := n.GetKeyPage("foo/page1")
+	require.Len(t, spec.Keys, 2)
+	require.Equal(t, protocol.ECDSA, spec.Keys[1].KeyAlgorithm)
+
+	// The secp256k1 key can sign for the page
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.UpdateKeyPage)
+		body.Operation = protocol.SetThreshold
+		body.Threshold = 2
+
+		tx, err := transactions.New("foo/page1", 3, secpSigner(secpKey, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Equal(t, uint64(2), n.GetKeyPage("foo/page1").Threshold)
+}
+
+func TestAdiAccountTx(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, barKey := generateKey(), generateKey()
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/tokens", protocol.AcmeUrl().String(), 1, false))
+	require.NoError(t, acctesting.CreateADI(dbTx, barKey, "bar"))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "bar/tokens", protocol.AcmeUrl().String(), 0, false))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		exch := new(protocol.SendTokens)
+		exch.AddRecipient(n.ParseUrl("bar/tokens"), 68)
+
+		tx, err := transactions.New("foo/tokens", 1, edSigner(fooKey, 1), exch)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Equal(t, int64(acctesting.TokenMx-68), n.GetTokenAccount("foo/tokens").Balance.Int64())
+	require.Equal(t, int64(68), n.GetTokenAccount("bar/tokens").Balance.Int64())
+}
+
+func TestSendCreditsFromAdiAccountToMultiSig(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey := generateKey()
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/tokens", protocol.AcmeUrl().String(), 1e2, false))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		ac := new(protocol.AddCredits)
+		ac.Amount = 55
+		ac.Recipient = "foo/page0"
+
+		tx, err := transactions.New("foo/tokens", 1, edSigner(fooKey, 1), ac)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	ks := n.GetKeyPage("foo/page0")
+	acct := n.GetTokenAccount("foo/tokens")
+	require.Equal(t, int64(acctesting.TestCredits+55), ks.CreditBalance.Int64())
+	require.Equal(t, int64(protocol.AcmePrecision*1e2-protocol.AcmePrecision/protocol.CreditsPerFiatUnit*55), acct.Balance.Int64())
+}
+
+func TestAcmeOracle(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, nodeKey := generateKey(), tmed25519.PrivKey(n.key)
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/tokens", protocol.AcmeUrl().String(), 1e2, false))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	nodeUrl := protocol.BvnUrl(reAlphaNum.ReplaceAllString(t.Name(), "-"))
+	oracle := new(protocol.AcmeOracle)
+	n.GetChainAs(nodeUrl.JoinPath(protocol.Oracle).String(), oracle)
+	require.Equal(t, uint64(protocol.InitialAcmeOracleValue), oracle.Price)
+
+	// Anchor a new price from the DN: 1 ACME = $2
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.SyntheticAnchor)
+		body.Source = protocol.DnUrl().String()
+		body.Index = 1
+		body.AcmeOraclePrice = 2 * protocol.AcmeOraclePrecision
+
+		tx, err := transactions.New(nodeUrl.String(), 1, edSigner(nodeKey, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	n.GetChainAs(nodeUrl.JoinPath(protocol.Oracle).String(), oracle)
+	require.Equal(t, uint64(2*protocol.AcmeOraclePrecision), oracle.Price)
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		ac := new(protocol.AddCredits)
+		ac.Amount = 100
+		ac.Recipient = "foo/page0"
+
+		tx, err := transactions.New("foo/tokens", 1, edSigner(fooKey, 1), ac)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	// At $2 per ACME, credits cost half as much ACME
+	ks := n.GetKeyPage("foo/page0")
+	acct := n.GetTokenAccount("foo/tokens")
+	require.Equal(t, int64(acctesting.TestCredits+100), ks.CreditBalance.Int64())
+	require.Equal(t, int64(protocol.AcmePrecision*1e2-protocol.AcmePrecision/protocol.CreditsPerFiatUnit*100/2), acct.Balance.Int64())
+
+	// Updating the oracle is a system transaction, so it is free but it can
+	// only be sent to the subnet's ADI
+	update := new(protocol.UpdateAcmeOracle)
+	update.Price = 3 * protocol.AcmeOraclePrecision
+	require.True(t, update.GetType().IsSystem())
+
+	var failed []error
+	n.onError = func(err error) { failed = append(failed, err) }
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.New("foo/tokens", 2, edSigner(fooKey, 2), update)
+		require.NoError(t, err)
+		send(tx)
+	})
+	require.Len(t, failed, 1)
+	require.Contains(t, failed[0].Error(), "updateAcmeOracle transactions can only be sent to "+nodeUrl.String())
+}
+
+func TestTransferCredits(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, testKey, liteKey := generateKey(), generateKey(), generateKey()
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page1", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyBook(dbTx, "foo/book1", "foo/page1"))
+	require.NoError(t, acctesting.CreateLiteTokenAccount(dbTx, liteKey, 1e2))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	liteUrl := acctesting.AcmeLiteAddressTmPriv(liteKey).String()
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tc := new(protocol.TransferCredits)
+		tc.Recipient = "foo/page0"
+		tc.Amount = 1000
+
+		tx, err := transactions.New("foo/page1", 2, edSigner(testKey, 1), tc)
+		require.NoError(t, err)
+		send(tx)
+
+		tc = new(protocol.TransferCredits)
+		tc.Recipient = "foo/page0"
+		tc.Amount = 2000
+
+		tx, err = transactions.New(liteUrl, 1, edSigner(liteKey, 1), tc)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	// The origins pay for the transfer and the fee
+	require.Equal(t, int64(acctesting.TestCredits-1000-protocol.FeeTransferCredits), n.GetKeyPage("foo/page1").CreditBalance.Int64())
+	require.Equal(t, int64(acctesting.TestCredits-2000-protocol.FeeTransferCredits), n.GetLiteTokenAccount(liteUrl).CreditBalance.Int64())
+	require.Equal(t, int64(acctesting.TestCredits+3000), n.GetKeyPage("foo/page0").CreditBalance.Int64())
+}
+
+func TestCreateKeyPage(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, testKey := generateKey(), generateKey()
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		cms := new(protocol.CreateKeyPage)
+		cms.Url = "foo/keyset1"
+		cms.Keys = append(cms.Keys, &protocol.KeySpecParams{
+			PublicKey: testKey.PubKey().Bytes(),
+		})
+
+		tx, err := transactions.New("foo", 1, edSigner(fooKey, 1), cms)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	spec := n.GetKeyPage("foo/keyset1")
+	require.Len(t, spec.Keys, 1)
+	key := spec.Keys[0]
+	require.Equal(t, types.Bytes32{}, spec.KeyBook)
+	require.Equal(t, uint64(0), key.Nonce)
+	require.Equal(t, testKey.PubKey().Bytes(), key.PublicKey)
+}
+
+func TestCreateKeyBook(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, testKey := generateKey(), generateKey()
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page1", testKey.PubKey().Bytes()))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	specUrl := n.ParseUrl("foo/page1")
+	specChainId := types.Bytes(specUrl.ResourceChain()).AsBytes32()
+
+	groupUrl := n.ParseUrl("foo/book1")
+	groupChainId := types.Bytes(groupUrl.ResourceChain()).AsBytes32()
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		csg := new(protocol.CreateKeyBook)
+		csg.Url = "foo/book1"
+		csg.Pages = append(csg.Pages, specChainId)
+
+		tx, err := transactions.New("foo", 1, edSigner(fooKey, 1), csg)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	group := n.GetKeyBook("foo/book1")
+	require.Len(t, group.Pages, 1)
+	require.Equal(t, specChainId, types.Bytes32(group.Pages[0]))
+
+	spec := n.GetKeyPage("foo/page1")
+	require.Equal(t, spec.KeyBook, groupChainId)
+}
+
+func TestAddKeyPage(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, testKey1, testKey2 := generateKey(), generateKey(), generateKey()
+
+	u := n.ParseUrl("foo/book1")
+	groupChainId := types.Bytes(u.ResourceChain()).AsBytes32()
+
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page1", testKey1.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyBook(dbTx, "foo/book1", "foo/page1"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	// Sanity check
+	require.Equal(t, groupChainId, n.GetKeyPage("foo/page1").KeyBook)
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		cms := new(protocol.CreateKeyPage)
+		cms.Url = "foo/page2"
+		cms.Keys = append(cms.Keys, &protocol.KeySpecParams{
+			PublicKey: testKey2.PubKey().Bytes(),
+		})
+
+		tx, err := transactions.New("foo/book1", 2, edSigner(testKey1, 1), cms)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	spec := n.GetKeyPage("foo/page2")
+	require.Len(t, spec.Keys, 1)
+	key := spec.Keys[0]
+	require.Equal(t, groupChainId, spec.KeyBook)
+	require.Equal(t, uint64(0), key.Nonce)
+	require.Equal(t, testKey2.PubKey().Bytes(), key.PublicKey)
+}
+
+func TestUpdateKeyBook(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, testKey1, testKey2 := generateKey(), generateKey(), generateKey()
+
+	bookId := types.Bytes(n.ParseUrl("foo/book1").ResourceChain()).AsBytes32()
+	page1Id := types.Bytes(n.ParseUrl("foo/page1").ResourceChain()).AsBytes32()
+	page2Id := types.Bytes(n.ParseUrl("foo/page2").ResourceChain()).AsBytes32()
+
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page1", testKey1.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page2", testKey2.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyBook(dbTx, "foo/book1", "foo/page1"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	getHeight := func(s string) uint64 {
+		obj, _, err := n.db.Begin().LoadChain(n.ParseUrl(s).ResourceChain())
+		require.NoError(t, err)
+		return obj.Height
+	}
+
+	// Add page 2 with the lowest priority
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.UpdateKeyBook)
+		body.Operation = protocol.AddPage
+		body.Page = "foo/page2"
+
+		tx, err := transactions.New("foo/book1", getHeight("foo/page1"), edSigner(testKey1, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Equal(t, [][32]byte{page1Id, page2Id}, n.GetKeyBook("foo/book1").Pages)
+	require.Equal(t, bookId, n.GetKeyPage("foo/page2").KeyBook)
+
+	// Give page 2 the highest priority
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.UpdateKeyBook)
+		body.Operation = protocol.SetPagePriority
+		body.Page = "foo/page2"
+		body.Priority = 0
+
+		tx, err := transactions.New("foo/book1", getHeight("foo/page1"), edSigner(testKey1, 2), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Equal(t, [][32]byte{page2Id, page1Id}, n.GetKeyBook("foo/book1").Pages)
+
+	// Page 2 removes page 1
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.UpdateKeyBook)
+		body.Operation = protocol.RemovePage
+		body.Page = "foo/page1"
+
+		tx, err := transactions.New("foo/book1", getHeight("foo/page2"), edSigner(testKey2, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Equal(t, [][32]byte{page2Id}, n.GetKeyBook("foo/book1").Pages)
+	require.Equal(t, types.Bytes32{}, n.GetKeyPage("foo/page1").KeyBook)
+	require.Equal(t, bookId, n.GetKeyPage("foo/page2").KeyBook)
+}
+
+func TestUpdateAccountAuth(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, testKey := generateKey(), generateKey()
+
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/tokens", protocol.AcmeUrl().String(), 1, false))
+	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page1", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyBook(dbTx, "foo/book1", "foo/page1"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	getHeight := func(s string) uint64 {
+		obj, _, err := n.db.Begin().LoadChain(n.ParseUrl(s).ResourceChain())
+		require.NoError(t, err)
+		return obj.Height
+	}
+
+	// Move the token account to book 1, authorized by book 0
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.UpdateAccountAuth)
+		body.KeyBookUrl = "foo/book1"
+
+		tx, err := transactions.New("foo/tokens", getHeight("foo/page0"), edSigner(fooKey, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	bookId := types.Bytes(n.ParseUrl("foo/book1").ResourceChain()).AsBytes32()
+	require.Equal(t, bookId, n.GetTokenAccount("foo/tokens").KeyBook)
+
+	// Book 1 can now spend from the account
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		ac := new(protocol.AddCredits)
+		ac.Amount = 55
+		ac.Recipient = "foo/page1"
+
+		tx, err := transactions.New("foo/tokens", getHeight("foo/page1"), edSigner(testKey, 1), ac)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Equal(t, int64(acctesting.TestCredits+55), n.GetKeyPage("foo/page1").CreditBalance.Int64())
+}
+
+func TestAddKey(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, testKey := generateKey(), generateKey()
+
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page1", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyBook(dbTx, "foo/book1", "foo/page1"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	newKey := generateKey()
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.UpdateKeyPage)
+		body.Operation = protocol.AddKey
+		body.NewKey = newKey.PubKey().Bytes()
+
+		tx, err := transactions.New("foo/page1", 2, edSigner(testKey, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	spec := n.GetKeyPage("foo/page1")
+	require.Len(t, spec.Keys, 2)
+	require.Equal(t, newKey.PubKey().Bytes(), spec.Keys[1].PublicKey)
+
+	// The page signed for itself, so the fee must survive the update
+	require.Equal(t, int64(acctesting.TestCredits-protocol.FeeUpdateKeyPage), spec.CreditBalance.Int64())
+}
+
+func TestUpdateKey(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, testKey := generateKey(), generateKey()
+
 	dbTx := n.db.Begin()
 	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
 	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page1", testKey.PubKey().Bytes()))
@@ -690,6 +1608,306 @@ func TestRemoveKey(t *testing.T) {
 	require.Equal(t, testKey2.PubKey().Bytes(), spec.Keys[0].PublicKey)
 }
 
+func TestKeyPageThreshold(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, testKey1, testKey2, testKey3 := generateKey(), generateKey(), generateKey(), generateKey()
+
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbTx, "foo/page1", testKey1.PubKey().Bytes(), testKey2.PubKey().Bytes(), testKey3.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyBook(dbTx, "foo/book1", "foo/page1"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		body := new(protocol.UpdateKeyPage)
+		body.Operation = protocol.SetThreshold
+		body.Threshold = 2
+
+		tx, err := transactions.New("foo/page1", 2, edSigner(testKey1, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Equal(t, uint64(2), n.GetKeyPage("foo/page1").Threshold)
+
+	newKey := generateKey()
+	body := new(protocol.UpdateKeyPage)
+	body.Operation = protocol.AddKey
+	body.NewKey = newKey.PubKey().Bytes()
+
+	// One signature is held as pending
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.New("foo/page1", 3, edSigner(testKey1, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Len(t, n.GetKeyPage("foo/page1").Keys, 3)
+
+	// Two signatures from the same key are still held as pending
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.New("foo/page1", 3, edSigner(testKey1, 2), body)
+		require.NoError(t, err)
+		sig := new(transactions.ED25519Sig)
+		require.NoError(t, sig.Sign(3, testKey1, tx.TransactionHash()))
+		tx.Signature = append(tx.Signature, sig)
+		send(tx)
+	})
+
+	require.Len(t, n.GetKeyPage("foo/page1").Keys, 3)
+
+	// Two signatures from distinct keys are executed
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.New("foo/page1", 3, edSigner(testKey1, 4), body)
+		require.NoError(t, err)
+		sig := new(transactions.ED25519Sig)
+		require.NoError(t, sig.Sign(1, testKey3, tx.TransactionHash()))
+		tx.Signature = append(tx.Signature, sig)
+		send(tx)
+	})
+
+	spec := n.GetKeyPage("foo/page1")
+	require.Len(t, spec.Keys, 4)
+	require.Equal(t, newKey.PubKey().Bytes(), spec.Keys[3].PublicKey)
+}
+
+func TestPendingTransactionSignatures(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, testKey1, testKey2, testKey3 := generateKey(), generateKey(), generateKey(), generateKey()
+
+	page := protocol.NewKeyPage()
+	page.ChainUrl = "acc://foo/page1"
+	page.CreditBalance.SetInt64(acctesting.TestCredits)
+	page.Threshold = 2
+	for _, key := range []crypto.PubKey{testKey1.PubKey(), testKey2.PubKey(), testKey3.PubKey()} {
+		page.Keys = append(page.Keys, &protocol.KeySpec{PublicKey: key.Bytes()})
+	}
+
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.WriteStates(dbTx, page))
+	require.NoError(t, acctesting.CreateKeyBook(dbTx, "foo/book1", "foo/page1"))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/tokens", protocol.AcmeUrl().String(), 1, false))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/treasury", protocol.AcmeUrl().String(), 1, false))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	// Assign the treasury to the 2-of-3 book
+	treasury := n.GetTokenAccount("foo/treasury")
+	treasury.KeyBook = types.Bytes(n.ParseUrl("foo/book1").ResourceChain()).AsBytes32()
+	dbTx = n.db.Begin()
+	require.NoError(t, acctesting.WriteStates(dbTx, treasury))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	body := new(protocol.SendTokens)
+	body.AddRecipient(n.ParseUrl("foo/tokens"), 68)
+	sigInfo := &transactions.SignatureInfo{URL: "acc://foo/treasury", KeyPageHeight: 2, Nonce: 1}
+
+	// The first signature is held as pending
+	var txid []byte
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.NewWith(sigInfo, edSigner(testKey1, 1), body)
+		require.NoError(t, err)
+		txid = tx.TransactionHash()
+		send(tx)
+	})
+
+	require.Equal(t, int64(acctesting.TokenMx), n.GetTokenAccount("foo/treasury").Balance.Int64())
+
+	pending := n.GetPendingTransactions("foo/page1")
+	require.Equal(t, uint64(2), pending.Threshold)
+	require.Len(t, pending.Transactions, 1)
+	require.Equal(t, txid, pending.Transactions[0].TxId[:])
+	require.Equal(t, "acc://foo/treasury", pending.Transactions[0].Origin)
+	require.Equal(t, [][]byte{testKey1.PubKey().Bytes()}, pending.Transactions[0].Signers)
+
+	// The submission is charged the submission fee
+	require.Equal(t, int64(acctesting.TestCredits-protocol.FeePendingSubmission), n.GetKeyPage("foo/page1").CreditBalance.Int64())
+
+	// A second signature, submitted separately, executes the transaction
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.NewWith(sigInfo, edSigner(testKey2, 1), body)
+		require.NoError(t, err)
+		require.Equal(t, txid, tx.TransactionHash())
+		send(tx)
+	})
+
+	require.Equal(t, int64(acctesting.TokenMx-68), n.GetTokenAccount("foo/treasury").Balance.Int64())
+	require.Equal(t, int64(acctesting.TokenMx+68), n.GetTokenAccount("foo/tokens").Balance.Int64())
+	require.Empty(t, n.GetPendingTransactions("foo/page1").Transactions)
+
+	// The submission fee is credited against the fee of the transaction
+	require.Equal(t, int64(acctesting.TestCredits-protocol.FeeSendTokens), n.GetKeyPage("foo/page1").CreditBalance.Int64())
+}
+
+func TestExpirePendingTransactions(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, testKey1, testKey2 := generateKey(), generateKey(), generateKey()
+
+	page := protocol.NewKeyPage()
+	page.ChainUrl = "acc://foo/page1"
+	page.CreditBalance.SetInt64(acctesting.TestCredits)
+	page.Threshold = 2
+	for _, key := range []crypto.PubKey{testKey1.PubKey(), testKey2.PubKey()} {
+		page.Keys = append(page.Keys, &protocol.KeySpec{PublicKey: key.Bytes()})
+	}
+
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.WriteStates(dbTx, page))
+	require.NoError(t, acctesting.CreateKeyBook(dbTx, "foo/book1", "foo/page1"))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/tokens", protocol.AcmeUrl().String(), 1, false))
+	require.NoError(t, acctesting.CreateTokenAccount(dbTx, "foo/treasury", protocol.AcmeUrl().String(), 1, false))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	treasury := n.GetTokenAccount("foo/treasury")
+	treasury.KeyBook = types.Bytes(n.ParseUrl("foo/book1").ResourceChain()).AsBytes32()
+	dbTx = n.db.Begin()
+	require.NoError(t, acctesting.WriteStates(dbTx, treasury))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	body := new(protocol.SendTokens)
+	body.AddRecipient(n.ParseUrl("foo/tokens"), 68)
+	sigInfo := &transactions.SignatureInfo{URL: "acc://foo/treasury", KeyPageHeight: 2, Nonce: 1}
+
+	var txid []byte
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.NewWith(sigInfo, edSigner(testKey1, 1), body)
+		require.NoError(t, err)
+		txid = tx.TransactionHash()
+		send(tx)
+	})
+	require.Len(t, n.GetPendingTransactions("foo/page1").Transactions, 1)
+
+	// Execute other transactions until the pending transaction is outside of
+	// the given window
+	var i int
+	first := n.height
+	waitFor := func(window int64) {
+		for ; n.height <= first+window; i++ {
+			n.Batch(func(send func(*transactions.GenTransaction)) {
+				tac := new(protocol.TokenAccountCreate)
+				tac.Url = fmt.Sprintf("foo/tokens%d", i)
+				tac.TokenUrl = protocol.AcmeUrl().String()
+				tx, err := transactions.New("foo", 1, edSigner(fooKey, uint64(i+1)), tac)
+				require.NoError(t, err)
+				send(tx)
+			})
+		}
+	}
+	loadPending := func() *state.PendingTransaction {
+		data, err := n.db.GetPendingTx(txid)
+		require.NoError(t, err)
+		obj := new(state.Object)
+		require.NoError(t, obj.UnmarshalBinary(data))
+		txPending := new(state.PendingTransaction)
+		require.NoError(t, obj.As(txPending))
+		return txPending
+	}
+
+	// The signatures of a transaction that is still pending are not pruned
+	waitFor(testPendingRetention)
+	require.Len(t, n.GetPendingTransactions("foo/page1").Transactions, 1)
+	require.Len(t, loadPending().Signature, 1)
+
+	// The transaction has expired
+	waitFor(testPendingExpiration)
+	require.Empty(t, n.GetPendingTransactions("foo/page1").Transactions)
+	txPending := loadPending()
+	var status struct {
+		Code    string `json:"code"`
+		Expired bool   `json:"expired"`
+	}
+	require.NoError(t, json.Unmarshal(txPending.Status, &status))
+	require.Equal(t, "1", status.Code)
+	require.True(t, status.Expired)
+
+	// The transaction is recorded again when it expires, so its signatures are
+	// pruned once the retention window has passed since
+	require.Len(t, txPending.Signature, 1)
+	first = n.height
+	waitFor(testPendingRetention)
+	require.Empty(t, loadPending().Signature)
+
+	// A second signature starts over instead of executing the transaction
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.NewWith(sigInfo, edSigner(testKey2, 1), body)
+		require.NoError(t, err)
+		send(tx)
+	})
+	require.Equal(t, int64(acctesting.TokenMx), n.GetTokenAccount("foo/treasury").Balance.Int64())
+	pending := n.GetPendingTransactions("foo/page1")
+	require.Len(t, pending.Transactions, 1)
+	require.Equal(t, [][]byte{testKey2.PubKey().Bytes()}, pending.Transactions[0].Signers)
+}
+
+func TestManagedDataAccount(t *testing.T) {
+	n := createAppWithMemDB(t, crypto.Address{}, true)
+	fooKey, mgrKey := generateKey(), generateKey()
+
+	dbTx := n.db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateADI(dbTx, mgrKey, "mgr"))
+	dbTx.Commit(n.NextHeight(), time.Unix(0, 0), nil)
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		cda := new(protocol.CreateDataAccount)
+		cda.Url = "foo/data"
+		cda.ManagerKeyBookUrl = "mgr/book0"
+		tx, err := transactions.New("foo", 1, edSigner(fooKey, 1), cda)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Equal(t, types.String("acc://mgr/book0"), n.GetDataAccount("foo/data").ManagerKeyBook)
+
+	// An entry signed only by the account's key book is held as pending
+	wd := new(protocol.WriteData)
+	wd.Entry.Data = []byte("approved by the manager")
+	sigInfo := &transactions.SignatureInfo{URL: "acc://foo/data", KeyPageHeight: 1, Nonce: 2}
+
+	var txid []byte
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.NewWith(sigInfo, edSigner(fooKey, 2), wd)
+		require.NoError(t, err)
+		txid = tx.TransactionHash()
+		send(tx)
+	})
+
+	pending := n.GetPendingTransactions("foo/page0")
+	require.Len(t, pending.Transactions, 1)
+	require.Equal(t, txid, pending.Transactions[0].TxId[:])
+
+	// The manager's signature executes the transaction
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.NewWith(sigInfo, edSigner(mgrKey, 1), wd)
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Empty(t, n.GetPendingTransactions("foo/page0").Transactions)
+	rde := protocol.ResponseDataEntry{}
+	require.NoError(t, rde.UnmarshalJSON(*n.GetChainDataByUrl("foo/data").Data))
+	require.True(t, rde.Entry.Equal(&wd.Entry))
+
+	// Removing the manager requires the manager's approval
+	sigInfo = &transactions.SignatureInfo{URL: "acc://foo/data", KeyPageHeight: 1, Nonce: 3}
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.NewWith(sigInfo, edSigner(fooKey, 3), new(protocol.UpdateManager))
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Equal(t, types.String("acc://mgr/book0"), n.GetDataAccount("foo/data").ManagerKeyBook)
+
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		tx, err := transactions.NewWith(sigInfo, edSigner(mgrKey, 2), new(protocol.UpdateManager))
+		require.NoError(t, err)
+		send(tx)
+	})
+
+	require.Empty(t, n.GetDataAccount("foo/data").ManagerKeyBook)
+}
+
 func TestSignatorHeight(t *testing.T) {
 	n := createAppWithMemDB(t, crypto.Address{}, true)
 	liteKey, fooKey := generateKey(), generateKey()
@@ -727,13 +1945,23 @@ func TestSignatorHeight(t *testing.T) {
 
 	require.Equal(t, liteHeight, getHeight(liteUrl), "Lite account height changed")
 
+	n.Batch(func(send func(*transactions.GenTransaction)) {
+		ac := new(protocol.AddCredits)
+		ac.Recipient = keyPageUrl.String()
+		ac.Amount = 5000
+
+		tx, err := transactions.New(liteUrl.String(), 1, edSigner(liteKey, 2), ac)
+		require.NoError(t, err)
+		send(tx)
+	})
+
 	keyPageHeight := getHeight(keyPageUrl)
 
 	n.Batch(func(send func(*transactions.GenTransaction)) {
 		tac := new(protocol.TokenAccountCreate)
 		tac.Url = tokenUrl.String()
 		tac.TokenUrl = protocol.AcmeUrl().String()
-		tx, err := transactions.New("foo", 1, edSigner(fooKey, 1), tac)
+		tx, err := transactions.New("foo", 2, edSigner(fooKey, 1), tac)
 		require.NoError(t, err)
 		send(tx)
 	})
@@ -0,0 +1,428 @@
+package abci
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// snapshotFormat is the format of snapshots. A snapshot is the output of
+// the State.Snapshot writer split into chunks. The metadata of a snapshot is the
+// concatenated SHA-256 hashes of its chunks and the hash of a snapshot is the
+// SHA-256 hash of the output.
+const snapshotFormat = 1
+
+// snapshotChunkSize is the maximum size of a chunk of a snapshot.
+const snapshotChunkSize = 10 << 20
+
+// snapshotFile is the name of the file that describes a snapshot.
+const snapshotFile = "snapshot.json"
+
+// SnapshotOptions configures the state sync snapshots taken by a node.
+type SnapshotOptions struct {
+	// Dir is the directory snapshots are stored in.
+	Dir string
+
+	// Interval is the number of blocks between snapshots. Zero disables
+	// snapshots.
+	Interval uint64
+
+	// Retention is the number of snapshots that are kept. Zero keeps every
+	// snapshot.
+	Retention int
+}
+
+// snapshotRestore is a snapshot that is being restored.
+type snapshotRestore struct {
+	snapshot *abci.Snapshot
+	appHash  []byte
+	file     *os.File
+	hash     hash.Hash
+	next     uint32
+}
+
+func (r *snapshotRestore) Close() {
+	_ = r.file.Close()
+	_ = os.Remove(r.file.Name())
+}
+
+// ListSnapshots implements github.com/tendermint/tendermint/abci/types.Application.
+func (app *Accumulator) ListSnapshots(req abci.RequestListSnapshots) abci.ResponseListSnapshots {
+	defer app.recover(nil)
+
+	snapshots, err := app.listSnapshots()
+	if err != nil {
+		app.logger.Error("Failed to list snapshots", "error", err)
+		return abci.ResponseListSnapshots{}
+	}
+
+	return abci.ResponseListSnapshots{Snapshots: snapshots}
+}
+
+// LoadSnapshotChunk implements github.com/tendermint/tendermint/abci/types.Application.
+func (app *Accumulator) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) abci.ResponseLoadSnapshotChunk {
+	defer app.recover(nil)
+
+	if req.Format != snapshotFormat || app.snapshots.Dir == "" {
+		return abci.ResponseLoadSnapshotChunk{}
+	}
+
+	chunk, err := os.ReadFile(chunkPath(app.snapshotDir(req.Height), req.Chunk))
+	if err != nil {
+		app.logger.Error("Failed to load snapshot chunk", "height", req.Height, "chunk", req.Chunk, "error", err)
+		return abci.ResponseLoadSnapshotChunk{}
+	}
+
+	return abci.ResponseLoadSnapshotChunk{Chunk: chunk}
+}
+
+// OfferSnapshot implements github.com/tendermint/tendermint/abci/types.Application.
+func (app *Accumulator) OfferSnapshot(req abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
+	defer app.recover(nil)
+
+	snapshot := req.Snapshot
+	switch {
+	case snapshot == nil:
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT}
+	case snapshot.Format != snapshotFormat:
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT_FORMAT}
+	case snapshot.Chunks == 0 || len(snapshot.Metadata) != int(snapshot.Chunks)*sha256.Size:
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_REJECT}
+	}
+
+	// Chunks are written to a temporary file until the snapshot is complete
+	file, err := os.CreateTemp(app.snapshots.Dir, "restore-")
+	if err != nil {
+		app.logger.Error("Failed to create snapshot file", "error", err)
+		return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ABORT}
+	}
+
+	if app.restore != nil {
+		app.restore.Close()
+	}
+	app.restore = &snapshotRestore{
+		snapshot: snapshot,
+		appHash:  req.AppHash,
+		file:     file,
+		hash:     sha256.New(),
+	}
+
+	app.logger.Info("Accepted snapshot", "height", snapshot.Height, "chunks", snapshot.Chunks)
+	return abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}
+}
+
+// ApplySnapshotChunk implements github.com/tendermint/tendermint/abci/types.Application.
+func (app *Accumulator) ApplySnapshotChunk(req abci.RequestApplySnapshotChunk) abci.ResponseApplySnapshotChunk {
+	defer app.recover(nil)
+
+	r := app.restore
+	if r == nil {
+		return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ABORT}
+	}
+
+	// Chunks are applied in order
+	if req.Index != r.next {
+		return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_RETRY_SNAPSHOT}
+	}
+
+	// Verify the chunk against the metadata
+	h := sha256.Sum256(req.Chunk)
+	want := r.snapshot.Metadata[req.Index*sha256.Size : (req.Index+1)*sha256.Size]
+	if !bytes.Equal(h[:], want) {
+		app.logger.Info("Rejected snapshot chunk", "height", r.snapshot.Height, "chunk", req.Index, "sender", req.Sender)
+		return abci.ResponseApplySnapshotChunk{
+			Result:        abci.ResponseApplySnapshotChunk_RETRY,
+			RefetchChunks: []uint32{req.Index},
+			RejectSenders: []string{req.Sender},
+		}
+	}
+
+	_, err := r.file.Write(req.Chunk)
+	if err != nil {
+		app.logger.Error("Failed to write snapshot chunk", "error", err)
+		return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ABORT}
+	}
+	_, _ = r.hash.Write(req.Chunk)
+	r.next++
+
+	if r.next < r.snapshot.Chunks {
+		return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}
+	}
+
+	// The snapshot is complete
+	app.restore = nil
+	defer r.Close()
+
+	if !bytes.Equal(r.hash.Sum(nil), r.snapshot.Hash) {
+		app.logger.Info("Rejected snapshot: hash does not match", "height", r.snapshot.Height)
+		return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT}
+	}
+
+	err = app.restoreSnapshot(r)
+	if err != nil {
+		app.logger.Error("Failed to restore snapshot", "height", r.snapshot.Height, "error", err)
+		return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT}
+	}
+
+	app.logger.Info("Restored snapshot", "height", r.snapshot.Height)
+	return abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}
+}
+
+// restoreSnapshot loads a complete snapshot into the state. The state is
+// verified against the app hash of the snapshot's height, and cleared if it
+// does not match.
+func (app *Accumulator) restoreSnapshot(r *snapshotRestore) error {
+	_, err := r.file.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	err = app.state.ReadSnapshot(r.file, r.appHash)
+	if err != nil {
+		return err
+	}
+
+	app.subnetID, err = app.state.SubnetID()
+	if err != nil {
+		return fmt.Errorf("failed to load subnet ID: %v", err)
+	}
+	return nil
+}
+
+// maybeTakeSnapshot takes a snapshot if the current block is on the snapshot
+// interval. The state is captured synchronously, but the snapshot is written in
+// the background so it does not hold up consensus. If the previous snapshot is
+// still being written, the snapshot is skipped.
+func (app *Accumulator) maybeTakeSnapshot() error {
+	if app.snapshots.Dir == "" || app.snapshots.Interval == 0 {
+		return nil
+	}
+
+	height, err := app.state.BlockIndex()
+	if err != nil {
+		return err
+	}
+	if height <= 0 || uint64(height)%app.snapshots.Interval != 0 {
+		return nil
+	}
+
+	if !atomic.CompareAndSwapInt32(&app.snapshotting, 0, 1) {
+		app.logger.Info("Skipping snapshot, the previous snapshot is still being written", "height", height)
+		return nil
+	}
+
+	// The snapshot is written to a temporary directory first, so that
+	// partially written snapshots are never listed
+	err = os.MkdirAll(app.snapshots.Dir, 0700)
+	if err != nil {
+		atomic.StoreInt32(&app.snapshotting, 0)
+		return err
+	}
+
+	tmp, err := os.MkdirTemp(app.snapshots.Dir, "tmp-")
+	if err != nil {
+		atomic.StoreInt32(&app.snapshotting, 0)
+		return err
+	}
+
+	write, err := app.state.Snapshot()
+	if err != nil {
+		_ = os.RemoveAll(tmp)
+		atomic.StoreInt32(&app.snapshotting, 0)
+		return err
+	}
+
+	go func() {
+		defer atomic.StoreInt32(&app.snapshotting, 0)
+
+		err := app.writeSnapshot(uint64(height), tmp, write)
+		if err == nil {
+			err = app.pruneSnapshots()
+		}
+		if err != nil {
+			app.logger.Error("Failed to take a snapshot", "height", height, "error", err)
+		}
+	}()
+	return nil
+}
+
+// writeSnapshot writes a captured state to the temporary directory as a
+// snapshot, then moves the directory into place.
+func (app *Accumulator) writeSnapshot(height uint64, tmp string, write func(io.Writer) error) error {
+	start := time.Now()
+	defer os.RemoveAll(tmp)
+
+	w := &chunkWriter{dir: tmp, hash: sha256.New()}
+	err := write(w)
+	if err != nil {
+		return err
+	}
+	err = w.Close()
+	if err != nil {
+		return err
+	}
+
+	snapshot := &abci.Snapshot{
+		Height:   height,
+		Format:   snapshotFormat,
+		Chunks:   uint32(len(w.chunks)),
+		Hash:     w.hash.Sum(nil),
+		Metadata: bytes.Join(w.chunks, nil),
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(filepath.Join(tmp, snapshotFile), data, 0600)
+	if err != nil {
+		return err
+	}
+
+	dir := app.snapshotDir(height)
+	err = os.RemoveAll(dir)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(tmp, dir)
+	if err != nil {
+		return err
+	}
+
+	app.logger.Info("Took snapshot", "height", height, "chunks", snapshot.Chunks, "duration", time.Since(start).String())
+	return nil
+}
+
+// pruneSnapshots removes the oldest snapshots beyond the retention limit.
+func (app *Accumulator) pruneSnapshots() error {
+	if app.snapshots.Retention <= 0 {
+		return nil
+	}
+
+	snapshots, err := app.listSnapshots()
+	if err != nil {
+		return err
+	}
+
+	for len(snapshots) > app.snapshots.Retention {
+		err = os.RemoveAll(app.snapshotDir(snapshots[0].Height))
+		if err != nil {
+			return err
+		}
+		snapshots = snapshots[1:]
+	}
+	return nil
+}
+
+// listSnapshots returns the snapshots of the node, sorted by height.
+func (app *Accumulator) listSnapshots() ([]*abci.Snapshot, error) {
+	if app.snapshots.Dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(app.snapshots.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var snapshots []*abci.Snapshot
+	for _, entry := range entries {
+		// Ignore anything that is not a snapshot
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := strconv.ParseUint(entry.Name(), 10, 64); err != nil {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(app.snapshots.Dir, entry.Name(), snapshotFile))
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot := new(abci.Snapshot)
+		err = json.Unmarshal(data, snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snapshot %s: %v", entry.Name(), err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Height < snapshots[j].Height
+	})
+	return snapshots, nil
+}
+
+func (app *Accumulator) snapshotDir(height uint64) string {
+	return filepath.Join(app.snapshots.Dir, strconv.FormatUint(height, 10))
+}
+
+func chunkPath(dir string, index uint32) string {
+	return filepath.Join(dir, fmt.Sprintf("chunk-%d", index))
+}
+
+// chunkWriter splits a snapshot into chunks and writes each chunk to a file.
+type chunkWriter struct {
+	dir    string
+	buf    []byte
+	chunks [][]byte
+	hash   hash.Hash
+}
+
+func (w *chunkWriter) Write(b []byte) (int, error) {
+	n := len(b)
+	_, _ = w.hash.Write(b)
+
+	for len(b) > 0 {
+		m := snapshotChunkSize - len(w.buf)
+		if m > len(b) {
+			m = len(b)
+		}
+		w.buf = append(w.buf, b[:m]...)
+		b = b[m:]
+
+		if len(w.buf) < snapshotChunkSize {
+			continue
+		}
+
+		err := w.flush()
+		if err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// Close writes the last chunk. A snapshot always has at least one chunk.
+func (w *chunkWriter) Close() error {
+	if len(w.buf) > 0 || len(w.chunks) == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *chunkWriter) flush() error {
+	err := os.WriteFile(chunkPath(w.dir, uint32(len(w.chunks))), w.buf, 0600)
+	if err != nil {
+		return err
+	}
+
+	h := sha256.Sum256(w.buf)
+	w.chunks = append(w.chunks, h[:])
+	w.buf = w.buf[:0]
+	return nil
+}
@@ -36,25 +36,41 @@ import (
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	tmed25519 "github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 var reAlphaNum = regexp.MustCompile("[^a-zA-Z0-9]")
 
+// testScratchRetention is the number of blocks the bodies of scratch data
+// entries are kept for in tests.
+const testScratchRetention = 2
+
+// testPendingRetention is the number of blocks the signatures of transactions
+// are kept for in tests.
+const testPendingRetention = 10
+
+// testPendingExpiration is the number of blocks a transaction may wait for
+// signatures in tests.
+const testPendingExpiration = 20
+
 func createAppWithMemDB(t testing.TB, addr crypto.Address, doGenesis bool) *fakeNode {
 	db := new(state.StateDB)
 	err := db.Open("memory", true, true, nil)
 	require.NoError(t, err)
 
-	return createApp(t, db, addr, doGenesis)
-}
-
-func createApp(t testing.TB, db *state.StateDB, addr crypto.Address, doGenesis bool) *fakeNode {
 	_, bvcKey, _ := ed25519.GenerateKey(rand)
+	return createApp(t, db, bvcKey, addr, doGenesis)
+}
 
+// createApp creates a node. The validator key must be the same every time the
+// node is created for a given database, since synthetic transactions must be
+// signed by a validator.
+func createApp(t testing.TB, db *state.StateDB, bvcKey ed25519.PrivateKey, addr crypto.Address, doGenesis bool) *fakeNode {
 	n := new(fakeNode)
 	n.t = t
 	n.db = db
+	n.key = bvcKey
 
 	logWriter, _ := logging.TestLogWriter(t)("plain")
 	logLevel, logWriter, err := logging.ParseLogLevel(config.DefaultLogLevels, logWriter)
@@ -76,6 +92,10 @@ func createApp(t testing.TB, db *state.StateDB, addr crypto.Address, doGenesis b
 
 	n.client = acctesting.NewABCIApplicationClient(appChan, db, n.NextHeight, func(err error) {
 		t.Helper()
+		if n.onError != nil {
+			n.onError(err)
+			return
+		}
 		assert.NoError(t, err)
 	}, 100*time.Millisecond)
 	relay := relay.New(n.client)
@@ -95,10 +115,19 @@ func createApp(t testing.TB, db *state.StateDB, addr crypto.Address, doGenesis b
 			ID:       subnet,
 			BvnNames: []string{subnet},
 		},
+		Storage: config.Storage{
+			ScratchRetention: testScratchRetention,
+			PendingRetention: testPendingRetention,
+		},
 	})
 	require.NoError(t, err)
 
-	n.app, err = abci.NewAccumulator(db, addr, mgr, logger)
+	n.app, err = abci.NewAccumulator(abci.AccumulatorOptions{
+		DB:      db,
+		Address: addr,
+		Chain:   mgr,
+		Logger:  logger,
+	})
 	require.NoError(t, err)
 	appChan <- n.app
 	n.app.(*abci.Accumulator).OnFatal(func(err error) {
@@ -122,6 +151,12 @@ func createApp(t testing.TB, db *state.StateDB, addr crypto.Address, doGenesis b
 		Validators: []tmtypes.GenesisValidator{
 			{PubKey: tmed25519.PrivKey(bvcKey).PubKey()},
 		},
+		PendingExpiration: testPendingExpiration,
+
+		// The node also signs for the DN
+		Subnets: map[string][]tmtypes.GenesisValidator{
+			protocol.Directory: {{PubKey: tmed25519.PrivKey(bvcKey).PubKey()}},
+		},
 	})
 	require.NoError(t, err)
 
@@ -144,6 +179,11 @@ type fakeNode struct {
 	client *acctesting.ABCIApplicationClient
 	query  *accapi.Query
 	height int64
+	key    ed25519.PrivateKey
+
+	// onError, if set, receives the errors of failed transactions instead of
+	// failing the test
+	onError func(err error)
 }
 
 func (n *fakeNode) NextHeight() int64 {
@@ -217,13 +257,20 @@ func generateKey() tmed25519.PrivKey {
 	return tmed25519.PrivKey(key)
 }
 
-func edSigner(key tmed25519.PrivKey, nonce uint64) func(hash []byte) (*transactions.ED25519Sig, error) {
-	return func(hash []byte) (*transactions.ED25519Sig, error) {
+func edSigner(key tmed25519.PrivKey, nonce uint64) func(hash []byte) (transactions.Signature, error) {
+	return func(hash []byte) (transactions.Signature, error) {
 		sig := new(transactions.ED25519Sig)
 		return sig, sig.Sign(nonce, key, hash)
 	}
 }
 
+func secpSigner(key secp256k1.PrivKey, nonce uint64) func(hash []byte) (transactions.Signature, error) {
+	return func(hash []byte) (transactions.Signature, error) {
+		sig := new(transactions.Secp256k1Sig)
+		return sig, sig.Sign(nonce, key, hash)
+	}
+}
+
 func (n *fakeNode) ParseUrl(s string) *url.URL {
 	u, err := url.Parse(s)
 	require.NoError(n.t, err)
@@ -281,6 +328,12 @@ func (n *fakeNode) GetTokenAccount(url string) *state.TokenAccount {
 	return acct
 }
 
+func (n *fakeNode) GetTokenIssuer(url string) *protocol.TokenIssuer {
+	issuer := new(protocol.TokenIssuer)
+	n.GetChainAs(url, issuer)
+	return issuer
+}
+
 func (n *fakeNode) GetLiteTokenAccount(url string) *protocol.LiteTokenAccount {
 	acct := new(protocol.LiteTokenAccount)
 	n.GetChainAs(url, acct)
@@ -305,6 +358,26 @@ func (n *fakeNode) GetKeyPage(url string) *protocol.KeyPage {
 	return mss
 }
 
+func (n *fakeNode) GetPendingTransactions(url string) *query.ResponsePendingTransactions {
+	req := new(query.RequestPendingTransactions)
+	req.Url = url
+	content, err := req.MarshalBinary()
+	require.NoError(n.t, err)
+
+	q := new(query.Query)
+	q.Type = req.Type()
+	q.Content = content
+	payload, err := q.MarshalBinary()
+	require.NoError(n.t, err)
+
+	resp := n.app.Query(abcitypes.RequestQuery{Data: payload})
+	require.Zero(n.t, resp.Code, "Query failed: %s", resp.Info)
+
+	res := new(query.ResponsePendingTransactions)
+	require.NoError(n.t, res.UnmarshalBinary(resp.Value))
+	return res
+}
+
 type e2eDUT struct {
 	*e2e.Suite
 	*fakeNode
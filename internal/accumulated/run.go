@@ -13,6 +13,7 @@ import (
 	"github.com/AccumulateNetwork/accumulate"
 	"github.com/AccumulateNetwork/accumulate/config"
 	"github.com/AccumulateNetwork/accumulate/internal/abci"
+	"github.com/AccumulateNetwork/accumulate/internal/anchoring"
 	apiv1 "github.com/AccumulateNetwork/accumulate/internal/api"
 	"github.com/AccumulateNetwork/accumulate/internal/api/v2"
 	"github.com/AccumulateNetwork/accumulate/internal/chain"
@@ -151,20 +152,42 @@ func (d *Daemon) Start() (err error) {
 	}
 	d.query = apiv1.NewQuery(d.relay)
 
+	// Only the DN anchors externally
+	var anchorer anchoring.ExternalAnchorer
+	if d.Config.Accumulate.Network.Type == config.Directory {
+		anchorer, err = anchoring.New(d.Config.Accumulate.Anchoring)
+		if err != nil {
+			return fmt.Errorf("failed to initialize external anchoring: %v", err)
+		}
+	}
+
 	execOpts := chain.ExecutorOptions{
-		Local:   clientProxy,
-		DB:      d.db,
-		Logger:  d.Logger,
-		Key:     d.Key().Bytes(),
-		Network: d.Config.Accumulate.Network,
-		IsTest:  d.IsTest,
+		Local:    clientProxy,
+		DB:       d.db,
+		Logger:   d.Logger,
+		Key:      d.Key().Bytes(),
+		Network:  d.Config.Accumulate.Network,
+		Storage:  d.Config.Accumulate.Storage,
+		Anchorer: anchorer,
+		IsTest:   d.IsTest,
 	}
 	exec, err := chain.NewNodeExecutor(execOpts)
 	if err != nil {
 		return fmt.Errorf("failed to initialize chain executor: %v", err)
 	}
 
-	app, err := abci.NewAccumulator(d.db, d.Key().PubKey().Address(), exec, d.Logger)
+	app, err := abci.NewAccumulator(abci.AccumulatorOptions{
+		DB:      d.db,
+		Address: d.Key().PubKey().Address(),
+		Chain:   exec,
+		Logger:  d.Logger,
+		Snapshots: abci.SnapshotOptions{
+			Dir:       filepath.Join(d.Config.RootDir, "snapshots"),
+			Interval:  d.Config.Accumulate.Storage.SnapshotInterval,
+			Retention: d.Config.Accumulate.Storage.SnapshotRetention,
+		},
+		RetainBlocks: d.Config.Accumulate.Storage.RetainBlocks,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to initialize ACBI app: %v", err)
 	}
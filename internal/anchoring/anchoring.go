@@ -0,0 +1,44 @@
+// Package anchoring anchors the roots of major blocks to external ledgers,
+// which provides third-party timestamps of the state of the network.
+package anchoring
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AccumulateNetwork/accumulate/config"
+)
+
+// Anchor is a root of the major anchor chain of a subnet.
+type Anchor struct {
+	Source    string    `json:"source"`
+	Index     int64     `json:"index"`
+	Timestamp time.Time `json:"timestamp"`
+	Root      [32]byte  `json:"root"`
+}
+
+// ExternalAnchorer writes anchors to an external ledger.
+type ExternalAnchorer interface {
+	// Ledger is the name of the external ledger.
+	Ledger() string
+
+	// Anchor writes the anchor to the external ledger and returns the ID of
+	// the external transaction.
+	Anchor(context.Context, *Anchor) ([]byte, error)
+}
+
+// New creates an ExternalAnchorer for the configured backend. New returns nil
+// if anchoring is disabled.
+func New(cfg config.Anchoring) (ExternalAnchorer, error) {
+	switch cfg.Backend {
+	case config.NoAnchoring:
+		return nil, nil
+	case config.FactomAnchoring:
+		return NewFactom(cfg.FactomdServer, cfg.FactomChainID, cfg.FactomEsAddress)
+	case config.FileAnchoring:
+		return NewFile(cfg.File)
+	default:
+		return nil, fmt.Errorf("invalid anchoring backend %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,61 @@
+package anchoring
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/Factom-Asset-Tokens/factom"
+)
+
+// Factom anchors to a Factom chain. Each anchor is written as an entry with the
+// source, index, and timestamp as external IDs and the root as the content.
+type Factom struct {
+	client  *factom.Client
+	chainId factom.Bytes32
+	es      factom.EsAddress
+}
+
+var _ ExternalAnchorer = (*Factom)(nil)
+
+// NewFactom creates a Factom anchorer that writes entries to the given chain
+// via the given factomd server, paying with the given entry credit address.
+func NewFactom(server, chainId, esAddress string) (*Factom, error) {
+	f := new(Factom)
+	f.client = factom.NewClient()
+	if server != "" {
+		f.client.FactomdServer = server
+	}
+
+	err := f.chainId.Set(chainId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Factom chain ID: %v", err)
+	}
+
+	f.es, err = factom.NewEsAddress(esAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Factom entry credit address: %v", err)
+	}
+
+	return f, nil
+}
+
+func (*Factom) Ledger() string { return "factom" }
+
+func (f *Factom) Anchor(ctx context.Context, anchor *Anchor) ([]byte, error) {
+	var index, timestamp [8]byte
+	binary.BigEndian.PutUint64(index[:], uint64(anchor.Index))
+	binary.BigEndian.PutUint64(timestamp[:], uint64(anchor.Timestamp.Unix()))
+
+	chainId := f.chainId
+	entry := new(factom.Entry)
+	entry.ChainID = &chainId
+	entry.ExtIDs = []factom.Bytes{[]byte(anchor.Source), index[:], timestamp[:]}
+	entry.Content = anchor.Root[:]
+
+	txid, err := entry.ComposeCreate(ctx, f.client, f.es)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Factom entry: %v", err)
+	}
+	return txid[:], nil
+}
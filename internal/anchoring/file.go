@@ -0,0 +1,55 @@
+package anchoring
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// File anchors to a local file. Each anchor is appended as a line of JSON and
+// the ID of the transaction is the hash of the line. File is intended for
+// testing.
+type File struct {
+	mu   sync.Mutex
+	path string
+}
+
+var _ ExternalAnchorer = (*File)(nil)
+
+// NewFile creates a file anchorer that appends anchors to the given file.
+func NewFile(path string) (*File, error) {
+	if path == "" {
+		return nil, fmt.Errorf("missing anchor file path")
+	}
+	return &File{path: path}, nil
+}
+
+func (*File) Ledger() string { return "file" }
+
+func (f *File) Anchor(_ context.Context, anchor *Anchor) ([]byte, error) {
+	line, err := json.Marshal(anchor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal anchor: %v", err)
+	}
+	line = append(line, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open anchor file: %v", err)
+	}
+	defer file.Close()
+
+	_, err = file.Write(line)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write anchor file: %v", err)
+	}
+
+	txid := sha256.Sum256(line)
+	return txid[:], nil
+}
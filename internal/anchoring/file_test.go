@@ -0,0 +1,54 @@
+package anchoring
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AccumulateNetwork/accumulate/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "anchors.json")
+	anchorer, err := New(config.Anchoring{Backend: config.FileAnchoring, File: path})
+	require.NoError(t, err)
+	require.Equal(t, "file", anchorer.Ledger())
+
+	anchors := []*Anchor{
+		{Source: "acc://dn", Index: 10, Timestamp: time.Unix(3600, 0).UTC(), Root: sha256.Sum256([]byte{1})},
+		{Source: "acc://dn", Index: 20, Timestamp: time.Unix(7200, 0).UTC(), Root: sha256.Sum256([]byte{2})},
+	}
+
+	var txids [][]byte
+	for _, anchor := range anchors {
+		txid, err := anchorer.Anchor(context.Background(), anchor)
+		require.NoError(t, err)
+		txids = append(txids, txid)
+	}
+	require.NotEqual(t, txids[0], txids[1])
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var i int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := append(scanner.Bytes(), '\n')
+		txid := sha256.Sum256(line)
+		require.Equal(t, txids[i], txid[:])
+
+		anchor := new(Anchor)
+		require.NoError(t, json.Unmarshal(line, anchor))
+		require.Equal(t, anchors[i], anchor)
+		i++
+	}
+	require.NoError(t, scanner.Err())
+	require.Equal(t, len(anchors), i)
+}
@@ -378,13 +378,14 @@ func TestQueryWrongType(t *testing.T) {
 	query := daemon.Query_TESTONLY()
 	japi := NewTest(t, &daemon.Config.Accumulate.API, query)
 
-	destAddress, _, tx, err := acctesting.BuildTestSynthDepositGenTx()
-	require.NoError(t, err)
-
-	err = acctesting.SendTxSync(query, tx)
+	_, liteKey, _ := ed25519.GenerateKey(nil)
+	dbTx := daemon.DB_TESTONLY().Begin()
+	require.NoError(t, acctesting.CreateLiteTokenAccount(dbTx, tmed25519.PrivKey(liteKey), 5e4))
+	_, err := dbTx.Commit(3, time.Unix(0, 0), nil)
 	require.NoError(t, err)
 
-	req, err := json.Marshal(&api.APIRequestURL{URL: destAddress})
+	destAddress := acctesting.AcmeLiteAddressStdPriv(liteKey).String()
+	req, err := json.Marshal(&api.APIRequestURL{URL: types.String(destAddress)})
 	require.NoError(t, err)
 
 	resp := japi.GetADI(context.Background(), req)
@@ -403,13 +404,20 @@ func TestGetTxId(t *testing.T) {
 	query := daemon.Query_TESTONLY()
 	japi := NewTest(t, &daemon.Config.Accumulate.API, query)
 
-	destAddress, _, tx, err := acctesting.BuildTestSynthDepositGenTx()
+	_, liteKey, _ := ed25519.GenerateKey(nil)
+	body := new(protocol.SendTokens)
+	body.AddRecipient(acctesting.AcmeLiteAddressStdPriv(liteKey), 1000)
+
+	protocol.FaucetWallet.Nonce = uint64(time.Now().UnixNano())
+	tx, err := transactions.New(protocol.FaucetWallet.Addr, 1, func(hash []byte) (transactions.Signature, error) {
+		return protocol.FaucetWallet.Sign(hash), nil
+	}, body)
 	require.NoError(t, err)
 
 	err = acctesting.SendTxSync(query, tx)
 	require.NoError(t, err)
 
-	u, err := url.Parse(*destAddress.AsString())
+	u, err := url.Parse(protocol.FaucetWallet.Addr)
 	require.NoError(t, err)
 	u.Path = ""
 	u.Query = fmt.Sprintf("txid=%x", tx.TransactionHash())
@@ -466,7 +474,7 @@ func TestFaucetReplay(t *testing.T) {
 	tx.AddRecipient(acctesting.MustParseUrl(destAccount), 1000000000)
 
 	protocol.FaucetWallet.Nonce = uint64(time.Now().UnixNano())
-	gtx, err := transactions.New(protocol.FaucetWallet.Addr, 1, func(hash []byte) (*transactions.ED25519Sig, error) {
+	gtx, err := transactions.New(protocol.FaucetWallet.Addr, 1, func(hash []byte) (transactions.Signature, error) {
 		return protocol.FaucetWallet.Sign(hash), nil
 	}, &tx)
 	require.NoError(t, err)
@@ -492,17 +492,18 @@ func packTransactionQuery(txId []byte, txData []byte, txPendingData []byte, txSy
 	resp.KeyPage.Height = txSigInfo.KeyPageHeight
 	resp.KeyPage.Index = txSigInfo.KeyPageIndex
 
+	//the status is kept when the signatures are pruned, and says so
+	if txPendingState != nil {
+		resp.Status = &txPendingState.Status
+	}
+
 	//if we have pending data (i.e. signature stuff, populate that too.)
 	if txPendingState != nil && len(txPendingState.Signature) > 0 {
 		//if the pending state still exists
-		resp.Status = &txPendingState.Status
-		resp.Signer = &acmeApi.Signer{}
-		resp.Signer.PublicKey.FromBytes(txPendingState.Signature[0].PublicKey)
-		if len(txPendingState.Signature) == 0 {
-			return nil, accumulateError(fmt.Errorf("malformed transaction, no signatures"))
-		}
-		resp.Signer.Nonce = txPendingState.Signature[0].Nonce
-		sig := types.Bytes(txPendingState.Signature[0].Signature).AsBytes64()
+		resp.Signer = &acmeApi.SignerInfo{}
+		resp.Signer.PublicKey = txPendingState.Signature[0].GetPublicKey()
+		resp.Signer.Nonce = txPendingState.Signature[0].GetNonce()
+		sig := types.Bytes(txPendingState.Signature[0].GetSignature())
 		resp.Sig = &sig
 	}
 	return resp, err
@@ -175,12 +175,26 @@ func unmarshalTransaction(sigInfo *transactions.SignatureInfo, txPayload []byte,
 		resp, err = unmarshalTxAs(txPayload, new(protocol.AddCredits))
 	case types.TxTypeUpdateKeyPage:
 		resp, err = unmarshalTxAs(txPayload, new(protocol.UpdateKeyPage))
+	case types.TxTypeUpdateKeyBook:
+		resp, err = unmarshalTxAs(txPayload, new(protocol.UpdateKeyBook))
+	case types.TxTypeUpdateAccountAuth:
+		resp, err = unmarshalTxAs(txPayload, new(protocol.UpdateAccountAuth))
+	case types.TxTypeUpdateManager:
+		resp, err = unmarshalTxAs(txPayload, new(protocol.UpdateManager))
+	case types.TxTypeUpdateAcmeOracle:
+		resp, err = unmarshalTxAs(txPayload, new(protocol.UpdateAcmeOracle))
+	case types.TxTypeTransferCredits:
+		resp, err = unmarshalTxAs(txPayload, new(protocol.TransferCredits))
+	case types.TxTypeUpdateValidator:
+		resp, err = unmarshalTxAs(txPayload, new(protocol.UpdateValidator))
 	case types.TxTypeSyntheticCreateChain:
 		resp, err = unmarshalTxAs(txPayload, new(protocol.SyntheticCreateChain))
 	case types.TxTypeSyntheticDepositCredits:
 		resp, err = unmarshalTxAs(txPayload, new(protocol.SyntheticDepositCredits))
 	case types.TxTypeSyntheticGenesis:
 		resp, err = unmarshalTxAs(txPayload, new(protocol.SyntheticGenesis))
+	case types.TxTypeSyntheticExternalAnchor:
+		resp, err = unmarshalTxAs(txPayload, new(protocol.SyntheticExternalAnchor))
 	case types.TxTypeAcmeFaucet:
 		resp, err = unmarshalTxAs(txPayload, new(protocol.AcmeFaucet))
 	case types.TxTypeSegWitDataEntry:
@@ -10,20 +10,28 @@ import (
 
 func (m *JrpcMethods) populateMethodTable() jsonrpc2.MethodMap {
 	if m.methods == nil {
-		m.methods = make(jsonrpc2.MethodMap, 22)
+		m.methods = make(jsonrpc2.MethodMap, 31)
 	}
 
 	m.methods["execute"] = m.Execute
 	m.methods["add-credits"] = m.ExecuteAddCredits
+	m.methods["burn-tokens"] = m.ExecuteBurnTokens
 	m.methods["create-adi"] = m.ExecuteCreateAdi
 	m.methods["create-data-account"] = m.ExecuteCreateDataAccount
 	m.methods["create-key-book"] = m.ExecuteCreateKeyBook
 	m.methods["create-key-page"] = m.ExecuteCreateKeyPage
 	m.methods["create-token"] = m.ExecuteCreateToken
 	m.methods["create-token-account"] = m.ExecuteCreateTokenAccount
+	m.methods["issue-tokens"] = m.ExecuteIssueTokens
 	m.methods["send-tokens"] = m.ExecuteSendTokens
+	m.methods["transfer-credits"] = m.ExecuteTransferCredits
+	m.methods["update-account-auth"] = m.ExecuteUpdateAccountAuth
+	m.methods["update-acme-oracle"] = m.ExecuteUpdateAcmeOracle
+	m.methods["update-key-book"] = m.ExecuteUpdateKeyBook
 	m.methods["update-key-page"] = m.ExecuteUpdateKeyPage
+	m.methods["update-manager"] = m.ExecuteUpdateManager
 	m.methods["write-data"] = m.ExecuteWriteData
+	m.methods["write-data-to"] = m.ExecuteWriteDataTo
 	m.methods["faucet"] = m.Faucet
 	m.methods["metrics"] = m.Metrics
 	m.methods["query"] = m.Query
@@ -32,6 +40,7 @@ func (m *JrpcMethods) populateMethodTable() jsonrpc2.MethodMap {
 	m.methods["query-data-set"] = m.QueryDataSet
 	m.methods["query-directory"] = m.QueryDirectory
 	m.methods["query-key-index"] = m.QueryKeyPageIndex
+	m.methods["query-pending"] = m.QueryPendingTransactions
 	m.methods["query-tx"] = m.QueryTx
 	m.methods["query-tx-history"] = m.QueryTxHistory
 	m.methods["version"] = m.Version
@@ -71,6 +80,10 @@ func (m *JrpcMethods) ExecuteAddCredits(ctx context.Context, params json.RawMess
 	return m.executeWith(ctx, params, new(protocol.AddCredits))
 }
 
+func (m *JrpcMethods) ExecuteBurnTokens(ctx context.Context, params json.RawMessage) interface{} {
+	return m.executeWith(ctx, params, new(protocol.BurnTokens))
+}
+
 func (m *JrpcMethods) ExecuteCreateAdi(ctx context.Context, params json.RawMessage) interface{} {
 	return m.executeWith(ctx, params, new(protocol.IdentityCreate))
 }
@@ -95,18 +108,46 @@ func (m *JrpcMethods) ExecuteCreateTokenAccount(ctx context.Context, params json
 	return m.executeWith(ctx, params, new(protocol.TokenAccountCreate))
 }
 
+func (m *JrpcMethods) ExecuteIssueTokens(ctx context.Context, params json.RawMessage) interface{} {
+	return m.executeWith(ctx, params, new(protocol.IssueTokens))
+}
+
 func (m *JrpcMethods) ExecuteSendTokens(ctx context.Context, params json.RawMessage) interface{} {
 	return m.executeWith(ctx, params, new(protocol.SendTokens), "From", "To")
 }
 
+func (m *JrpcMethods) ExecuteTransferCredits(ctx context.Context, params json.RawMessage) interface{} {
+	return m.executeWith(ctx, params, new(protocol.TransferCredits))
+}
+
+func (m *JrpcMethods) ExecuteUpdateAccountAuth(ctx context.Context, params json.RawMessage) interface{} {
+	return m.executeWith(ctx, params, new(protocol.UpdateAccountAuth))
+}
+
+func (m *JrpcMethods) ExecuteUpdateAcmeOracle(ctx context.Context, params json.RawMessage) interface{} {
+	return m.executeWith(ctx, params, new(protocol.UpdateAcmeOracle))
+}
+
+func (m *JrpcMethods) ExecuteUpdateKeyBook(ctx context.Context, params json.RawMessage) interface{} {
+	return m.executeWith(ctx, params, new(protocol.UpdateKeyBook))
+}
+
 func (m *JrpcMethods) ExecuteUpdateKeyPage(ctx context.Context, params json.RawMessage) interface{} {
 	return m.executeWith(ctx, params, new(protocol.UpdateKeyPage))
 }
 
+func (m *JrpcMethods) ExecuteUpdateManager(ctx context.Context, params json.RawMessage) interface{} {
+	return m.executeWith(ctx, params, new(protocol.UpdateManager))
+}
+
 func (m *JrpcMethods) ExecuteWriteData(ctx context.Context, params json.RawMessage) interface{} {
 	return m.executeWith(ctx, params, new(protocol.WriteData))
 }
 
+func (m *JrpcMethods) ExecuteWriteDataTo(ctx context.Context, params json.RawMessage) interface{} {
+	return m.executeWith(ctx, params, new(protocol.WriteDataTo))
+}
+
 func (m *JrpcMethods) Query(_ context.Context, params json.RawMessage) interface{} {
 	req := new(UrlQuery)
 	err := m.parse(params, req)
@@ -167,6 +208,16 @@ func (m *JrpcMethods) QueryKeyPageIndex(_ context.Context, params json.RawMessag
 	return jrpcFormatResponse(m.opts.Query.QueryKeyPageIndex(req.Url, req.Key))
 }
 
+func (m *JrpcMethods) QueryPendingTransactions(_ context.Context, params json.RawMessage) interface{} {
+	req := new(UrlQuery)
+	err := m.parse(params, req)
+	if err != nil {
+		return err
+	}
+
+	return jrpcFormatResponse(m.opts.Query.QueryPendingTransactions(req.Url))
+}
+
 func (m *JrpcMethods) QueryTx(_ context.Context, params json.RawMessage) interface{} {
 	req := new(TxnQuery)
 	err := m.parse(params, req)
@@ -33,15 +33,23 @@ import (
 var reAlphaNum = regexp.MustCompile("[^a-zA-Z0-9]")
 
 func startAccumulate(t *testing.T, ips []net.IP, bvns, validators, basePort int) []*accumulated.Daemon {
-	if len(ips) != bvns*validators {
-		panic(fmt.Errorf("want %d validators each for %d BVNs but got %d IPs", validators, bvns, len(ips)))
+	if len(ips) != bvns*validators+1 {
+		panic(fmt.Errorf("want a DN and %d validators each for %d BVNs but got %d IPs", validators, bvns, len(ips)))
 	}
 
 	names := make([]string, bvns)
-	addrs := make(map[string][]string, bvns)
+	addrs := make(map[string][]string, bvns+1)
 	IPs := make([][]string, bvns)
 	config := make([][]*config.Config, bvns)
 
+	// The DN runs a single validator
+	dnIP := ips[0].String()
+	ips = ips[1:]
+	addrs[protocol.Directory] = []string{fmt.Sprintf("http://%s:%d", dnIP, basePort)}
+	dnConfig := acctesting.DefaultConfig(cfg.Directory, cfg.Validator, protocol.Directory)
+	dnConfig.Accumulate.Network.BvnNames = names
+	dnConfig.Accumulate.Network.Addresses = addrs
+
 	workDir := t.TempDir()
 	name := reAlphaNum.ReplaceAllString(t.Name(), "-")
 	for bvn := 0; bvn < bvns; bvn++ {
@@ -63,24 +71,41 @@ func startAccumulate(t *testing.T, ips []net.IP, bvns, validators, basePort int)
 		}
 	}
 
-	daemons := make([]*accumulated.Daemon, 0, bvns*validators)
+	subnets := []node.InitOptions{{
+		WorkDir:  path.Join(workDir, "dn"),
+		Port:     basePort,
+		Config:   []*cfg.Config{dnConfig},
+		RemoteIP: []string{dnIP},
+		ListenIP: []string{dnIP},
+	}}
 	for bvn := 0; bvn < bvns; bvn++ {
-		require.NoError(t, node.Init(node.InitOptions{
+		subnets = append(subnets, node.InitOptions{
 			WorkDir:  path.Join(workDir, fmt.Sprintf("bvn%d", bvn)),
-			Port:     3000,
+			Port:     basePort,
 			Config:   config[bvn],
 			RemoteIP: IPs[bvn],
 			ListenIP: IPs[bvn],
-		}))
+		})
+	}
+	require.NoError(t, node.InitNetwork(subnets))
+
+	runDaemon := func(dir string) *accumulated.Daemon {
+		daemon, err := acctesting.RunDaemon(acctesting.DaemonOptions{
+			Dir:       dir,
+			LogWriter: logging.TestLogWriter(t),
+		}, t.Cleanup)
+		require.NoError(t, err)
+		daemon.Node_TESTONLY().ABCI.(*abci.Accumulator).OnFatal(func(err error) { require.NoError(t, err) })
+		return daemon
+	}
 
+	// The DN is not returned, since requests are submitted to the BVNs
+	runDaemon(filepath.Join(workDir, "dn", "Node0"))
+
+	daemons := make([]*accumulated.Daemon, 0, bvns*validators)
+	for bvn := 0; bvn < bvns; bvn++ {
 		for val := 0; val < validators; val++ {
-			daemon, err := acctesting.RunDaemon(acctesting.DaemonOptions{
-				Dir:       filepath.Join(workDir, fmt.Sprintf("bvn%d", bvn), fmt.Sprintf("Node%d", val)),
-				LogWriter: logging.TestLogWriter(t),
-			}, t.Cleanup)
-			require.NoError(t, err)
-			daemon.Node_TESTONLY().ABCI.(*abci.Accumulator).OnFatal(func(err error) { require.NoError(t, err) })
-			daemons = append(daemons, daemon)
+			daemons = append(daemons, runDaemon(filepath.Join(workDir, fmt.Sprintf("bvn%d", bvn), fmt.Sprintf("Node%d", val))))
 		}
 	}
 
@@ -141,6 +166,7 @@ type execParams struct {
 	Origin    string
 	Key       ed25519.PrivateKey
 	PageIndex uint64
+	PageUrl   string
 	Payload   protocol.TransactionPayload
 }
 
@@ -156,7 +182,13 @@ func recode(t *testing.T, from, to interface{}) {
 func executeTx(t *testing.T, japi *api.JrpcMethods, method string, wait bool, params execParams) *api.TxResponse {
 	t.Helper()
 
-	qr := query(t, japi, "query", &api.UrlQuery{Url: params.Origin})
+	// Sign with the height of the key page if one is specified, otherwise
+	// with the height of the origin
+	pageUrl := params.PageUrl
+	if pageUrl == "" {
+		pageUrl = params.Origin
+	}
+	qr := query(t, japi, "query", &api.UrlQuery{Url: pageUrl})
 	now := time.Now()
 	nonce := uint64(now.Unix()*1e9) + uint64(now.Nanosecond())
 	tx, err := transactions.NewWith(&transactions.SignatureInfo{
@@ -164,7 +196,7 @@ func executeTx(t *testing.T, japi *api.JrpcMethods, method string, wait bool, pa
 		KeyPageIndex:  params.PageIndex,
 		KeyPageHeight: qr.MerkleState.Count,
 		Nonce:         nonce,
-	}, func(hash []byte) (*transactions.ED25519Sig, error) {
+	}, func(hash []byte) (transactions.Signature, error) {
 		sig := new(transactions.ED25519Sig)
 		return sig, sig.Sign(nonce, params.Key, hash)
 	}, params.Payload)
@@ -174,7 +206,7 @@ func executeTx(t *testing.T, japi *api.JrpcMethods, method string, wait bool, pa
 	req.Origin = params.Origin
 	req.Signer.PublicKey = params.Key[32:]
 	req.Signer.Nonce = nonce
-	req.Signature = tx.Signature[0].Signature
+	req.Signature = tx.Signature[0].GetSignature()
 	req.KeyPage.Index = params.PageIndex
 	req.KeyPage.Height = qr.MerkleState.Count
 	req.Payload = params.Payload
@@ -199,7 +231,7 @@ func executeTxFail(t *testing.T, japi *api.JrpcMethods, method string, height ui
 		KeyPageIndex:  params.PageIndex,
 		KeyPageHeight: height,
 		Nonce:         nonce,
-	}, func(hash []byte) (*transactions.ED25519Sig, error) {
+	}, func(hash []byte) (transactions.Signature, error) {
 		sig := new(transactions.ED25519Sig)
 		return sig, sig.Sign(nonce, params.Key, hash)
 	}, params.Payload)
@@ -209,7 +241,7 @@ func executeTxFail(t *testing.T, japi *api.JrpcMethods, method string, height ui
 	req.Origin = params.Origin
 	req.Signer.PublicKey = params.Key[32:]
 	req.Signer.Nonce = nonce
-	req.Signature = tx.Signature[0].Signature
+	req.Signature = tx.Signature[0].GetSignature()
 	req.KeyPage.Index = params.PageIndex
 	req.KeyPage.Height = height
 	req.Payload = params.Payload
@@ -255,8 +287,9 @@ func (d *e2eDUT) SubmitTxn(tx *transactions.GenTransaction) {
 	pl := new(api.TxRequest)
 	pl.Origin = tx.SigInfo.URL
 	pl.Signer.Nonce = tx.SigInfo.Nonce
-	pl.Signer.PublicKey = tx.Signature[0].PublicKey
-	pl.Signature = tx.Signature[0].Signature
+	pl.Signer.Type = tx.Signature[0].Type()
+	pl.Signer.PublicKey = tx.Signature[0].GetPublicKey()
+	pl.Signature = tx.Signature[0].GetSignature()
 	pl.KeyPage.Index = tx.SigInfo.KeyPageIndex
 	pl.KeyPage.Height = tx.SigInfo.KeyPageHeight
 	pl.Payload = tx.Transaction
@@ -28,7 +28,7 @@ func TestEndToEnd(t *testing.T) {
 	acctesting.SkipPlatformCI(t, "darwin", "requires setting up localhost aliases")
 
 	// Reuse the same IPs for each test
-	ips := acctesting.GetIPs(2)
+	ips := acctesting.GetIPs(3)
 
 	suite.Run(t, e2e.NewSuite(func(s *e2e.Suite) e2e.DUT {
 		daemons := startAccumulate(t, ips, 1, 2, 3000)
@@ -41,7 +41,7 @@ func TestValidate(t *testing.T) {
 	acctesting.SkipPlatform(t, "darwin", "flaky")
 	acctesting.SkipPlatformCI(t, "darwin", "requires setting up localhost aliases")
 
-	daemons := startAccumulate(t, acctesting.GetIPs(4), 2, 2, 3000)
+	daemons := startAccumulate(t, acctesting.GetIPs(5), 2, 2, 3000)
 	japi := daemons[0].Jrpc_TESTONLY()
 
 	t.Run("Not found", func(t *testing.T) {
@@ -75,14 +75,14 @@ func TestValidate(t *testing.T) {
 			Key:    liteKey,
 			Payload: &AddCredits{
 				Recipient: liteUrl.String(),
-				Amount:    100,
+				Amount:    6 * CreditsPerFiatUnit,
 			},
 		})
 
 		account := NewLiteTokenAccount()
 		queryAs(t, japi, "query", &api.UrlQuery{Url: liteUrl.String()}, account)
-		assert.Equal(t, int64(100), account.CreditBalance.Int64())
-		assert.Equal(t, int64(10*AcmePrecision-AcmePrecision/100), account.Balance.Int64())
+		assert.Equal(t, int64(6*CreditsPerFiatUnit), account.CreditBalance.Int64())
+		assert.Equal(t, int64(4*AcmePrecision), account.Balance.Int64())
 
 		query(t, japi, "query-chain", &api.ChainIdQuery{ChainId: liteUrl.ResourceChain()})
 	})
@@ -129,11 +129,27 @@ func TestValidate(t *testing.T) {
 		require.Len(t, r.Items, 3)
 	})
 
+	t.Run("ADI Key Page Credits", func(t *testing.T) {
+		executeTx(t, japi, "add-credits", true, execParams{
+			Origin: liteUrl.String(),
+			Key:    liteKey,
+			Payload: &AddCredits{
+				Recipient: adiName + "/page",
+				Amount:    3 * CreditsPerFiatUnit,
+			},
+		})
+
+		page := NewKeyPage()
+		queryAs(t, japi, "query", &api.UrlQuery{Url: adiName + "/page"}, page)
+		assert.Equal(t, int64(3*CreditsPerFiatUnit), page.CreditBalance.Int64())
+	})
+
 	dataAccountUrl := adiName + "/dataAccount"
 	t.Run("Create Data Account", func(t *testing.T) {
 		executeTx(t, japi, "create-data-account", true, execParams{
-			Origin: adiName,
-			Key:    adiKey,
+			Origin:  adiName,
+			Key:     adiKey,
+			PageUrl: adiName + "/page",
 			Payload: &CreateDataAccount{
 				Url: dataAccountUrl,
 			},
@@ -150,8 +166,9 @@ func TestValidate(t *testing.T) {
 			PublicKey: adiKey,
 		})
 		executeTx(t, japi, "create-key-page", true, execParams{
-			Origin: adiName,
-			Key:    adiKey,
+			Origin:  adiName,
+			Key:     adiKey,
+			PageUrl: adiName + "/page",
 			Payload: &CreateKeyPage{
 				Url:  keyPageUrl,
 				Keys: keys,
@@ -169,8 +186,9 @@ func TestValidate(t *testing.T) {
 		pageChainId := types.Bytes(pageUrl.ResourceChain()).AsBytes32()
 		page = append(page, pageChainId)
 		executeTx(t, japi, "create-key-book", true, execParams{
-			Origin: adiName,
-			Key:    adiKey,
+			Origin:  adiName,
+			Key:     adiKey,
+			PageUrl: adiName + "/page",
 			Payload: &CreateKeyBook{
 				Url:   keyBookUrl,
 				Pages: page,
@@ -185,8 +203,9 @@ func TestValidate(t *testing.T) {
 	tokenAccountUrl := adiName + "/account"
 	t.Run("Create Token Account", func(t *testing.T) {
 		executeTx(t, japi, "create-token-account", true, execParams{
-			Origin: adiName,
-			Key:    adiKey,
+			Origin:  adiName,
+			Key:     adiKey,
+			PageUrl: adiName + "/page",
 			Payload: &TokenAccountCreate{
 				Url:        tokenAccountUrl,
 				TokenUrl:   tokenUrl,
@@ -216,7 +235,7 @@ func TestTokenTransfer(t *testing.T) {
 	acctesting.SkipPlatform(t, "darwin", "flaky")
 	acctesting.SkipPlatformCI(t, "darwin", "requires setting up localhost aliases")
 
-	daemons := startAccumulate(t, acctesting.GetIPs(4), 2, 2, 3000)
+	daemons := startAccumulate(t, acctesting.GetIPs(5), 2, 2, 3000)
 
 	var aliceKey ed25519.PrivateKey
 	var aliceUrl *url.URL
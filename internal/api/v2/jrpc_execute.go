@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/AccumulateNetwork/accumulate/internal/url"
@@ -85,9 +86,10 @@ func (m *JrpcMethods) Faucet(ctx context.Context, params json.RawMessage) interf
 	txrq := new(TxRequest)
 	txrq.Origin = tx.SigInfo.URL
 	txrq.Signer.Nonce = tx.SigInfo.Nonce
-	txrq.Signer.PublicKey = tx.Signature[0].PublicKey
+	txrq.Signer.Type = tx.Signature[0].Type()
+	txrq.Signer.PublicKey = tx.Signature[0].GetPublicKey()
 	txrq.KeyPage.Height = tx.SigInfo.KeyPageHeight
-	txrq.Signature = tx.Signature[0].Signature
+	txrq.Signature = tx.Signature[0].GetSignature()
 	return m.execute(ctx, txrq, tx.Transaction)
 }
 
@@ -170,11 +172,11 @@ func (m *JrpcMethods) executeLocal(ctx context.Context, req *TxRequest, payload
 	tx.SigInfo.KeyPageHeight = req.KeyPage.Height
 	tx.SigInfo.KeyPageIndex = req.KeyPage.Index
 
-	ed := new(transactions.ED25519Sig)
-	ed.Nonce = req.Signer.Nonce
-	ed.PublicKey = req.Signer.PublicKey
-	ed.Signature = req.Signature
-	tx.Signature = append(tx.Signature, ed)
+	sig, err := newSignature(req)
+	if err != nil {
+		return validatorError(err)
+	}
+	tx.Signature = append(tx.Signature, sig)
 
 	txb, err := tx.Marshal()
 	if err != nil {
@@ -242,6 +244,21 @@ func (m *JrpcMethods) executeLocal(ctx context.Context, req *TxRequest, payload
 	}
 }
 
+// newSignature constructs the signature of an execute request. Requests that
+// do not specify a signature type are ED25519.
+func newSignature(req *TxRequest) (transactions.Signature, error) {
+	switch req.Signer.Type {
+	case transactions.UnknownSignatureType, transactions.ED25519SignatureType:
+		return &transactions.ED25519Sig{Nonce: req.Signer.Nonce, PublicKey: req.Signer.PublicKey, Signature: req.Signature}, nil
+	case transactions.Secp256k1SignatureType:
+		return &transactions.Secp256k1Sig{Nonce: req.Signer.Nonce, PublicKey: req.Signer.PublicKey, Signature: req.Signature}, nil
+	case transactions.RSAPSSSignatureType:
+		return &transactions.RSAPSSSig{Nonce: req.Signer.Nonce, PublicKey: req.Signer.PublicKey, Signature: req.Signature}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature type %v", req.Signer.Type)
+	}
+}
+
 // executeBatch accepts execute requests for dispatch, then dispatches requests
 // in batches to the appropriate remote BVCs.
 func (m *JrpcMethods) executeBatch(queue ...*executeRequest) {
@@ -69,13 +69,18 @@ func packTxResponse(txid [32]byte, synth []byte, main *state.Transaction, pend *
 		res.Data = payload
 	}
 
+	// The status is kept when the signatures are pruned, and says so
+	if pend != nil {
+		res.Status = pend.Status
+	}
+
 	if pend != nil && len(pend.Signature) > 0 {
 		sig := pend.Signature[0]
-		res.Status = pend.Status
 		res.Signer = new(Signer)
-		res.Signer.PublicKey = sig.PublicKey
-		res.Signer.Nonce = sig.Nonce
-		res.Sig = sig.Signature
+		res.Signer.Type = sig.Type()
+		res.Signer.PublicKey = sig.GetPublicKey()
+		res.Signer.Nonce = sig.GetNonce()
+		res.Sig = sig.GetSignature()
 	}
 
 	return res, nil
@@ -400,3 +400,31 @@ func (q *queryDirect) QueryKeyPageIndex(s string, key []byte) (*QueryResponse, e
 	res.Type = "key-page-index"
 	return res, nil
 }
+
+func (q *queryDirect) QueryPendingTransactions(s string) (*QueryResponse, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUrl, err)
+	}
+
+	req := new(query.RequestPendingTransactions)
+	req.Url = u.String()
+	k, v, err := q.query(req)
+	if err != nil {
+		return nil, err
+	}
+	if k != "pending" {
+		return nil, fmt.Errorf("unknown response type: want pending, got %q", k)
+	}
+
+	qr := new(query.ResponsePendingTransactions)
+	err = qr.UnmarshalBinary(v)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(QueryResponse)
+	res.Data = qr
+	res.Type = "pending"
+	return res, nil
+}
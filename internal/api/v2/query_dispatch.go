@@ -104,6 +104,15 @@ func (q *queryDispatch) QueryKeyPageIndex(url string, key []byte) (*QueryRespons
 	return q.direct(r).QueryKeyPageIndex(url, key)
 }
 
+func (q *queryDispatch) QueryPendingTransactions(url string) (*QueryResponse, error) {
+	r, err := q.routing(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return q.direct(r).QueryPendingTransactions(url)
+}
+
 func (q *queryDispatch) QueryChain(id []byte) (*QueryResponse, error) {
 	res, err := q.queryAll(func(q *queryDirect) (*QueryResponse, error) {
 		return q.QueryChain(id)
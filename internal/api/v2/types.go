@@ -22,6 +22,7 @@ type Querier interface {
 	QueryData(url string, entryHash [32]byte) (*QueryResponse, error)
 	QueryDataSet(url string, pagination QueryPagination, opts QueryOptions) (*QueryResponse, error)
 	QueryKeyPageIndex(url string, key []byte) (*QueryResponse, error)
+	QueryPendingTransactions(url string) (*QueryResponse, error)
 }
 
 // ABCIQueryClient is a subset of from TM/rpc/client.ABCIClient for sending
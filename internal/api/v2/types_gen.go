@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/AccumulateNetwork/accumulate/internal/encoding"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
 )
 
 type ChainIdQuery struct {
@@ -111,8 +112,9 @@ type QueryResponse struct {
 }
 
 type Signer struct {
-	PublicKey []byte `json:"publicKey,omitempty" form:"publicKey" query:"publicKey" validate:"required"`
-	Nonce     uint64 `json:"nonce,omitempty" form:"nonce" query:"nonce" validate:"required"`
+	Type      transactions.SignatureType `json:"type,omitempty" form:"type" query:"type"`
+	PublicKey []byte                     `json:"publicKey,omitempty" form:"publicKey" query:"publicKey" validate:"required"`
+	Nonce     uint64                     `json:"nonce,omitempty" form:"nonce" query:"nonce" validate:"required"`
 }
 
 type TokenDeposit struct {
@@ -555,9 +557,11 @@ func (v *QueryResponse) MarshalJSON() ([]byte, error) {
 
 func (v *Signer) MarshalJSON() ([]byte, error) {
 	u := struct {
-		PublicKey *string `json:"publicKey,omitempty"`
-		Nonce     uint64  `json:"nonce,omitempty"`
+		Type      transactions.SignatureType `json:"type,omitempty"`
+		PublicKey *string                    `json:"publicKey,omitempty"`
+		Nonce     uint64                     `json:"nonce,omitempty"`
 	}{}
+	u.Type = v.Type
 	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
 	u.Nonce = v.Nonce
 	return json.Marshal(&u)
@@ -827,14 +831,17 @@ func (v *QueryResponse) UnmarshalJSON(data []byte) error {
 
 func (v *Signer) UnmarshalJSON(data []byte) error {
 	u := struct {
-		PublicKey *string `json:"publicKey,omitempty"`
-		Nonce     uint64  `json:"nonce,omitempty"`
+		Type      transactions.SignatureType `json:"type,omitempty"`
+		PublicKey *string                    `json:"publicKey,omitempty"`
+		Nonce     uint64                     `json:"nonce,omitempty"`
 	}{}
+	u.Type = v.Type
 	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
 	u.Nonce = v.Nonce
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
+	v.Type = u.Type
 	if x, err := encoding.BytesFromJSON(u.PublicKey); err != nil {
 		return fmt.Errorf("error decoding PublicKey: %w", err)
 	} else {
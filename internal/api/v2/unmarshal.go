@@ -40,6 +40,10 @@ func unmarshalTxPayload(b []byte) (protocol.TransactionPayload, error) {
 		payload = new(protocol.IdentityCreate)
 	case types.TxTypeCreateToken:
 		payload = new(protocol.CreateToken)
+	case types.TxTypeIssueTokens:
+		payload = new(protocol.IssueTokens)
+	case types.TxTypeBurnTokens:
+		payload = new(protocol.BurnTokens)
 	case types.TxTypeCreateTokenAccount:
 		payload = new(protocol.TokenAccountCreate)
 	case types.TxTypeCreateKeyPage:
@@ -50,18 +54,40 @@ func unmarshalTxPayload(b []byte) (protocol.TransactionPayload, error) {
 		payload = new(protocol.AddCredits)
 	case types.TxTypeUpdateKeyPage:
 		payload = new(protocol.UpdateKeyPage)
+	case types.TxTypeUpdateKeyBook:
+		payload = new(protocol.UpdateKeyBook)
+	case types.TxTypeUpdateAccountAuth:
+		payload = new(protocol.UpdateAccountAuth)
+	case types.TxTypeUpdateManager:
+		payload = new(protocol.UpdateManager)
+	case types.TxTypeUpdateAcmeOracle:
+		payload = new(protocol.UpdateAcmeOracle)
+	case types.TxTypeTransferCredits:
+		payload = new(protocol.TransferCredits)
+	case types.TxTypeUpdateValidator:
+		payload = new(protocol.UpdateValidator)
 	case types.TxTypeSyntheticCreateChain:
 		payload = new(protocol.SyntheticCreateChain)
+	case types.TxTypeSyntheticBurnTokens:
+		payload = new(protocol.SyntheticBurnTokens)
 	case types.TxTypeSyntheticDepositCredits:
 		payload = new(protocol.SyntheticDepositCredits)
 	case types.TxTypeSyntheticGenesis:
 		payload = new(protocol.SyntheticGenesis)
+	case types.TxTypeSyntheticExternalAnchor:
+		payload = new(protocol.SyntheticExternalAnchor)
 	case types.TxTypeAcmeFaucet:
 		payload = new(protocol.AcmeFaucet)
 	case types.TxTypeCreateDataAccount:
 		payload = new(protocol.CreateDataAccount)
 	case types.TxTypeWriteData:
 		payload = new(protocol.WriteData)
+	case types.TxTypeWriteDataTo:
+		payload = new(protocol.WriteDataTo)
+	case types.TxTypeSyntheticWriteData:
+		payload = new(protocol.SyntheticWriteData)
+	case types.TxTypeSegWitDataEntry:
+		payload = new(protocol.SegWitDataEntry)
 	default:
 		return nil, fmt.Errorf("unknown TX type %v", typ)
 	}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/AccumulateNetwork/accumulate/config"
 	"github.com/AccumulateNetwork/accumulate/internal/url"
 	"github.com/AccumulateNetwork/accumulate/protocol"
 	"github.com/AccumulateNetwork/accumulate/smt/storage"
@@ -12,48 +13,39 @@ import (
 	"github.com/AccumulateNetwork/accumulate/types/state"
 )
 
-type AddCredits struct{}
+type AddCredits struct {
+	Network *config.Network
+}
 
 func (AddCredits) Type() types.TxType { return types.TxTypeAddCredits }
 
-func (AddCredits) Validate(st *StateManager, tx *transactions.GenTransaction) error {
+func (x AddCredits) Validate(st *StateManager, tx *transactions.GenTransaction) error {
 	body := new(protocol.AddCredits)
 	err := tx.As(body)
 	if err != nil {
 		return fmt.Errorf("invalid payload: %v", err)
 	}
 
+	// The subnet's oracle holds the last price anchored from the DN
+	oracle := new(protocol.AcmeOracle)
+	err = st.LoadUrlAs(x.Network.NodeUrl().JoinPath(protocol.Oracle), oracle)
+	if err != nil {
+		return fmt.Errorf("failed to load the ACME oracle: %v", err)
+	}
+	if oracle.Price == 0 {
+		return fmt.Errorf("the ACME oracle has not been set")
+	}
+
 	// tokens = credits / (credits per dollar) / (dollars per token)
 	amount := types.NewAmount(protocol.AcmePrecision) // Do everything with ACME precision
 	amount.Mul(int64(body.Amount))                    // Amount in credits
+	amount.Mul(protocol.AcmeOraclePrecision)          // Scale by the oracle precision
 	amount.Div(protocol.CreditsPerFiatUnit)           // Amount in dollars
-	amount.Div(protocol.FiatUnitsPerAcmeToken)        // Amount in tokens
+	amount.Div(int64(oracle.Price))                   // Amount in tokens
 
-	recvUrl, err := url.Parse(body.Recipient)
+	recvUrl, err := checkCreditRecipient(st, tx, body.Recipient)
 	if err != nil {
-		return fmt.Errorf("invalid recipient")
-	}
-
-	recv, err := st.LoadUrl(recvUrl)
-	if err == nil {
-		// If the recipient happens to be on the same BVC, ensure it is a valid
-		// recipient. Most credit transfers will be within the same ADI, so this
-		// should catch most mistakes early.
-		switch recv := recv.(type) {
-		case *protocol.LiteTokenAccount, *protocol.KeyPage:
-			// OK
-		default:
-			return fmt.Errorf("invalid recipient: want chain type %v or %v, got %v", types.ChainTypeLiteTokenAccount, types.ChainTypeKeyPage, recv.Header().Type)
-		}
-	} else if errors.Is(err, storage.ErrNotFound) {
-		if recvUrl.Routing() == tx.Routing {
-			// If the recipient and the origin have the same routing number,
-			// they must be on the same BVC. Thus in that case, failing to
-			// locate the recipient chain means it doesn't exist.
-			return fmt.Errorf("invalid recipient: not found")
-		}
-	} else {
-		return fmt.Errorf("failed to load recipient: %v", err)
+		return err
 	}
 
 	var account tokenChain
@@ -93,3 +85,36 @@ func (AddCredits) Validate(st *StateManager, tx *transactions.GenTransaction) er
 
 	return nil
 }
+
+// checkCreditRecipient parses the recipient of a credit deposit and, if the
+// recipient is local, verifies that it can hold credits.
+func checkCreditRecipient(st *StateManager, tx *transactions.GenTransaction, recipient string) (*url.URL, error) {
+	recvUrl, err := url.Parse(recipient)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient")
+	}
+
+	recv, err := st.LoadUrl(recvUrl)
+	if err == nil {
+		// If the recipient happens to be on the same BVC, ensure it is a valid
+		// recipient. Most credit transfers will be within the same ADI, so this
+		// should catch most mistakes early.
+		switch recv := recv.(type) {
+		case *protocol.LiteTokenAccount, *protocol.KeyPage:
+			// OK
+		default:
+			return nil, fmt.Errorf("invalid recipient: want chain type %v or %v, got %v", types.ChainTypeLiteTokenAccount, types.ChainTypeKeyPage, recv.Header().Type)
+		}
+	} else if errors.Is(err, storage.ErrNotFound) {
+		if recvUrl.Routing() == tx.Routing {
+			// If the recipient and the origin have the same routing number,
+			// they must be on the same BVC. Thus in that case, failing to
+			// locate the recipient chain means it doesn't exist.
+			return nil, fmt.Errorf("invalid recipient: not found")
+		}
+	} else {
+		return nil, fmt.Errorf("failed to load recipient: %v", err)
+	}
+
+	return recvUrl, nil
+}
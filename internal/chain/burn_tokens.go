@@ -1,12 +1,12 @@
 package chain
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/AccumulateNetwork/accumulate/protocol"
 	"github.com/AccumulateNetwork/accumulate/types"
 	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
 )
 
 type BurnTokens struct{}
@@ -20,5 +20,42 @@ func (BurnTokens) Validate(st *StateManager, tx *transactions.GenTransaction) er
 		return fmt.Errorf("invalid payload: %v", err)
 	}
 
-	return errors.New("not implemented") // TODO
+	var account tokenChain
+	switch origin := st.Origin.(type) {
+	case *state.TokenAccount:
+		account = origin
+	case *protocol.LiteTokenAccount:
+		account = origin
+	default:
+		return fmt.Errorf("invalid origin record: want %v or %v, got %v", types.ChainTypeTokenAccount, types.ChainTypeLiteTokenAccount, st.Origin.Header().Type)
+	}
+
+	tokenUrl, err := account.ParseTokenUrl()
+	if err != nil {
+		return fmt.Errorf("invalid token URL: %v", err)
+	}
+
+	if body.Amount.Sign() <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+
+	if !account.DebitTokens(&body.Amount) {
+		return fmt.Errorf("%q balance is insufficient", st.OriginUrl)
+	}
+	st.Update(account)
+
+	burn := new(protocol.SyntheticBurnTokens)
+	copy(burn.Cause[:], tx.TransactionHash())
+	burn.Amount.Set(&body.Amount)
+	burn.Source = st.OriginUrl.String()
+	st.Submit(tokenUrl, burn)
+
+	//create a transaction reference chain acme-xxxxx/0, 1, 2, ... n.
+	//This will reference the txid to keep the history
+	txHash := types.Bytes(tx.TransactionHash()).AsBytes32()
+	refUrl := st.OriginUrl.JoinPath(fmt.Sprint(account.NextTx()))
+	txr := state.NewTxReference(refUrl.String(), txHash[:])
+	st.Update(txr)
+
+	return nil
 }
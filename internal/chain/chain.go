@@ -19,6 +19,9 @@ func NewNodeExecutor(opts ExecutorOptions) (*Executor, error) {
 			SyntheticSignTransactions{},
 			SyntheticAnchor{Network: &opts.Network},
 			SyntheticMirror{},
+			SyntheticExternalAnchor{DB: opts.DB, Network: &opts.Network},
+			UpdateAcmeOracle{Network: &opts.Network},
+			UpdateValidator{Network: &opts.Network},
 		)
 
 	case config.BlockValidator:
@@ -26,18 +29,29 @@ func NewNodeExecutor(opts ExecutorOptions) (*Executor, error) {
 			CreateIdentity{},
 			SendTokens{},
 			CreateTokenAccount{},
+			CreateToken{},
+			IssueTokens{},
+			BurnTokens{},
 			CreateDataAccount{},
-			AddCredits{},
+			AddCredits{Network: &opts.Network},
+			TransferCredits{},
 			CreateKeyPage{},
 			CreateKeyBook{},
 			UpdateKeyPage{},
+			UpdateKeyBook{},
+			UpdateAccountAuth{},
+			UpdateManager{},
 			WriteData{},
+			WriteDataTo{},
 			SyntheticCreateChain{},
 			SyntheticDepositTokens{},
 			SyntheticDepositCredits{},
+			SyntheticBurnTokens{},
+			SyntheticWriteData{},
 			SyntheticSignTransactions{},
 			SyntheticAnchor{Network: &opts.Network},
 			SyntheticMirror{},
+			UpdateValidator{Network: &opts.Network},
 
 			// TODO Only for TestNet
 			AcmeFaucet{},
@@ -50,10 +64,10 @@ func NewNodeExecutor(opts ExecutorOptions) (*Executor, error) {
 
 // NewGenesisExecutor creates a transaction executor that can be used to set up
 // the genesis state.
-func NewGenesisExecutor(db *state.StateDB, typ config.NetworkType) (*Executor, error) {
+func NewGenesisExecutor(db *state.StateDB, typ config.NetworkType, id string) (*Executor, error) {
 	return newExecutor(ExecutorOptions{
 		DB:        db,
-		Network:   config.Network{Type: typ},
+		Network:   config.Network{Type: typ, ID: id},
 		isGenesis: true,
 	})
 }
@@ -33,15 +33,7 @@ func (CreateDataAccount) Validate(st *StateManager, tx *transactions.GenTransact
 	//create the data account
 	account := protocol.NewDataAccount()
 	account.ChainUrl = types.String(dataAccountUrl.String())
-
-	//if we have a manger book URL, then we need make sure it is valid url syntax
-	if body.ManagerKeyBookUrl != "" {
-		u, err := url.Parse(body.ManagerKeyBookUrl)
-		if err != nil {
-			return fmt.Errorf("manager key book specified, but url is invalid: %v", err)
-		}
-		account.ManagerKeyBook = types.String(u.String())
-	}
+	account.Scratch = body.Scratch
 
 	//setup key book associated with account
 	if body.KeyBookUrl == "" {
@@ -60,6 +52,43 @@ func (CreateDataAccount) Validate(st *StateManager, tx *transactions.GenTransact
 		copy(account.KeyBook[:], keyBookUrl.ResourceChain())
 	}
 
+	//if we have a manager book URL, then it must be a key book other than the
+	//account's own key book
+	if body.ManagerKeyBookUrl != "" {
+		u, err := validateManagerKeyBook(st, body.ManagerKeyBookUrl, account.KeyBook)
+		if err != nil {
+			return err
+		}
+		account.ManagerKeyBook = types.String(u.String())
+	}
+
 	st.Create(account)
 	return nil
 }
+
+// validateManagerKeyBook verifies that the URL refers to a key book that can
+// manage an account assigned to the given key book.
+func validateManagerKeyBook(st *StateManager, bookUrl string, keyBook types.Bytes32) (*url.URL, error) {
+	u, err := url.Parse(bookUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manager key book URL: %v", err)
+	}
+
+	// The manager key book must be local so that the signatures of its pages
+	// can be checked against the current state of the book
+	if !st.isLocal(u) {
+		return nil, fmt.Errorf("%q cannot manage %q: it is not in the same subnet", u, st.OriginUrl)
+	}
+
+	book := new(protocol.KeyBook)
+	err = st.LoadUrlAs(u, book)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manager key book %q: %v", u, err)
+	}
+
+	if types.Bytes(u.ResourceChain()).AsBytes32() == keyBook {
+		return nil, fmt.Errorf("%q cannot manage an account assigned to it", u)
+	}
+
+	return u, nil
+}
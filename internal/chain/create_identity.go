@@ -51,8 +51,14 @@ func (CreateIdentity) Validate(st *StateManager, tx *transactions.GenTransaction
 		pageUrl = identityUrl.JoinPath(body.KeyPageName)
 	}
 
+	err = validateKey(body.KeyAlgorithm, body.PublicKey)
+	if err != nil {
+		return err
+	}
+
 	keySpec := new(protocol.KeySpec)
 	keySpec.PublicKey = body.PublicKey
+	keySpec.KeyAlgorithm = body.KeyAlgorithm
 
 	page := protocol.NewKeyPage()
 	page.ChainUrl = types.String(pageUrl.String()) // TODO Allow override
@@ -69,8 +69,14 @@ func (CreateKeyPage) Validate(st *StateManager, tx *transactions.GenTransaction)
 	}
 
 	for _, sig := range body.Keys {
+		err = validateKey(sig.KeyAlgorithm, sig.PublicKey)
+		if err != nil {
+			return err
+		}
+
 		ss := new(protocol.KeySpec)
 		ss.PublicKey = sig.PublicKey
+		ss.KeyAlgorithm = sig.KeyAlgorithm
 		spec.Keys = append(spec.Keys, ss)
 	}
 
@@ -1,12 +1,13 @@
 package chain
 
 import (
-	"errors"
 	"fmt"
 
+	"github.com/AccumulateNetwork/accumulate/internal/url"
 	"github.com/AccumulateNetwork/accumulate/protocol"
 	"github.com/AccumulateNetwork/accumulate/types"
 	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
 )
 
 type CreateToken struct{}
@@ -20,5 +21,62 @@ func (CreateToken) Validate(st *StateManager, tx *transactions.GenTransaction) e
 		return fmt.Errorf("invalid payload: %v", err)
 	}
 
-	return errors.New("not implemented") // TODO
+	if _, ok := st.Origin.(*state.AdiState); !ok {
+		return fmt.Errorf("invalid origin record: want %v, got %v", types.ChainTypeIdentity, st.Origin.Header().Type)
+	}
+
+	issuerUrl, err := url.Parse(body.Url)
+	if err != nil {
+		return fmt.Errorf("invalid token URL: %v", err)
+	}
+
+	if !issuerUrl.Identity().Equal(st.OriginUrl) {
+		return fmt.Errorf("%q cannot be the origininator of %q", st.OriginUrl, issuerUrl)
+	}
+
+	if body.Symbol == "" {
+		return fmt.Errorf("missing token symbol")
+	}
+
+	if body.Precision > 18 {
+		return fmt.Errorf("precision must be in range 0 to 18")
+	}
+
+	if body.SupplyLimit.Sign() < 0 {
+		return fmt.Errorf("supply limit cannot be negative")
+	}
+
+	issuer := protocol.NewTokenIssuer()
+	issuer.ChainUrl = types.String(issuerUrl.String())
+	issuer.Symbol = body.Symbol
+	issuer.Precision = body.Precision
+	issuer.SupplyLimit.Set(&body.SupplyLimit)
+
+	if body.Properties != "" {
+		u, err := url.Parse(body.Properties)
+		if err != nil {
+			return fmt.Errorf("invalid properties URL: %v", err)
+		}
+		issuer.Properties = u.String()
+	}
+
+	if body.KeyBookUrl == "" {
+		issuer.KeyBook = st.Origin.Header().KeyBook
+	} else {
+		keyBookUrl, err := url.Parse(body.KeyBookUrl)
+		if err != nil {
+			return fmt.Errorf("invalid key book URL: %v", err)
+		}
+
+		book := new(protocol.KeyBook)
+		err = st.LoadUrlAs(keyBookUrl, book)
+		if err != nil {
+			return fmt.Errorf("invalid key book %q: %v", keyBookUrl, err)
+		}
+
+		copy(issuer.KeyBook[:], keyBookUrl.ResourceChain())
+	}
+
+	st.Create(issuer)
+	return nil
 }
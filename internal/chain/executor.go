@@ -12,6 +12,7 @@ import (
 
 	"github.com/AccumulateNetwork/accumulate/config"
 	"github.com/AccumulateNetwork/accumulate/internal/abci"
+	"github.com/AccumulateNetwork/accumulate/internal/anchoring"
 	"github.com/AccumulateNetwork/accumulate/internal/api/v2"
 	"github.com/AccumulateNetwork/accumulate/internal/logging"
 	"github.com/AccumulateNetwork/accumulate/protocol"
@@ -36,6 +37,8 @@ type Executor struct {
 	mu      *sync.Mutex
 	chainWG map[uint64]*sync.WaitGroup
 	leader  bool
+	updates []abci.ValidatorUpdate
+	pending [][32]byte
 	height  int64
 	dbTx    *state.DBTransaction
 	time    time.Time
@@ -50,6 +53,11 @@ type ExecutorOptions struct {
 	Key     ed25519.PrivateKey
 	Local   api.ABCIBroadcastClient
 	Network config.Network
+	Storage config.Storage
+
+	// Anchorer anchors the roots of major blocks of the DN to an external
+	// ledger. Anchorer is optional.
+	Anchorer anchoring.ExternalAnchorer
 
 	isGenesis bool
 
@@ -234,6 +242,8 @@ func (m *Executor) BeginBlock(req abci.BeginBlockRequest) (abci.BeginBlockRespon
 	m.time = req.Time
 	m.chainWG = make(map[uint64]*sync.WaitGroup, chainWGSize)
 	m.dbTx = m.DB.Begin()
+	m.updates = nil
+	m.pending = nil
 
 	// In order for other BVCs to be able to validate the synthetic transaction,
 	// a wrapped signed version must be resubmitted to this BVC network and the
@@ -257,6 +267,13 @@ func (m *Executor) BeginBlock(req abci.BeginBlockRequest) (abci.BeginBlockRespon
 		return abci.BeginBlockResponse{}, err
 	}
 
+	// Record the root of the synthetic transaction chain, so that receipts can
+	// be built once the DN has anchored it
+	err = m.indexSynthRoot()
+	if err != nil {
+		return abci.BeginBlockResponse{}, err
+	}
+
 	// Send synthetic transactions produced in the previous block
 	txns, err := m.sendSynthTxns()
 	if err != nil {
@@ -278,27 +295,96 @@ func (m *Executor) BeginBlock(req abci.BeginBlockRequest) (abci.BeginBlockRespon
 }
 
 // EndBlock implements ./abci.Chain
-func (m *Executor) EndBlock(req abci.EndBlockRequest) {}
+func (m *Executor) EndBlock(req abci.EndBlockRequest) abci.EndBlockResponse {
+	m.wg.Wait()
+	return abci.EndBlockResponse{ValidatorUpdates: collapseValidatorUpdates(m.updates)}
+}
+
+// collapseValidatorUpdates merges the changes to each validator, so that the
+// last change wins. Tendermint rejects a set of updates that changes the same
+// validator more than once.
+func collapseValidatorUpdates(updates []abci.ValidatorUpdate) []abci.ValidatorUpdate {
+	var collapsed []abci.ValidatorUpdate
+	index := map[string]int{}
+	for _, update := range updates {
+		i, ok := index[string(update.PubKey)]
+		if ok {
+			collapsed[i].Power = update.Power
+			continue
+		}
+
+		index[string(update.PubKey)] = len(collapsed)
+		collapsed = append(collapsed, update)
+	}
+	return collapsed
+}
 
 // Commit implements ./abci.Chain
 func (m *Executor) Commit() ([]byte, error) {
 	m.wg.Wait()
 
+	err := m.expirePendingTransactions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to expire pending transactions: %v", err)
+	}
+
+	err = m.indexPendingTransactions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to index pending transactions: %v", err)
+	}
+
+	err = m.pruneScratchEntries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune scratch entries: %v", err)
+	}
+
+	err = m.prunePendingTransactions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune pending transactions: %v", err)
+	}
+
 	subnet, err := m.DB.SubnetID()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load subnet ID: %v", err)
 	}
 
+	major, err := m.isMajorBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for a major block: %v", err)
+	}
+
 	mdRoot, err := m.dbTx.Commit(m.height, m.time, func() error {
-		// Add a synthetic transaction for the previous block's anchor
-		err := m.addAnchorTxn()
+		// Mirror the subnet's ADI immediately after genesis, and mirror its
+		// records again whenever they change
+		var mirror *protocol.SyntheticMirror
+		var err error
+		switch m.Network.Type {
+		case config.Directory:
+			mirror, err = m.buildMirror(protocol.DnUrl())
+		case config.BlockValidator:
+			mirror, err = m.buildMirror(protocol.BvnUrl(subnet))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to mirror %s: %v", subnet, err)
+		}
+
+		// Add a synthetic transaction for the previous block's anchor. The
+		// mirror is proven against the anchor, so the anchor must be sent
+		// along with it.
+		err = m.addAnchorTxn(mirror != nil)
 		if err != nil {
 			return err
 		}
 
-		// Mirror the subnet's ADI, but only immediately after genesis
-		if m.height != 2 || m.IsTest {
-			// TODO Don't skip during testing
+		// Roll up the minor anchors on the major block schedule
+		if major {
+			err = m.addMajorBlock()
+			if err != nil {
+				return fmt.Errorf("failed to add major block: %v", err)
+			}
+		}
+
+		if mirror == nil {
 			return nil
 		}
 
@@ -306,11 +392,6 @@ func (m *Executor) Commit() ([]byte, error) {
 		switch m.Network.Type {
 		case config.Directory:
 			// Mirror DN ADI
-			mirror, err := m.mirrorADIs(protocol.DnUrl())
-			if err != nil {
-				return fmt.Errorf("failed to mirror BVN ADI: %v", err)
-			}
-
 			for _, bvn := range m.Network.BvnNames {
 				tx, err := m.buildSynthTxn(protocol.BvnUrl(bvn), mirror)
 				if err != nil {
@@ -321,11 +402,6 @@ func (m *Executor) Commit() ([]byte, error) {
 
 		case config.BlockValidator:
 			// Mirror BVN ADI
-			mirror, err := m.mirrorADIs(protocol.BvnUrl(subnet))
-			if err != nil {
-				return fmt.Errorf("failed to mirror BVN ADI: %v", err)
-			}
-
 			tx, err := m.buildSynthTxn(protocol.DnUrl(), mirror)
 			if err != nil {
 				return fmt.Errorf("failed to build mirror txn: %v", err)
@@ -345,5 +421,13 @@ func (m *Executor) Commit() ([]byte, error) {
 
 	m.logInfo("Committed", "db_time", m.DB.TimeBucket)
 	m.DB.TimeBucket = 0
+
+	if major {
+		err = m.anchorMajorBlock()
+		if err != nil {
+			return nil, fmt.Errorf("failed to anchor major block: %v", err)
+		}
+	}
+
 	return mdRoot, nil
 }
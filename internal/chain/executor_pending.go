@@ -0,0 +1,155 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+)
+
+// pendingIndexKey is the key of the pending transaction set within a key
+// page's pending index.
+const pendingIndexKey = "Transactions"
+
+// pendingStatus is the part of a pending transaction's status that indicates
+// whether it is waiting for more signatures and whether its signatures have
+// been pruned. A pending transaction also records what its submissions have
+// paid and the key page it is waiting on.
+type pendingStatus struct {
+	Pending bool   `json:"pending"`
+	Pruned  bool   `json:"pruned"`
+	Paid    uint64 `json:"paid"`
+	KeyPage string `json:"keyPage"`
+}
+
+// loadPendingStatus loads the status of a transaction that is waiting for
+// more signatures. If the transaction is not pending, loadPendingStatus
+// returns nil.
+func (m *Executor) loadPendingStatus(txid []byte) (*pendingStatus, error) {
+	pending, err := m.loadPendingTransaction(txid)
+	if err != nil || pending == nil {
+		return nil, err
+	}
+
+	status := new(pendingStatus)
+	if json.Unmarshal(pending.Status, status) != nil || !status.Pending {
+		return nil, nil
+	}
+	return status, nil
+}
+
+// loadPendingFeePaid returns the amount the previous submissions of a pending
+// transaction have paid.
+func (m *Executor) loadPendingFeePaid(txid []byte) (uint64, error) {
+	status, err := m.loadPendingStatus(txid)
+	if err != nil || status == nil {
+		return 0, err
+	}
+	return status.Paid, nil
+}
+
+// loadPendingTransaction loads the pending state of a transaction. If the
+// transaction has never been submitted, loadPendingTransaction returns nil.
+func (m *Executor) loadPendingTransaction(txid []byte) (*state.PendingTransaction, error) {
+	data, err := m.dbTx.GetPendingTx(txid)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load pending transaction %X: %v", txid, err)
+	}
+
+	pending, err := unmarshalPendingTransaction(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pending transaction %X: %v", txid, err)
+	}
+	return pending, nil
+}
+
+// unmarshalPendingTransaction unmarshals a pending transaction state object.
+func unmarshalPendingTransaction(data []byte) (*state.PendingTransaction, error) {
+	obj := new(state.Object)
+	err := obj.UnmarshalBinary(data)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := new(state.PendingTransaction)
+	err = obj.As(pending)
+	if err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// isPending returns true if the transaction is waiting for more signatures.
+func isPending(tx *state.PendingTransaction) bool {
+	var status pendingStatus
+	return json.Unmarshal(tx.Status, &status) == nil && status.Pending
+}
+
+// addSignatures adds the signatures collected by previous submissions of a
+// transaction. The signatures of the new submission are kept first, since only
+// the nonce of the first signature is checked. A key that signed both is only
+// counted once.
+func addSignatures(tx *transactions.GenTransaction, prev *state.PendingTransaction) {
+	for _, sig := range prev.Signature {
+		var found bool
+		for _, s := range tx.Signature {
+			if bytes.Equal(s.GetPublicKey(), sig.GetPublicKey()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			tx.Signature = append(tx.Signature, sig)
+		}
+	}
+}
+
+// loadPendingIndex loads the list of pending transactions of a key page.
+func loadPendingIndex(dbTx *state.DBTransaction, page [32]byte) (*protocol.PendingTransactionSet, error) {
+	set := new(protocol.PendingTransactionSet)
+	data, err := dbTx.GetIndex(state.PendingIndex, page[:], pendingIndexKey)
+	switch {
+	case err == nil:
+		err = set.UnmarshalBinary(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pending transaction index: %v", err)
+		}
+	case !errors.Is(err, storage.ErrNotFound):
+		return nil, fmt.Errorf("failed to load pending transaction index: %v", err)
+	}
+	return set, nil
+}
+
+// updatePendingIndex adds a transaction to or removes it from the list of
+// pending transactions of a key page.
+func (m *Executor) updatePendingIndex(page [32]byte, txid [32]byte, add bool) error {
+	set, err := loadPendingIndex(m.dbTx, page)
+	if err != nil {
+		return err
+	}
+
+	var changed bool
+	if add {
+		changed = set.Add(txid)
+	} else {
+		changed = set.Remove(txid)
+	}
+	if !changed {
+		return nil
+	}
+
+	data, err := set.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending transaction index: %v", err)
+	}
+
+	m.dbTx.WriteIndex(state.PendingIndex, page[:], pendingIndexKey, data)
+	return nil
+}
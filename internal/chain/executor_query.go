@@ -120,6 +120,58 @@ func (m *Executor) queryByTxId(txid []byte) (*query.ResponseByTxId, error) {
 	return &qr, nil
 }
 
+func (m *Executor) queryPendingTransactions(u *url.URL) (*query.ResponsePendingTransactions, error) {
+	obj, err := m.queryByChainId(u.ResourceChain())
+	if err != nil {
+		return nil, err
+	}
+
+	page := new(protocol.KeyPage)
+	err = obj.As(page)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a key page: %v", u, err)
+	}
+
+	qr := new(query.ResponsePendingTransactions)
+	qr.KeyPage = page.GetChainUrl()
+	qr.Threshold = page.GetThreshold()
+
+	set := new(protocol.PendingTransactionSet)
+	data, err := m.DB.GetIndex(state.PendingIndex, u.ResourceChain(), pendingIndexKey)
+	switch {
+	case err == nil:
+		err = set.UnmarshalBinary(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pending transaction index: %v", err)
+		}
+	case !errors.Is(err, storage.ErrNotFound):
+		return nil, fmt.Errorf("failed to load pending transaction index: %v", err)
+	}
+
+	for _, txid := range set.Transactions {
+		data, err := m.DB.GetPendingTx(txid[:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to load pending transaction %X: %v", txid, err)
+		}
+
+		pending, err := unmarshalPendingTransaction(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pending transaction %X: %v", txid, err)
+		}
+
+		status := new(query.PendingTransactionStatus)
+		status.TxId = txid
+		status.Origin = pending.TransactionState.SigInfo.URL
+		status.KeyPageHeight = pending.TransactionState.SigInfo.KeyPageHeight
+		for _, sig := range pending.Signature {
+			status.Signers = append(status.Signers, sig.GetPublicKey())
+		}
+		qr.Transactions = append(qr.Transactions, status)
+	}
+
+	return qr, nil
+}
+
 func (m *Executor) queryDataByUrl(u *url.URL) (*protocol.ResponseDataEntry, error) {
 	qr := protocol.ResponseDataEntry{}
 
@@ -164,10 +216,16 @@ func (m *Executor) queryDataSet(u *url.URL, start int64, limit int64, expand boo
 	for i := range entryHashes {
 		if expand {
 			entry, err := m.queryDataByEntryHash(u, entryHashes[i][:])
-			if err != nil {
+			switch {
+			case err == nil:
+				qr.DataEntries = append(qr.DataEntries, *entry)
+			case errors.Is(err, storage.ErrNotFound):
+				// The body of a pruned scratch entry is gone, but its hash is
+				// still on the chain
+				qr.DataEntries = append(qr.DataEntries, protocol.ResponseDataEntry{EntryHash: entryHashes[i]})
+			default:
 				return nil, err
 			}
-			qr.DataEntries = append(qr.DataEntries, *entry)
 		} else {
 			qr.DataEntries = append(qr.DataEntries, protocol.ResponseDataEntry{EntryHash: entryHashes[i]})
 		}
@@ -387,6 +445,25 @@ func (m *Executor) Query(q *query.Query) (k, v []byte, err *protocol.Error) {
 		if err != nil {
 			return nil, nil, &protocol.Error{Code: protocol.CodeMarshallingError, Message: err}
 		}
+	case types.QueryTypePendingTransactions:
+		req := query.RequestPendingTransactions{}
+		err := req.UnmarshalBinary(q.Content)
+		if err != nil {
+			return nil, nil, &protocol.Error{Code: protocol.CodeUnMarshallingError, Message: err}
+		}
+		u, err := url.Parse(req.Url)
+		if err != nil {
+			return nil, nil, &protocol.Error{Code: protocol.CodeInvalidURL, Message: fmt.Errorf("invalid URL in query %s", req.Url)}
+		}
+		response, err := m.queryPendingTransactions(u)
+		if err != nil {
+			return nil, nil, &protocol.Error{Code: protocol.CodeTxnQueryError, Message: err}
+		}
+		k = []byte("pending")
+		v, err = response.MarshalBinary()
+		if err != nil {
+			return nil, nil, &protocol.Error{Code: protocol.CodeMarshallingError, Message: err}
+		}
 	default:
 		return nil, nil, &protocol.Error{Code: protocol.CodeInvalidQueryType, Message: fmt.Errorf("unable to query for type, %s (%d)", q.Type.Name(), q.Type.AsUint64())}
 	}
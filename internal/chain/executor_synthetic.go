@@ -94,7 +94,9 @@ func (m *Executor) addSystemTxns(txns ...*transactions.GenTransaction) error {
 	return nil
 }
 
-func (m *Executor) addAnchorTxn() error {
+// addAnchorTxn adds an anchor for the current block, if anything happened in
+// the block or if records of the subnet are being mirrored.
+func (m *Executor) addAnchorTxn(mirrored bool) error {
 	synth, err := m.DB.SynthTxidChain()
 	if err != nil {
 		return err
@@ -120,6 +122,8 @@ func (m *Executor) addAnchorTxn() error {
 		// Modified chains last block, continue
 	case synthHead.Index == m.height:
 		// Produced synthetic transactions last block, continue
+	case mirrored:
+		// Mirrored records are proven against the anchor, continue
 	default:
 		// Nothing happened last block, so skip creating an anchor txn
 		return nil
@@ -134,8 +138,30 @@ func (m *Executor) addAnchorTxn() error {
 	copy(body.ChainAnchor[:], anchor.Chain.Anchor())
 	copy(body.SynthTxnAnchor[:], synth.Chain.Anchor())
 
+	// Send the price of ACME to the BVNs
+	if m.Network.Type == config.Directory {
+		oracle := new(protocol.AcmeOracle)
+		_, err = m.dbTx.LoadChainAs(protocol.DnUrl().JoinPath(protocol.Oracle).ResourceChain(), oracle)
+		if err != nil {
+			return fmt.Errorf("failed to load the ACME oracle: %v", err)
+		}
+		body.AcmeOraclePrice = oracle.Price
+
+		// Relay the roots of the BVNs that were anchored this block
+		body.SubnetAnchors, err = m.relayedSubnetAnchors()
+		if err != nil {
+			return err
+		}
+	}
+
 	m.logDebug("Creating anchor txn", "root", logging.AsHex(body.Root), "chains", logging.AsHex(body.ChainAnchor), "synth", logging.AsHex(body.SynthTxnAnchor))
 
+	return m.sendAnchorTxn(body)
+}
+
+// sendAnchorTxn adds system transactions that send the anchor from the DN to
+// every BVN, or from a BVN to the DN.
+func (m *Executor) sendAnchorTxn(body *protocol.SyntheticAnchor) error {
 	var txns []*transactions.GenTransaction
 	switch m.Network.Type {
 	case config.Directory:
@@ -363,14 +389,44 @@ func (m *Executor) sendSynthTxns() ([]abci.SynthTxnReference, error) {
 			Signature: sig.Signature,
 		})
 
-		// Marshal the transaction
-		raw, err := tx.Marshal()
+		// Parse the URL
+		u, err := url.Parse(tx.SigInfo.URL)
 		if err != nil {
 			return nil, err
 		}
 
-		// Parse the URL
-		u, err := url.Parse(tx.SigInfo.URL)
+		// Transactions sent to another subnet must carry a receipt. Hold the
+		// transaction until the DN has anchored a root that includes it.
+		_, local := m.dispatcher.route(u)
+		switch {
+		case local:
+			// Local transactions are verified against the local validators
+
+		case tx.TransactionType() == types.TxTypeSyntheticAnchor:
+			// Anchors are not on the synthetic transaction chain. The DN's
+			// roots are anchored once its anchor has been sent.
+			err = m.recordAnchorSent(tx)
+			if err != nil {
+				return nil, err
+			}
+
+		case tx.TransactionType() == types.TxTypeSyntheticMirror:
+			// Mirrors are not on the synthetic transaction chain. Each record
+			// carries its own proof.
+
+		default:
+			receipt, err := m.synthReceipt(sig.Txid[:])
+			if err != nil {
+				return nil, err
+			}
+			if receipt == nil {
+				continue
+			}
+			tx.Signature = append(tx.Signature, receipt)
+		}
+
+		// Marshal the transaction
+		raw, err := tx.Marshal()
 		if err != nil {
 			return nil, err
 		}
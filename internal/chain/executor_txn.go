@@ -2,12 +2,13 @@ package chain
 
 import (
 	"bytes"
-	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"sync"
 
+	"github.com/AccumulateNetwork/accumulate/internal/logging"
 	"github.com/AccumulateNetwork/accumulate/protocol"
 	"github.com/AccumulateNetwork/accumulate/smt/storage"
 	"github.com/AccumulateNetwork/accumulate/types"
@@ -22,10 +23,14 @@ func (m *Executor) CheckTx(tx *transactions.GenTransaction) *protocol.Error {
 		return &protocol.Error{Code: protocol.CodeRoutingChainId, Message: err}
 	}
 
-	st, err := m.check(tx)
+	st, err := m.check(tx, false)
 	if errors.Is(err, storage.ErrNotFound) {
 		return &protocol.Error{Code: protocol.CodeNotFound, Message: err}
 	}
+	var perr *protocol.Error
+	if errors.As(err, &perr) {
+		return perr
+	}
 	if err != nil {
 		return &protocol.Error{Code: protocol.CodeCheckTxError, Message: err}
 	}
@@ -35,7 +40,9 @@ func (m *Executor) CheckTx(tx *transactions.GenTransaction) *protocol.Error {
 		return &protocol.Error{Code: protocol.CodeInvalidTxnType, Message: fmt.Errorf("unsupported TX type: %v", types.TxType(tx.TransactionType()))}
 	}
 	err = executor.Validate(st, tx)
-	if err != nil {
+	if err != nil && !isRefundable(tx.TransactionType()) {
+		// Deposits and burns are delivered even if they are invalid, so that
+		// the failure is recorded and the tokens are refunded
 		return &protocol.Error{Code: protocol.CodeValidateTxnError, Message: err}
 	}
 	return nil
@@ -83,15 +90,61 @@ func (m *Executor) DeliverTx(tx *transactions.GenTransaction) *protocol.Error {
 	defer group.Done()
 	m.mu.Unlock()
 
-	st, err := m.check(tx)
+	if m.dbTx == nil {
+		m.dbTx = m.DB.Begin()
+	}
+
+	// If the transaction has been submitted before and is waiting for more
+	// signatures, add the signatures that have been collected. Otherwise this
+	// is a new submission, even if an identical transaction was executed
+	// previously.
+	prev, err := m.loadPendingTransaction(tx.TransactionHash())
+	if err != nil {
+		return &protocol.Error{Code: protocol.CodeTxnStateError, Message: err}
+	}
+	if prev != nil && isPending(prev) && !tx.TransactionType().IsSynthetic() {
+		addSignatures(tx, prev)
+		txPending = state.NewPendingTransaction(tx)
+	} else {
+		prev = nil
+	}
+
+	st, err := m.check(tx, false)
 	if err != nil {
-		return m.recordTransactionError(txPending, &chainId, tx.TransactionHash(), &protocol.Error{Code: protocol.CodeCheckTxError, Message: fmt.Errorf("txn check failed : %v", err)})
+		perr := &protocol.Error{Code: protocol.CodeCheckTxError, Message: fmt.Errorf("txn check failed : %v", err)}
+		var cerr *protocol.Error
+		if errors.As(err, &cerr) {
+			perr = &protocol.Error{Code: cerr.Code, Message: fmt.Errorf("txn check failed : %v", cerr.Message)}
+		}
+
+		// Do not overwrite the signatures collected for a pending transaction
+		if prev != nil {
+			return perr
+		}
+		return m.recordTransactionError(txPending, &chainId, tx.TransactionHash(), perr)
+	}
+
+	// Hold the transaction until it has been signed by enough keys
+	if st.pending {
+		return m.recordPendingTransaction(st, txPending, &chainId, tx.TransactionHash())
+	}
+
+	// The transaction is no longer pending, whether it succeeds or fails
+	if prev != nil {
+		err = m.updatePendingIndex(st.keyPage, types.Bytes(tx.TransactionHash()).AsBytes32(), false)
+		if err != nil {
+			return &protocol.Error{Code: protocol.CodeTxnStateError, Message: err}
+		}
 	}
 
 	// Validate
 	// TODO result should return a list of chainId's the transaction touched.
 	err = executor.Validate(st, tx)
 	if err != nil {
+		m.chargeFailedTx(tx)
+		if err := m.refundFailedTokens(tx); err != nil {
+			m.logError("Failed to refund failed tokens", "txid", logging.AsHex(tx.TransactionHash()), "error", err)
+		}
 		return m.recordTransactionError(txPending, &chainId, tx.TransactionHash(), &protocol.Error{Code: protocol.CodeInvalidTxnError, Message: fmt.Errorf("txn validation failed : %v", err)})
 	}
 
@@ -99,7 +152,11 @@ func (m *Executor) DeliverTx(tx *transactions.GenTransaction) *protocol.Error {
 	// split the transaction in 2, the body (i.e. TxAccepted), and the
 	// validation material (i.e. TxPending).  The body of the transaction
 	// gets put on the main chain, and the validation material gets put on
-	// the pending chain which is purged after about 2 weeks
+	// the pending chain whose signatures are pruned after the retention window.
+	// An executor may replace the payload with a segregated witness, e.g.
+	// WriteData replaces the data entry with a reference to it, in which case
+	// the reference is put on the main chain instead of the payload.
+	*txPending.TransactionState.Transaction = tx.Transaction
 	txAccepted, txPending := state.NewTransaction(txPending)
 	txAcceptedObject := new(state.Object)
 	txAcceptedObject.Entry, err = txAccepted.MarshalBinary()
@@ -115,7 +172,7 @@ func (m *Executor) DeliverTx(tx *transactions.GenTransaction) *protocol.Error {
 	}
 
 	// Store the tx state
-	err = m.dbTx.AddTransaction(&chainId, tx.TransactionHash(), txPendingObject, txAcceptedObject)
+	err = m.addTransaction(&chainId, tx.TransactionHash(), txPendingObject, txAcceptedObject)
 	if err != nil {
 		return &protocol.Error{Code: protocol.CodeTxnStateError, Message: err}
 	}
@@ -132,10 +189,26 @@ func (m *Executor) DeliverTx(tx *transactions.GenTransaction) *protocol.Error {
 		return &protocol.Error{Code: protocol.CodeSyntheticTxnError, Message: err}
 	}
 
+	// Queue changes to the validator set for the end of the block
+	if len(st.validators) > 0 {
+		m.mu.Lock()
+		m.updates = append(m.updates, st.validators...)
+		m.mu.Unlock()
+	}
+
+	// If this is a refund, mark the transaction that caused it as bounced
+	err = m.recordBounce(tx)
+	if err != nil {
+		return &protocol.Error{Code: protocol.CodeTxnStateError, Message: err}
+	}
+
 	return nil
 }
 
-func (m *Executor) check(tx *transactions.GenTransaction) (*StateManager, error) {
+// check validates the signatures and the nonce of the transaction, and debits
+// the fee from the signator. If failed is true, the signator is charged the
+// fee for a failed transaction.
+func (m *Executor) check(tx *transactions.GenTransaction, failed bool) (*StateManager, error) {
 	if len(tx.Signature) == 0 {
 		return nil, fmt.Errorf("transaction is not signed")
 	}
@@ -152,7 +225,7 @@ func (m *Executor) check(tx *transactions.GenTransaction) (*StateManager, error)
 	st, err := NewStateManager(m.dbTx, tx)
 	if errors.Is(err, storage.ErrNotFound) {
 		switch txt {
-		case types.TxTypeSyntheticCreateChain, types.TxTypeSyntheticDepositTokens:
+		case types.TxTypeSyntheticCreateChain, types.TxTypeSyntheticDepositTokens, types.TxTypeSyntheticWriteData:
 			// TX does not require an origin - it may create the origin
 		default:
 			return nil, fmt.Errorf("origin record not found: %w", err)
@@ -161,6 +234,13 @@ func (m *Executor) check(tx *transactions.GenTransaction) (*StateManager, error)
 		return nil, err
 	}
 	st.logger = m.logger
+	st.BlockHeight = uint64(m.height)
+	st.Network = &m.Network
+
+	// System transactions can only be sent to the subnet's ADI
+	if nodeUrl := m.Network.NodeUrl(); txt.IsSystem() && !st.OriginUrl.Identity().Equal(nodeUrl) {
+		return nil, fmt.Errorf("%v transactions can only be sent to %v", txt, nodeUrl)
+	}
 
 	if txt.IsSynthetic() {
 		return st, m.checkSynthetic(st, tx)
@@ -169,9 +249,9 @@ func (m *Executor) check(tx *transactions.GenTransaction) (*StateManager, error)
 	book := new(protocol.KeyBook)
 	switch origin := st.Origin.(type) {
 	case *protocol.LiteTokenAccount:
-		return st, m.checkLite(st, tx, origin)
+		return st, m.checkLite(st, tx, origin, failed)
 
-	case *state.AdiState, *state.TokenAccount, *protocol.KeyPage, *protocol.DataAccount:
+	case *state.AdiState, *state.TokenAccount, *protocol.KeyPage, *protocol.DataAccount, *protocol.TokenIssuer, *protocol.AcmeOracle:
 		if (origin.Header().KeyBook == types.Bytes32{}) {
 			return nil, fmt.Errorf("sponsor has not been assigned to a key book")
 		}
@@ -185,7 +265,6 @@ func (m *Executor) check(tx *transactions.GenTransaction) (*StateManager, error)
 
 	default:
 		// The TX origin cannot be a transaction
-		// Token issue chains are not implemented
 		return nil, fmt.Errorf("invalid origin record: chain type %v cannot be the origininator of transactions", origin.Header().Type)
 	}
 
@@ -193,8 +272,7 @@ func (m *Executor) check(tx *transactions.GenTransaction) (*StateManager, error)
 		return nil, fmt.Errorf("invalid sig spec index")
 	}
 
-	page := new(protocol.KeyPage)
-	err = st.LoadAs(book.Pages[tx.SigInfo.KeyPageIndex], page)
+	page, err := loadKeyPage(st, book.Pages[tx.SigInfo.KeyPageIndex])
 	if err != nil {
 		return nil, fmt.Errorf("invalid sig spec: %v", err)
 	}
@@ -208,45 +286,190 @@ func (m *Executor) check(tx *transactions.GenTransaction) (*StateManager, error)
 		return nil, fmt.Errorf("invalid height")
 	}
 
+	// If the origin is managed, the manager key book must also sign
+	var managerPages []*protocol.KeyPage
+	if mgr := st.Origin.Header().ManagerKeyBook; mgr != "" {
+		managerPages, err = loadManagerPages(st, string(mgr))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	signed := map[*protocol.KeySpec]bool{}
+	managerSigned := map[*protocol.KeyPage]map[*protocol.KeySpec]bool{}
 	for i, sig := range tx.Signature {
-		ks := page.FindKey(sig.PublicKey)
+		signer := page
+		ks := page.FindKey(sig.GetPublicKey())
+		for _, mp := range managerPages {
+			if ks != nil {
+				break
+			}
+			signer, ks = mp, mp.FindKey(sig.GetPublicKey())
+		}
 		if ks == nil {
 			return nil, fmt.Errorf("no key spec matches signature %d", i)
 		}
 
+		if alg := protocol.SignatureKeyAlgorithm(sig.Type()); alg != ks.GetKeyAlgorithm() {
+			return nil, fmt.Errorf("signature %d is a %v signature but the key is a %v key", i, sig.Type(), ks.GetKeyAlgorithm())
+		}
+
+		if ks.IsExpired(st.BlockHeight) {
+			return nil, fmt.Errorf("the key of signature %d expired at height %d", i, ks.ExpirationHeight)
+		}
+
 		switch {
 		case i > 0:
 			// Only check the nonce of the first key
-		case ks.Nonce >= sig.Nonce:
+		case ks.Nonce >= sig.GetNonce():
 			return nil, fmt.Errorf("invalid nonce")
 		default:
-			ks.Nonce = sig.Nonce
+			ks.Nonce = sig.GetNonce()
+			if signer != page {
+				st.UpdateNonce(signer)
+			}
+		}
+
+		if signer == page {
+			signed[ks] = true
+			continue
+		}
+		if managerSigned[signer] == nil {
+			managerSigned[signer] = map[*protocol.KeySpec]bool{}
+		}
+		managerSigned[signer][ks] = true
+	}
+
+	// Multiple signatures from the same key only count once
+	st.keyPage = book.Pages[tx.SigInfo.KeyPageIndex]
+	st.pending = uint64(len(signed)) < page.GetThreshold()
+
+	// The manager has approved the transaction if any one of its pages has
+	// met its threshold
+	if len(managerPages) > 0 {
+		var approved bool
+		for mp, keys := range managerSigned {
+			if uint64(len(keys)) >= mp.GetThreshold() {
+				approved = true
+			}
+		}
+		st.pending = st.pending || !approved
+	}
+
+	// Each submission of a multisig transaction that has not been signed by
+	// enough keys is charged the submission fee, so that pending transactions
+	// cannot be spammed for free. What previous submissions paid is credited
+	// against the fee that is charged once the transaction can be executed.
+	paid, err := m.loadPendingFeePaid(tx.TransactionHash())
+	if err != nil {
+		return nil, err
+	}
+
+	if st.pending {
+		fee, err := debitSubmissionFee(st, tx, page, &page.CreditBalance)
+		if err != nil {
+			return nil, err
 		}
+		st.paid = paid + fee
+		return st, nil
 	}
 
-	//cost, err := protocol.ComputeFee(tx)
-	//if err != nil {
-	//	return nil, err
-	//}
-	//if !page.DebitCredits(uint64(cost)) {
-	//	return nil, fmt.Errorf("insufficent credits for the transaction")
-	//}
-	//
-	//st.UpdateCreditBalance(page)
-	st.UpdateNonce(page)
+	err = debitFee(st, tx, page, &page.CreditBalance, failed, paid)
+	if err != nil {
+		return nil, err
+	}
 	return st, nil
 }
 
-func (m *Executor) checkSynthetic(st *StateManager, tx *transactions.GenTransaction) error {
-	//placeholder for special validation rules for synthetic transactions.
-	//need to verify the sender is a legit bvc validator also need the dbvc receipt
-	//so if the transaction is a synth tx, then we need to verify the sender is a BVC validator and
-	//not an impostor. Need to figure out how to do this. Right now we just assume the synth request
-	//sender is legit.
+// loadManagerPages loads the key pages of the manager key book of a managed
+// account.
+func loadManagerPages(st *StateManager, bookUrl string) ([]*protocol.KeyPage, error) {
+	book := new(protocol.KeyBook)
+	err := st.LoadStringAs(bookUrl, book)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manager key book %q: %v", bookUrl, err)
+	}
+
+	pages := make([]*protocol.KeyPage, len(book.Pages))
+	for i, id := range book.Pages {
+		pages[i], err = loadKeyPage(st, id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid manager key page: %v", err)
+		}
+	}
+	return pages, nil
+}
+
+// loadKeyPage loads a key page through the state manager's cache. Credits and
+// nonces are written to the cached record, which is the origin when the key
+// page signs for itself, so that they are not lost when the origin is updated.
+func loadKeyPage(st *StateManager, chainId [32]byte) (*protocol.KeyPage, error) {
+	record, err := st.Load(chainId)
+	if err != nil {
+		return nil, err
+	}
+
+	page, ok := record.(*protocol.KeyPage)
+	if !ok {
+		return nil, fmt.Errorf("want %T, got %T", page, record)
+	}
+	return page, nil
+}
+
+// chargeFailedTx charges the signator of a transaction that failed validation
+// and updates its nonce, so that failed transactions cannot be spammed or
+// replayed for free.
+func (m *Executor) chargeFailedTx(tx *transactions.GenTransaction) {
+	if tx.TransactionType().IsSynthetic() {
+		return
+	}
+
+	st, err := m.check(tx, true)
+	if err == nil {
+		err = st.Commit()
+	}
+	if err != nil {
+		m.logError("Failed to charge the fee for a failed transaction", "txid", logging.AsHex(tx.TransactionHash()), "error", err)
+	}
+}
+
+// debitFee debits the transaction fee, less what was paid by previous
+// submissions, from the signator's credit balance. The fee of a failed
+// transaction is capped at FeeFailedMaximum and at the signator's balance.
+func debitFee(st *StateManager, tx *transactions.GenTransaction, signator creditChain, balance *big.Int, failed bool, paid uint64) error {
+	computeFee := protocol.ComputeFee
+	if account, ok := st.Origin.(*protocol.DataAccount); ok && account.Scratch {
+		computeFee = protocol.ComputeScratchFee
+	}
+
+	fee, err := computeFee(tx)
+	switch {
+	case failed:
+		if err != nil || fee > protocol.FeeFailedMaximum.AsInt() {
+			fee = protocol.FeeFailedMaximum.AsInt()
+		}
+		if balance.Cmp(big.NewInt(int64(fee))) < 0 {
+			fee = int(balance.Int64())
+		}
+	case err != nil:
+		return fmt.Errorf("unable to compute the fee: %v", err)
+	}
+
+	if uint64(fee) > paid {
+		fee -= int(paid)
+	} else {
+		fee = 0
+	}
+
+	if !signator.DebitCredits(uint64(fee)) {
+		return &protocol.Error{Code: protocol.CodeInsufficientCredits, Message: fmt.Errorf("insufficient credits for the transaction: the fee is %d but the balance of %q is %v", fee, signator.Header().ChainUrl, balance)}
+	}
+
+	st.UpdateCreditBalance(signator)
 	return nil
 }
 
-func (m *Executor) checkLite(st *StateManager, tx *transactions.GenTransaction, account *protocol.LiteTokenAccount) error {
+func (m *Executor) checkLite(st *StateManager, tx *transactions.GenTransaction, account *protocol.LiteTokenAccount, failed bool) error {
 	u, err := account.ParseUrl()
 	if err != nil {
 		// This shouldn't happen because invalid URLs should never make it
@@ -262,22 +485,68 @@ func (m *Executor) checkLite(st *StateManager, tx *transactions.GenTransaction,
 	}
 
 	for i, sig := range tx.Signature {
-		sigKH := sha256.Sum256(sig.PublicKey)
-		if !bytes.Equal(urlKH, sigKH[:20]) {
+		sigKH := protocol.LiteKeyHash(protocol.SignatureKeyAlgorithm(sig.Type()), sig.GetPublicKey())
+		if !bytes.Equal(urlKH, sigKH) {
 			return fmt.Errorf("signature %d's public key does not match the origin record", i)
 		}
 
 		switch {
 		case i > 0:
 			// Only check the nonce of the first key
-		case account.Nonce >= sig.Nonce:
+		case account.Nonce >= sig.GetNonce():
 			return fmt.Errorf("invalid nonce")
 		default:
-			account.Nonce = sig.Nonce
+			account.Nonce = sig.GetNonce()
 		}
 	}
 
-	st.UpdateNonce(account)
+	return debitFee(st, tx, account, &account.CreditBalance, failed, 0)
+}
+
+// debitSubmissionFee debits the fee of a submission of a transaction that has
+// not been signed by enough keys to be executed. System transactions are sent
+// by the validators and are not charged.
+func debitSubmissionFee(st *StateManager, tx *transactions.GenTransaction, signator creditChain, balance *big.Int) (uint64, error) {
+	if tx.TransactionType().IsSystem() {
+		st.UpdateNonce(signator)
+		return 0, nil
+	}
+
+	fee := uint64(protocol.FeePendingSubmission)
+	if !signator.DebitCredits(fee) {
+		return 0, &protocol.Error{Code: protocol.CodeInsufficientCredits, Message: fmt.Errorf("insufficient credits for the transaction: the submission fee is %d but the balance of %q is %v", fee, signator.Header().ChainUrl, balance)}
+	}
+
+	st.UpdateCreditBalance(signator)
+	return fee, nil
+}
+
+// recordPendingTransaction records a transaction that has not met the signature
+// threshold of its key page. The transaction is stored on the pending chain,
+// including the body and the signatures collected so far, and is added to the
+// key page's list of pending transactions. The fee and nonce are committed.
+func (m *Executor) recordPendingTransaction(st *StateManager, txPending *state.PendingTransaction, chainId *types.Bytes32, txid []byte) *protocol.Error {
+	err := st.Commit()
+	if err != nil {
+		return m.recordTransactionError(txPending, chainId, txid, &protocol.Error{Code: protocol.CodeRecordTxnError, Message: err})
+	}
+
+	err = m.updatePendingIndex(st.keyPage, types.Bytes(txid).AsBytes32(), true)
+	if err != nil {
+		return &protocol.Error{Code: protocol.CodeTxnStateError, Message: err}
+	}
+
+	txPending.Status = json.RawMessage(fmt.Sprintf("{\"code\":\"0\", \"pending\":true, \"paid\":%d, \"keyPage\":\"%X\"}", st.paid, st.keyPage))
+	txPendingObject := new(state.Object)
+	txPendingObject.Entry, err = txPending.MarshalBinary()
+	if err != nil {
+		return &protocol.Error{Code: protocol.CodeMarshallingError, Message: err}
+	}
+
+	err = m.addTransaction(chainId, txid, txPendingObject, nil)
+	if err != nil {
+		return &protocol.Error{Code: protocol.CodeTxnStateError, Message: err}
+	}
 	return nil
 }
 
@@ -289,7 +558,7 @@ func (m *Executor) recordTransactionError(txPending *state.PendingTransaction, c
 	if err1 != nil {
 		return &protocol.Error{Code: protocol.CodeMarshallingError, Message: fmt.Errorf("failed marshaling pending tx (%v) on error: %v", err1, err)}
 	}
-	err1 = m.dbTx.AddTransaction(chainId, txid, txPendingObject, nil)
+	err1 = m.addTransaction(chainId, txid, txPendingObject, nil)
 	if err1 != nil {
 		err = &protocol.Error{Code: protocol.CodeAddTxnError, Message: fmt.Errorf("error adding pending tx (%v) on error %v", err1, err)}
 	}
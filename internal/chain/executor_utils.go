@@ -31,24 +31,20 @@ func (m *Executor) loadDirectoryEntry(chainId []byte, index uint64) (string, err
 	return string(b), nil
 }
 
-func (m *Executor) mirrorADIs(urls ...*url.URL) (*protocol.SyntheticMirror, error) {
-	mirror := new(protocol.SyntheticMirror)
-
-	for _, u := range urls {
-		chainId := u.ResourceChain()
-		obj, _, err := m.dbTx.LoadChain(chainId)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load %q: %v", u, err)
-		}
-		mirror.Objects = append(mirror.Objects, obj)
+// adiRecordUrls returns the URLs of the ADIs and of the entries of their
+// directories.
+func (m *Executor) adiRecordUrls(adis ...*url.URL) ([]*url.URL, error) {
+	var urls []*url.URL
+	for _, adi := range adis {
+		urls = append(urls, adi)
 
-		md, err := m.loadDirectoryMetadata(chainId)
+		md, err := m.loadDirectoryMetadata(adi.ResourceChain())
 		if err != nil {
-			return nil, fmt.Errorf("failed to load directory for %q: %v", u, err)
+			return nil, fmt.Errorf("failed to load directory for %q: %v", adi, err)
 		}
 
 		for i := uint64(0); i < md.Count; i++ {
-			s, err := m.loadDirectoryEntry(chainId, i)
+			s, err := m.loadDirectoryEntry(adi.ResourceChain(), i)
 			if err != nil {
 				return nil, fmt.Errorf("failed to load directory entry %d", i)
 			}
@@ -57,14 +53,108 @@ func (m *Executor) mirrorADIs(urls ...*url.URL) (*protocol.SyntheticMirror, erro
 			if err != nil {
 				return nil, fmt.Errorf("invalid url %q: %v", s, err)
 			}
+			urls = append(urls, u)
+		}
+	}
 
-			obj, _, err := m.dbTx.LoadChain(u.ResourceChain())
-			if err != nil {
-				return nil, fmt.Errorf("failed to load %q: %v", u, err)
-			}
-			mirror.Objects = append(mirror.Objects, obj)
+	return urls, nil
+}
+
+// changedRecordUrls filters the URLs, returning those of records that were
+// updated in the current block.
+func (m *Executor) changedRecordUrls(urls []*url.URL) ([]*url.URL, error) {
+	anchor, err := m.DB.MinorAnchorChain()
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := anchor.Record()
+	if err != nil {
+		return nil, err
+	}
+	if head.Index != m.height {
+		return nil, nil
+	}
+
+	changed := make(map[[32]byte]bool, len(head.Chains))
+	for _, id := range head.Chains {
+		changed[id] = true
+	}
+
+	var filtered []*url.URL
+	for _, u := range urls {
+		if changed[u.ResourceChain32()] {
+			filtered = append(filtered, u)
+		}
+	}
+	return filtered, nil
+}
+
+// mirrorRecords builds a mirror of the records of the source subnet. Each
+// record carries a receipt that proves its Merkle state against the current
+// root of the minor anchor chain, which is sent in the anchor of this block.
+func (m *Executor) mirrorRecords(source *url.URL, urls ...*url.URL) (*protocol.SyntheticMirror, error) {
+	anchor, err := m.DB.MinorAnchorChain()
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := anchor.Chain.Entry(anchor.Height() - 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the head of the anchor chain: %v", err)
+	}
+
+	mirror := new(protocol.SyntheticMirror)
+	mirror.Source = source.String()
+
+	for _, u := range urls {
+		obj, _, err := m.dbTx.LoadChain(u.ResourceChain())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %q: %v", u, err)
+		}
+
+		entry, err := obj.Anchor()
+		if err != nil {
+			return nil, fmt.Errorf("failed to anchor the state of %q: %v", u, err)
+		}
+
+		receipt, err := anchor.Chain.Receipt(entry, root)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prove the state of %q: %v", u, err)
 		}
+
+		mirror.Objects = append(mirror.Objects, protocol.AnchoredRecord{
+			Record: *obj,
+			Proof:  *protocol.ReceiptFromManaged(receipt),
+		})
 	}
 
 	return mirror, nil
 }
+
+// buildMirror mirrors the records of the source's ADI immediately after
+// genesis, and mirrors them again whenever they change. buildMirror returns nil
+// if there is nothing to mirror.
+func (m *Executor) buildMirror(source *url.URL) (*protocol.SyntheticMirror, error) {
+	if m.IsTest {
+		// TODO Don't skip during testing
+		return nil, nil
+	}
+
+	urls, err := m.adiRecordUrls(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.height != 2 {
+		urls, err = m.changedRecordUrls(urls)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(urls) == 0 {
+		return nil, nil
+	}
+	return m.mirrorRecords(source, urls...)
+}
@@ -1,9 +1,9 @@
 package chain
 
 import (
-	"errors"
 	"fmt"
 
+	"github.com/AccumulateNetwork/accumulate/internal/url"
 	"github.com/AccumulateNetwork/accumulate/protocol"
 	"github.com/AccumulateNetwork/accumulate/types"
 	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
@@ -20,5 +20,31 @@ func (IssueTokens) Validate(st *StateManager, tx *transactions.GenTransaction) e
 		return fmt.Errorf("invalid payload: %v", err)
 	}
 
-	return errors.New("not implemented") // TODO
+	issuer, ok := st.Origin.(*protocol.TokenIssuer)
+	if !ok {
+		return fmt.Errorf("invalid origin record: want %v, got %v", types.ChainTypeTokenIssuer, st.Origin.Header().Type)
+	}
+
+	recipient, err := url.Parse(body.Recipient)
+	if err != nil {
+		return fmt.Errorf("invalid recipient URL: %v", err)
+	}
+
+	if body.Amount.Sign() <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+
+	if !issuer.Issue(&body.Amount) {
+		return fmt.Errorf("cannot issue %v %s: supply limit of %v would be exceeded", &body.Amount, issuer.Symbol, &issuer.SupplyLimit)
+	}
+	st.Update(issuer)
+
+	deposit := new(protocol.SyntheticDepositTokens)
+	copy(deposit.Cause[:], tx.TransactionHash())
+	deposit.Token = st.OriginUrl.String()
+	deposit.Amount.Set(&body.Amount)
+	deposit.Source = st.OriginUrl.String()
+	st.Submit(recipient, deposit)
+
+	return nil
 }
@@ -0,0 +1,57 @@
+package chain_test
+
+import (
+	"testing"
+
+	. "github.com/AccumulateNetwork/accumulate/internal/chain"
+	acctesting "github.com/AccumulateNetwork/accumulate/internal/testing"
+	"github.com/AccumulateNetwork/accumulate/internal/url"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+	"github.com/stretchr/testify/require"
+	tmed25519 "github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestIssueTokens_SupplyLimit(t *testing.T) {
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	key := tmed25519.GenPrivKey()
+	issuerUrl, err := url.Parse("foo/tokens")
+	require.NoError(t, err)
+
+	issuer := protocol.NewTokenIssuer()
+	issuer.ChainUrl = types.String(issuerUrl.String())
+	issuer.Symbol = "FOO"
+	issuer.SupplyLimit.SetInt64(100)
+	issuer.Issued.SetInt64(60)
+
+	dbTx := db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbTx, key, "foo"))
+	require.NoError(t, acctesting.WriteStates(dbTx, issuer))
+
+	signer := func(hash []byte) (transactions.Signature, error) {
+		sig := new(transactions.ED25519Sig)
+		return sig, sig.Sign(1, key, hash)
+	}
+
+	body := new(protocol.IssueTokens)
+	body.Recipient = "foo/account"
+	body.Amount.SetInt64(60)
+	tx, err := transactions.New(issuerUrl.String(), 1, signer, body)
+	require.NoError(t, err)
+
+	st, err := NewStateManager(dbTx, tx)
+	require.NoError(t, err)
+	require.Error(t, IssueTokens{}.Validate(st, tx))
+
+	body.Amount.SetInt64(40)
+	tx, err = transactions.New(issuerUrl.String(), 1, signer, body)
+	require.NoError(t, err)
+
+	st, err = NewStateManager(dbTx, tx)
+	require.NoError(t, err)
+	require.NoError(t, IssueTokens{}.Validate(st, tx))
+}
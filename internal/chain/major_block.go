@@ -0,0 +1,173 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/AccumulateNetwork/accumulate/config"
+	"github.com/AccumulateNetwork/accumulate/internal/anchoring"
+	"github.com/AccumulateNetwork/accumulate/internal/logging"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+)
+
+// externalAnchorTimeout is how long the leader waits for the external ledger.
+const externalAnchorTimeout = time.Minute
+
+// isMajorBlock checks if the current block is the first block of a new
+// interval of the major block schedule. isMajorBlock must be called before the
+// minor anchor chain is updated for the current block.
+func (m *Executor) isMajorBlock() (bool, error) {
+	schedule := m.Network.MajorBlockSchedule
+	if schedule <= 0 {
+		return false, nil
+	}
+
+	minor, err := m.DB.MinorAnchorChain()
+	if err != nil {
+		return false, err
+	}
+
+	// Compare against the last block that was anchored
+	head, err := minor.Record()
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return m.time.Truncate(schedule).After(head.Timestamp.Truncate(schedule)), nil
+}
+
+// addMajorBlock rolls up the minor anchor chain into the major anchor chain and
+// adds a major anchor.
+func (m *Executor) addMajorBlock() error {
+	minor, err := m.DB.MinorAnchorChain()
+	if err != nil {
+		return err
+	}
+
+	major, err := m.DB.MajorAnchorChain()
+	if err != nil {
+		return err
+	}
+
+	err = major.Rollup(m.height, m.time, minor)
+	if err != nil {
+		return err
+	}
+
+	synth, err := m.DB.SynthTxidChain()
+	if err != nil {
+		return err
+	}
+
+	body := new(protocol.SyntheticAnchor)
+	body.Source = m.Network.NodeUrl().String()
+	body.Major = true
+	body.Index = m.height
+	body.Timestamp = m.time
+	copy(body.Root[:], m.DB.RootHash())
+	copy(body.ChainAnchor[:], major.Chain.Anchor())
+	copy(body.SynthTxnAnchor[:], synth.Chain.Anchor())
+
+	m.logInfo("Creating major anchor txn", "height", m.height, "root", logging.AsHex(body.Root), "chains", logging.AsHex(body.ChainAnchor))
+
+	return m.sendAnchorTxn(body)
+}
+
+// anchorMajorBlock anchors the root of the major block to the external ledger.
+// Writing to the external ledger can take a while, so the leader does it in
+// the background and records the external transaction ID with a synthetic
+// transaction once it is done.
+func (m *Executor) anchorMajorBlock() error {
+	if m.Anchorer == nil || m.Network.Type != config.Directory || !m.leader {
+		return nil
+	}
+
+	major, err := m.DB.MajorAnchorChain()
+	if err != nil {
+		return err
+	}
+
+	head, err := major.Record()
+	if err != nil {
+		return err
+	}
+
+	// Finalization was skipped, so there is no new major block
+	if head.Index != m.height {
+		return nil
+	}
+
+	anchor := new(anchoring.Anchor)
+	anchor.Source = m.Network.NodeUrl().String()
+	anchor.Index = head.Index
+	anchor.Timestamp = head.Timestamp
+	anchor.Root = head.ChainAnchor
+
+	go func() {
+		err := m.anchorExternally(anchor)
+		if err != nil {
+			m.logError("Failed to anchor major block", "height", anchor.Index, "ledger", m.Anchorer.Ledger(), "error", err)
+		}
+	}()
+	return nil
+}
+
+// anchorExternally writes the anchor to the external ledger and sends a
+// synthetic transaction that records the external transaction ID.
+func (m *Executor) anchorExternally(anchor *anchoring.Anchor) error {
+	ctx, cancel := context.WithTimeout(context.Background(), externalAnchorTimeout)
+	defer cancel()
+
+	txid, err := m.Anchorer.Anchor(ctx, anchor)
+	if err != nil {
+		return err
+	}
+
+	m.logInfo("Anchored major block", "height", anchor.Index, "ledger", m.Anchorer.Ledger(), "txid", logging.AsHex(txid))
+
+	body := new(protocol.SyntheticExternalAnchor)
+	body.Ledger = m.Anchorer.Ledger()
+	body.Index = anchor.Index
+	body.Root = anchor.Root
+	body.TxID = txid
+
+	data, err := body.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal external anchor: %v", err)
+	}
+
+	// The transaction is built outside of consensus, so it is not on the
+	// synthetic transaction chain and its nonce is only used to make it unique
+	tx := new(transactions.GenTransaction)
+	tx.SigInfo = new(transactions.SignatureInfo)
+	tx.SigInfo.URL = m.Network.NodeUrl().JoinPath(protocol.ExternalAnchorPool).String()
+	tx.SigInfo.KeyPageHeight = 1
+	tx.SigInfo.Nonce = uint64(time.Now().UnixNano())
+	tx.Transaction = data
+
+	ed := new(transactions.ED25519Sig)
+	tx.Signature = append(tx.Signature, ed)
+	ed.PublicKey = m.Key[32:]
+	err = ed.Sign(tx.SigInfo.Nonce, m.Key, tx.TransactionHash())
+	if err != nil {
+		return fmt.Errorf("failed to sign external anchor: %v", err)
+	}
+
+	data, err = tx.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to marshal external anchor txn: %v", err)
+	}
+
+	_, err = m.Local.BroadcastTxAsync(ctx, data)
+	if err != nil {
+		return fmt.Errorf("failed to broadcast external anchor txn: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+)
+
+// pendingExpiredKey is the key of the height of the last block whose pending
+// transactions have been expired, within the pending transaction index.
+// pendingPrunedKey is the key of the height of the last block whose pending
+// transactions have been pruned, within the prune index. Expiration is part of
+// consensus while pruning is local to the node.
+const (
+	pendingExpiredKey = "Expired"
+	pendingPrunedKey  = "Pending"
+)
+
+// addTransaction stores the state of a transaction and records it so that its
+// signatures can be pruned once the retention window has passed.
+func (m *Executor) addTransaction(chainId *types.Bytes32, txid []byte, txPending, txAccepted *state.Object) error {
+	err := m.dbTx.AddTransaction(chainId, txid, txPending, txAccepted)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.pending = append(m.pending, types.Bytes(txid).AsBytes32())
+	m.mu.Unlock()
+	return nil
+}
+
+// indexPendingTransactions records the transactions of the block in the
+// pending transaction index under the height of the block. The height is also
+// recorded for each transaction, so that a transaction that is recorded again
+// in a later block is not pruned early.
+func (m *Executor) indexPendingTransactions() error {
+	if len(m.pending) == 0 {
+		return nil
+	}
+
+	set := new(protocol.PendingTransactionSet)
+	for _, txid := range m.pending {
+		set.Add(txid)
+	}
+
+	data, err := set.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending transaction index: %v", err)
+	}
+
+	height := uint64(m.height)
+	m.dbTx.WriteIndex(state.PendingTxIndex, nil, height, data)
+
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], height)
+	for _, txid := range set.Transactions {
+		m.dbTx.WriteIndex(state.PendingTxIndex, nil, txid, b[:])
+	}
+	return nil
+}
+
+// expirePendingTransactions expires the transactions that have waited for
+// signatures longer than the expiration window. Expiration affects how later
+// submissions are executed, so its window is set at genesis.
+func (m *Executor) expirePendingTransactions() error {
+	expiration, err := m.DB.PendingExpiration()
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		// The subnet was created before expiration was a genesis parameter
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to load the pending expiration window: %v", err)
+	}
+
+	end, ok := m.pendingWindowEnd(expiration)
+	if !ok {
+		return nil
+	}
+
+	// Transactions that are recorded in this block are not loaded from the
+	// database, and they are not waiting anyway
+	recorded := make(map[[32]byte]bool, len(m.pending))
+	for _, txid := range m.pending {
+		recorded[txid] = true
+	}
+
+	return m.walkPendingTransactions(state.PendingTxIndex, pendingExpiredKey, end, func(txid [32]byte) error {
+		if recorded[txid] {
+			return nil
+		}
+		return m.expirePendingTransaction(txid)
+	})
+}
+
+// prunePendingTransactions removes the signatures of the transactions that
+// were recorded before the retention window. Pruning only affects what the
+// node stores, so its window is configured per node.
+func (m *Executor) prunePendingTransactions() error {
+	end, ok := m.pendingWindowEnd(m.Storage.PendingRetention)
+	if !ok {
+		return nil
+	}
+
+	return m.walkPendingTransactions(state.PruneIndex, pendingPrunedKey, end, m.prunePendingTransaction)
+}
+
+// pendingWindowEnd returns the height of the last block that has left the
+// given window. It returns false if the window is disabled or no block has
+// left it.
+func (m *Executor) pendingWindowEnd(window uint64) (uint64, bool) {
+	if window == 0 || uint64(m.height) <= window {
+		return 0, false
+	}
+	return uint64(m.height) - window, true
+}
+
+// loadPendingHeight loads the height of the last block that has been walked,
+// which is recorded under the given key of the given index.
+func (m *Executor) loadPendingHeight(index state.Index, key string) (uint64, error) {
+	data, err := m.dbTx.GetIndex(index, nil, key)
+	switch {
+	case err == nil:
+		return binary.BigEndian.Uint64(data), nil
+	case errors.Is(err, storage.ErrNotFound):
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("failed to load the pending %s height: %v", strings.ToLower(key), err)
+	}
+}
+
+// walkPendingTransactions calls fn for each transaction of the blocks after the
+// last block that has been walked, up to the given height. A transaction is
+// skipped if it has been recorded again since. The height of the last block
+// that has been walked is recorded under the given key of the given index.
+func (m *Executor) walkPendingTransactions(index state.Index, key string, end uint64, fn func(txid [32]byte) error) error {
+	start, err := m.loadPendingHeight(index, key)
+	if err != nil {
+		return err
+	}
+	if end > start+maxPrunedHeights {
+		end = start + maxPrunedHeights
+	}
+
+	for height := start + 1; height <= end; height++ {
+		data, err := m.dbTx.GetIndex(state.PendingTxIndex, nil, height)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			continue
+		case err != nil:
+			return fmt.Errorf("failed to load pending transaction index: %v", err)
+		}
+
+		set := new(protocol.PendingTransactionSet)
+		err = set.UnmarshalBinary(data)
+		if err != nil {
+			return fmt.Errorf("invalid pending transaction index: %v", err)
+		}
+
+		for _, txid := range set.Transactions {
+			ok, err := m.isLastRecorded(txid, height)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+
+			err = fn(txid)
+			if err != nil {
+				return err
+			}
+		}
+		m.logDebug("Walked pending transactions", "walk", key, "height", height, "count", len(set.Transactions))
+	}
+
+	if end > start {
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], end)
+		m.dbTx.WriteIndex(index, nil, key, b[:])
+	}
+	return nil
+}
+
+// isLastRecorded returns true if the transaction was last recorded at the
+// given height.
+func (m *Executor) isLastRecorded(txid [32]byte, height uint64) (bool, error) {
+	data, err := m.dbTx.GetIndex(state.PendingTxIndex, nil, txid)
+	switch {
+	case errors.Is(err, storage.ErrNotFound), err == nil && len(data) != 8:
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to load pending transaction index: %v", err)
+	}
+	return binary.BigEndian.Uint64(data) == height, nil
+}
+
+// prunePendingTransaction removes the signatures of a transaction. The
+// signatures of a transaction that is still waiting for signatures are kept,
+// since a later submission builds on them. If the transaction expires, it is
+// recorded again and pruned once the retention window has passed since.
+func (m *Executor) prunePendingTransaction(txid [32]byte) error {
+	pending, err := m.loadPendingTransaction(txid[:])
+	if err != nil {
+		return err
+	}
+	if pending == nil || isPruned(pending) || isPending(pending) {
+		return nil
+	}
+
+	return m.prunePendingSignatures(txid, pending)
+}
+
+// prunePendingSignatures removes the signatures of a transaction and marks its
+// status as pruned. The hash and status of the transaction are kept.
+func (m *Executor) prunePendingSignatures(txid [32]byte, pending *state.PendingTransaction) error {
+	var err error
+	pending.Signature = nil
+	pending.Status, err = prunedStatus(pending.Status)
+	if err != nil {
+		return fmt.Errorf("invalid status of pending transaction %X: %v", txid, err)
+	}
+
+	obj := new(state.Object)
+	obj.Entry, err = pending.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending transaction %X: %v", txid, err)
+	}
+
+	err = m.dbTx.PrunePendingTx(txid[:], obj)
+	if err != nil {
+		return fmt.Errorf("failed to prune pending transaction %X: %v", txid, err)
+	}
+	return nil
+}
+
+// expirePendingTransaction removes a transaction that is still waiting for
+// signatures from the pending transactions of its key page and records it
+// again with an expired status.
+func (m *Executor) expirePendingTransaction(txid [32]byte) error {
+	pending, err := m.loadPendingTransaction(txid[:])
+	if err != nil {
+		return err
+	}
+	if pending == nil || !isPending(pending) {
+		return nil
+	}
+
+	var status pendingStatus
+	err = json.Unmarshal(pending.Status, &status)
+	if err != nil {
+		return fmt.Errorf("invalid status of pending transaction %X: %v", txid, err)
+	}
+
+	page, err := hex.DecodeString(status.KeyPage)
+	if err != nil || len(page) != 32 {
+		return fmt.Errorf("invalid key page of pending transaction %X", txid)
+	}
+	pageId := types.Bytes(page).AsBytes32()
+
+	err = m.updatePendingIndex(pageId, txid, false)
+	if err != nil {
+		return err
+	}
+
+	pending.Status = json.RawMessage(fmt.Sprintf("{\"code\":\"1\", \"error\":\"the transaction expired before it was signed by enough keys\", \"expired\":true}"))
+	obj := new(state.Object)
+	obj.Entry, err = pending.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending transaction %X: %v", txid, err)
+	}
+
+	err = m.addTransaction(&pageId, txid[:], obj, nil)
+	if err != nil {
+		return fmt.Errorf("failed to expire pending transaction %X: %v", txid, err)
+	}
+	return nil
+}
+
+// isPruned returns true if the signatures of the transaction have been pruned.
+func isPruned(tx *state.PendingTransaction) bool {
+	var status pendingStatus
+	return json.Unmarshal(tx.Status, &status) == nil && status.Pruned
+}
+
+// prunedStatus marks a transaction's status as pruned, so that queries report
+// that its signatures were pruned.
+func prunedStatus(status json.RawMessage) (json.RawMessage, error) {
+	fields := map[string]interface{}{}
+	if len(status) > 0 {
+		// Keep numbers as they are, so the status digests the same once pruned
+		dec := json.NewDecoder(bytes.NewReader(status))
+		dec.UseNumber()
+		err := dec.Decode(&fields)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields["pruned"] = true
+	return json.Marshal(fields)
+}
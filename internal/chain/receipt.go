@@ -0,0 +1,326 @@
+package chain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/config"
+	"github.com/AccumulateNetwork/accumulate/internal/url"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/smt/common"
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+)
+
+// The receipt index records:
+//
+//   - The height of the synthetic transaction chain of this subnet for each of
+//     its roots, keyed by the root.
+//   - The roots of the synthetic transaction chains of other subnets that have
+//     been anchored by the DN, keyed by the subnet and the root.
+//   - The latest root of this subnet that has been anchored by the DN, keyed by
+//     receiptAnchoredKey.
+//   - On the DN, the anchors of BVNs received in a block, keyed by the height
+//     of the block. These are relayed to the BVNs in the DN's next anchor.
+const receiptAnchoredKey = "Anchored"
+
+// indexSynthRoot records the height of the synthetic transaction chain for its
+// current root, if synthetic transactions were produced by the previous block.
+func (m *Executor) indexSynthRoot() error {
+	chain, err := m.DB.SynthTxidChain()
+	if err != nil {
+		return err
+	}
+
+	head, err := chain.Record()
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil
+	case err != nil:
+		return err
+	case head.Index != m.height-1:
+		return nil
+	}
+
+	m.dbTx.WriteIndex(state.ReceiptIndex, nil, chain.Chain.Anchor(), common.Uint64Bytes(uint64(chain.Height())))
+	return nil
+}
+
+// synthReceipt builds a receipt for a synthetic transaction produced by this
+// subnet. The receipt proves the transaction against the latest root of the
+// synthetic transaction chain that has been anchored by the DN. If that root
+// does not include the transaction yet, synthReceipt returns nil.
+func (m *Executor) synthReceipt(txid []byte) (*transactions.ReceiptSig, error) {
+	chain, err := m.DB.SynthTxidChain()
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := m.DB.GetIndex(state.ReceiptIndex, nil, receiptAnchoredKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load the anchored synthetic root: %v", err)
+	}
+
+	data, err := m.DB.GetIndex(state.ReceiptIndex, nil, root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the height of synthetic root %X: %v", root, err)
+	}
+	height, _ := common.BytesUint64(data)
+
+	index, err := chain.Chain.HeightOf(txid)
+	if err != nil {
+		return nil, fmt.Errorf("synthetic transaction %X is not on the synthetic transaction chain: %v", txid, err)
+	}
+	if uint64(index) >= height {
+		return nil, nil
+	}
+
+	anchor, err := chain.Chain.Entry(int64(height) - 1)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := chain.Chain.Receipt(txid, anchor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build a receipt for %X: %v", txid, err)
+	}
+
+	sig := new(transactions.ReceiptSig)
+	sig.Source = m.Network.NodeUrl().String()
+	sig.Receipt = *receipt
+	return sig, nil
+}
+
+// recordSubnetAnchor records the roots of the synthetic transaction chain and
+// of the minor anchor chain of a subnet as anchored by the DN.
+func recordSubnetAnchor(st *StateManager, nodeUrl *url.URL, anchor *protocol.SubnetAnchor) error {
+	source, err := url.Parse(anchor.Source)
+	if err != nil {
+		return fmt.Errorf("invalid source: %v", err)
+	}
+
+	if anchor.ChainAnchor != [32]byte{} {
+		recordAnchoredChainRoot(st, source, anchor.ChainAnchor[:])
+	}
+
+	if anchor.SynthTxnAnchor == [32]byte{} {
+		return nil
+	}
+
+	st.WriteIndex(state.ReceiptIndex, source.ResourceChain(), anchor.SynthTxnAnchor[:], []byte{1})
+	if source.Equal(nodeUrl) {
+		st.WriteIndex(state.ReceiptIndex, nil, receiptAnchoredKey, anchor.SynthTxnAnchor[:])
+	}
+	return nil
+}
+
+// recordAnchorSent records the root of the DN's synthetic transaction chain as
+// anchored once the DN has sent an anchor that includes it. The roots of BVNs
+// are anchored once they are relayed back by the DN.
+func (m *Executor) recordAnchorSent(tx *transactions.GenTransaction) error {
+	if m.Network.Type != config.Directory {
+		return nil
+	}
+
+	body := new(protocol.SyntheticAnchor)
+	err := tx.As(body)
+	if err != nil {
+		return fmt.Errorf("invalid anchor: %v", err)
+	}
+
+	if body.SynthTxnAnchor != [32]byte{} {
+		m.dbTx.WriteIndex(state.ReceiptIndex, nil, receiptAnchoredKey, body.SynthTxnAnchor[:])
+	}
+	return nil
+}
+
+// relaySubnetAnchor queues the anchor of a BVN to be relayed to the BVNs in the
+// DN's next anchor.
+func relaySubnetAnchor(st *StateManager, anchor *protocol.SubnetAnchor) error {
+	set := new(protocol.SubnetAnchorSet)
+	data, err := st.GetIndex(state.ReceiptIndex, nil, st.BlockHeight)
+	switch {
+	case err == nil:
+		err = set.UnmarshalBinary(data)
+		if err != nil {
+			return fmt.Errorf("invalid subnet anchor index: %v", err)
+		}
+	case !errors.Is(err, storage.ErrNotFound):
+		return fmt.Errorf("failed to load subnet anchor index: %v", err)
+	}
+
+	set.Anchors = append(set.Anchors, *anchor)
+	data, err = set.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal subnet anchor index: %v", err)
+	}
+
+	st.WriteIndex(state.ReceiptIndex, nil, st.BlockHeight, data)
+	return nil
+}
+
+// relayedSubnetAnchors returns the anchors of BVNs received by the DN in the
+// current block.
+func (m *Executor) relayedSubnetAnchors() ([]protocol.SubnetAnchor, error) {
+	data, err := m.dbTx.GetIndex(state.ReceiptIndex, nil, uint64(m.height))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load subnet anchor index: %v", err)
+	}
+
+	set := new(protocol.SubnetAnchorSet)
+	err = set.UnmarshalBinary(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subnet anchor index: %v", err)
+	}
+	return set.Anchors, nil
+}
+
+// checkSynthetic verifies that a synthetic transaction was produced by a subnet
+// of the network. Synthetic transactions must be signed by a validator. A
+// transaction from another subnet must carry a receipt that ties it to a root
+// of the source's synthetic transaction chain that has been anchored by the DN.
+// A transaction without a receipt must be signed by a validator of this subnet
+// and must have been produced by it.
+func (m *Executor) checkSynthetic(st *StateManager, tx *transactions.GenTransaction) error {
+	var signer []byte
+	var receipt *transactions.ReceiptSig
+	for _, sig := range tx.Signature {
+		switch sig := sig.(type) {
+		case *transactions.ReceiptSig:
+			if receipt != nil {
+				return fmt.Errorf("synthetic transaction has more than one receipt")
+			}
+			receipt = sig
+		case *transactions.ED25519Sig:
+			if signer == nil {
+				signer = sig.PublicKey
+			}
+		}
+	}
+	if signer == nil {
+		return fmt.Errorf("synthetic transaction is not signed by a validator")
+	}
+
+	// Anchors and mirrors are not on the synthetic transaction chain, so they
+	// cannot have a receipt
+	switch tx.TransactionType() {
+	case types.TxTypeSyntheticAnchor:
+		body := new(protocol.SyntheticAnchor)
+		err := tx.As(body)
+		if err != nil {
+			return fmt.Errorf("invalid payload: %v", err)
+		}
+		return checkSystemSigner(st, body.Source, signer)
+
+	case types.TxTypeSyntheticMirror:
+		body := new(protocol.SyntheticMirror)
+		err := tx.As(body)
+		if err != nil {
+			return fmt.Errorf("invalid payload: %v", err)
+		}
+		return checkSystemSigner(st, body.Source, signer)
+	}
+
+	// The receipt was verified along with the signatures, so its path leads to
+	// its root. The transaction must also be signed by a validator of the
+	// receipt's source.
+	if receipt != nil {
+		err := checkSystemSigner(st, receipt.Source, signer)
+		if err != nil {
+			return err
+		}
+		return m.checkReceiptRoot(st, receipt)
+	}
+
+	nodeUrl := m.Network.NodeUrl()
+	ok, err := isValidator(st, nodeUrl, signer)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("synthetic transaction is not signed by a validator of %v", nodeUrl)
+	}
+
+	// Signatures of synthetic transactions and external anchors are not staged
+	switch tx.TransactionType() {
+	case types.TxTypeSyntheticSignTransactions, types.TxTypeSyntheticExternalAnchor:
+		return nil
+	}
+
+	_, err = m.DB.GetSynthTxn(types.Bytes(tx.TransactionHash()).AsBytes32())
+	if err != nil {
+		return fmt.Errorf("synthetic transaction %X was not produced by %v: %v", tx.TransactionHash(), nodeUrl, err)
+	}
+	return nil
+}
+
+// checkReceiptRoot verifies that the root of a receipt has been anchored by the
+// DN.
+func (m *Executor) checkReceiptRoot(st *StateManager, receipt *transactions.ReceiptSig) error {
+	source, err := url.Parse(receipt.Source)
+	if err != nil {
+		return fmt.Errorf("invalid receipt source: %v", err)
+	}
+
+	_, err = st.GetIndex(state.ReceiptIndex, source.ResourceChain(), []byte(receipt.Receipt.MDRoot))
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, storage.ErrNotFound):
+		return fmt.Errorf("receipt root %X of %v has not been anchored by the directory", receipt.Receipt.MDRoot, source)
+	default:
+		return fmt.Errorf("failed to load receipt root: %v", err)
+	}
+}
+
+// checkSystemSigner verifies that a synthetic transaction from another subnet
+// is signed by a validator of its source.
+func checkSystemSigner(st *StateManager, sourceUrl string, signer []byte) error {
+	source, err := url.Parse(sourceUrl)
+	if err != nil {
+		return fmt.Errorf("invalid source: %v", err)
+	}
+
+	ok, err := isValidator(st, source, signer)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("synthetic transaction is not signed by a validator of %v", source)
+	}
+	return nil
+}
+
+// isValidator checks whether the key is on one of the pages of the validator
+// key book of the subnet. The validator books of the other subnets are
+// recorded at genesis. isValidator fails if the validators of the subnet are
+// unknown.
+func isValidator(st *StateManager, subnet *url.URL, key []byte) (bool, error) {
+	book := new(protocol.KeyBook)
+	err := st.LoadUrlAs(subnet.JoinPath("validators"), book)
+	switch {
+	case err == nil:
+	case errors.Is(err, storage.ErrNotFound):
+		return false, fmt.Errorf("the validators of %v are unknown", subnet)
+	default:
+		return false, fmt.Errorf("failed to load the validators of %v: %v", subnet, err)
+	}
+
+	for _, id := range book.Pages {
+		page := new(protocol.KeyPage)
+		err = st.LoadAs(id, page)
+		if err != nil {
+			return false, fmt.Errorf("failed to load the validators of %v: %v", subnet, err)
+		}
+		if page.FindKey(key) != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,101 @@
+package chain_test
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/AccumulateNetwork/accumulate/config"
+	. "github.com/AccumulateNetwork/accumulate/internal/chain"
+	acctesting "github.com/AccumulateNetwork/accumulate/internal/testing"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/smt/managed"
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
+	"github.com/AccumulateNetwork/accumulate/smt/storage/database"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+	"github.com/stretchr/testify/require"
+	tmed25519 "github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestSyntheticReceipt(t *testing.T) {
+	validator := generateKey()
+
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	exec, err := NewNodeExecutor(ExecutorOptions{
+		DB:  db,
+		Key: validator.Bytes(),
+		Network: config.Network{
+			Type:     config.BlockValidator,
+			ID:       "BVN0",
+			BvnNames: []string{"BVN0", "BVN1"},
+			Addresses: map[string][]string{
+				"directory": {"http://127.0.0.1:26656"},
+				"bvn1":      {"http://127.0.0.1:26656"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	// Record the validators of the source, as genesis does
+	bvn1 := protocol.BvnUrl("BVN1")
+	dbtx := db.Begin()
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, types.String(bvn1.JoinPath("validators0").String()), validator.PubKey().(tmed25519.PubKey)))
+	require.NoError(t, acctesting.CreateKeyBook(dbtx, types.String(bvn1.JoinPath("validators").String()), bvn1.JoinPath("validators0").String()))
+	_, err = dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	// Build a deposit from the source with a receipt against a root of a chain
+	// that has not been anchored
+	deposit := func(source string, key tmed25519.PrivKey) (*transactions.GenTransaction, []byte) {
+		tx, err := acctesting.CreateFakeSyntheticDepositTx(generateKey())
+		require.NoError(t, err)
+
+		sig, err := edSigner(key, tx.SigInfo.Nonce)(tx.TransactionHash())
+		require.NoError(t, err)
+		receipt := buildReceipt(t, tx.TransactionHash())
+		tx.Signature = []transactions.Signature{sig, &transactions.ReceiptSig{Source: source, Receipt: *receipt}}
+		return tx, receipt.MDRoot
+	}
+
+	// The validators of the source must be known
+	tx, _ := deposit(protocol.BvnUrl("BVN2").String(), validator)
+	require.EqualError(t, exec.CheckTx(tx), "the validators of acc://bvn-BVN2 are unknown")
+
+	// The transaction must be signed by a validator of the source
+	tx, _ = deposit(protocol.BvnUrl("BVN1").String(), generateKey())
+	require.EqualError(t, exec.CheckTx(tx), "synthetic transaction is not signed by a validator of acc://bvn-BVN1")
+
+	// The root of the receipt must have been anchored by the DN
+	tx, root := deposit(protocol.BvnUrl("BVN1").String(), validator)
+	require.Contains(t, exec.CheckTx(tx).Error(), "has not been anchored by the directory")
+
+	// Receive the anchor
+	dbtx = db.Begin()
+	dbtx.WriteIndex(state.ReceiptIndex, bvn1.ResourceChain(), root, []byte{1})
+	_, err = dbtx.Commit(2, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	require.Nil(t, exec.CheckTx(tx))
+}
+
+// buildReceipt builds a receipt for the hash from a chain that has not been
+// anchored.
+func buildReceipt(t *testing.T, hash []byte) *managed.Receipt {
+	db, err := database.NewDBManager("memory", "", nil)
+	require.NoError(t, err)
+	manager, err := managed.NewMerkleManager(db, 2)
+	require.NoError(t, err)
+	require.NoError(t, manager.SetKey(storage.MakeKey("receipt")))
+
+	last := sha256.Sum256(hash)
+	manager.AddHash(hash)
+	manager.AddHash(last[:])
+
+	receipt, err := managed.GetReceipt(manager, hash, last[:])
+	require.NoError(t, err)
+	return receipt
+}
@@ -0,0 +1,156 @@
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/AccumulateNetwork/accumulate/internal/logging"
+	"github.com/AccumulateNetwork/accumulate/internal/url"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+)
+
+// isRefundable returns true if the tokens of a failed transaction of the given
+// type are refunded.
+func isRefundable(typ types.TransactionType) bool {
+	switch typ {
+	case types.TxTypeSyntheticDepositTokens, types.TxTypeSyntheticBurnTokens:
+		return true
+	default:
+		return false
+	}
+}
+
+// refundFailedTokens returns the tokens of a synthetic deposit or burn that
+// failed to the account that sent them. The source of an issued deposit is the
+// issuer, which un-issues the tokens when the refund arrives. Transactions
+// without a source, such as deposits created by the faucet, and refunds
+// themselves are not refunded.
+func (m *Executor) refundFailedTokens(tx *transactions.GenTransaction) error {
+	var cause [32]byte
+	var token, from string
+	var amount *big.Int
+	switch tx.TransactionType() {
+	case types.TxTypeSyntheticDepositTokens:
+		body := new(protocol.SyntheticDepositTokens)
+		err := tx.As(body)
+		if err != nil {
+			return fmt.Errorf("invalid payload: %v", err)
+		}
+		if body.IsRefund {
+			return nil
+		}
+		cause, token, from, amount = body.Cause, body.Token, body.Source, &body.Amount
+
+	case types.TxTypeSyntheticBurnTokens:
+		// The origin of a burn is the token issuer
+		body := new(protocol.SyntheticBurnTokens)
+		err := tx.As(body)
+		if err != nil {
+			return fmt.Errorf("invalid payload: %v", err)
+		}
+		issuer, err := url.Parse(tx.SigInfo.URL)
+		if err != nil {
+			return fmt.Errorf("invalid origin: %v", err)
+		}
+		cause, token, from, amount = body.Cause, issuer.String(), body.Source, &body.Amount
+
+	default:
+		return nil
+	}
+	if from == "" {
+		return nil
+	}
+
+	source, err := url.Parse(from)
+	if err != nil {
+		return fmt.Errorf("invalid source: %v", err)
+	}
+
+	refund := new(protocol.SyntheticDepositTokens)
+	refund.Cause = cause
+	refund.Token = token
+	refund.Amount.Set(amount)
+	refund.IsRefund = true
+
+	synth, err := m.buildSynthTxn(source, refund)
+	if err != nil {
+		return err
+	}
+
+	obj := new(state.Object)
+	obj.Entry, err = state.NewPendingTransaction(synth).MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	// The failed transaction is not recorded as a transaction, so the refund
+	// must be staged directly
+	err = m.dbTx.WriteSynthTxn(synth.TransactionHash(), obj)
+	if err != nil {
+		return err
+	}
+	m.dbTx.AddSynthTx(tx.TransactionHash(), synth.TransactionHash(), obj)
+
+	m.logInfo("Refunding failed tokens", "txid", logging.AsHex(tx.TransactionHash()), "type", tx.TransactionType(), "cause", logging.AsHex(cause[:]), "source", source)
+	return nil
+}
+
+// bouncedStatus is the status of a transaction whose deposit failed and was
+// refunded.
+type bouncedStatus struct {
+	Code    string `json:"code"`
+	Bounced bool   `json:"bounced"`
+	Refund  string `json:"refund"`
+}
+
+// recordBounce marks the transaction that caused a refund as bounced.
+func (m *Executor) recordBounce(tx *transactions.GenTransaction) error {
+	if tx.TransactionType() != types.TxTypeSyntheticDepositTokens {
+		return nil
+	}
+
+	body := new(protocol.SyntheticDepositTokens)
+	err := tx.As(body)
+	if err != nil {
+		return fmt.Errorf("invalid payload: %v", err)
+	}
+	if !body.IsRefund {
+		return nil
+	}
+
+	pending, err := m.loadPendingTransaction(body.Cause[:])
+	if err != nil {
+		return err
+	}
+	if pending == nil {
+		// The pending state of the cause may have been purged
+		return nil
+	}
+
+	pending.Status, err = json.Marshal(bouncedStatus{
+		Code:    "0",
+		Bounced: true,
+		Refund:  fmt.Sprintf("%X", tx.TransactionHash()),
+	})
+	if err != nil {
+		return err
+	}
+
+	origin, err := url.Parse(pending.TransactionState.SigInfo.URL)
+	if err != nil {
+		return fmt.Errorf("invalid origin of %X: %v", body.Cause, err)
+	}
+
+	obj := new(state.Object)
+	obj.Entry, err = pending.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	chainId := types.Bytes(origin.ResourceChain()).AsBytes32()
+	return m.addTransaction(&chainId, body.Cause[:], obj, nil)
+}
@@ -0,0 +1,98 @@
+package chain
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+)
+
+// scratchPrunedKey is the key of the height of the last block whose scratch
+// entries have been pruned, within the prune index.
+const scratchPrunedKey = "Scratch"
+
+// maxPrunedHeights is the largest number of heights whose entries are pruned in
+// a single block. When pruning is enabled on a chain that has been running for
+// a while, the backlog is worked through over the following blocks instead of
+// in one.
+const maxPrunedHeights = 1000
+
+// addScratchEntry records a scratch data entry in the scratch index under the
+// height of the block it was written in, so that its body can be pruned once
+// the retention window has passed.
+func addScratchEntry(st *StateManager, chainId, entryHash [32]byte) error {
+	set := new(protocol.ScratchEntrySet)
+	data, err := st.GetIndex(state.ScratchIndex, nil, st.BlockHeight)
+	switch {
+	case err == nil:
+		err = set.UnmarshalBinary(data)
+		if err != nil {
+			return fmt.Errorf("invalid scratch entry index: %v", err)
+		}
+	case !errors.Is(err, storage.ErrNotFound):
+		return fmt.Errorf("failed to load scratch entry index: %v", err)
+	}
+
+	set.Entries = append(set.Entries, protocol.ScratchEntry{Chain: chainId, EntryHash: entryHash})
+	data, err = set.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal scratch entry index: %v", err)
+	}
+
+	st.WriteIndex(state.ScratchIndex, nil, st.BlockHeight, data)
+	return nil
+}
+
+// pruneScratchEntries removes the bodies of the scratch data entries that were
+// written before the retention window. Only the bodies are removed, the entry
+// hashes remain on the data chains. At most maxPrunedHeights heights are pruned
+// per block. Pruning is local to the node and does not affect consensus, so the
+// scratch index itself is kept.
+func (m *Executor) pruneScratchEntries() error {
+	retention := m.Storage.ScratchRetention
+	if retention == 0 || uint64(m.height) <= retention {
+		return nil
+	}
+	end := uint64(m.height) - retention
+
+	var start uint64
+	data, err := m.dbTx.GetIndex(state.PruneIndex, nil, scratchPrunedKey)
+	switch {
+	case err == nil:
+		start = binary.BigEndian.Uint64(data)
+	case !errors.Is(err, storage.ErrNotFound):
+		return fmt.Errorf("failed to load the scratch pruning height: %v", err)
+	}
+	if end > start+maxPrunedHeights {
+		end = start + maxPrunedHeights
+	}
+
+	for height := start + 1; height <= end; height++ {
+		data, err := m.dbTx.GetIndex(state.ScratchIndex, nil, height)
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			continue
+		case err != nil:
+			return fmt.Errorf("failed to load scratch entry index: %v", err)
+		}
+
+		set := new(protocol.ScratchEntrySet)
+		err = set.UnmarshalBinary(data)
+		if err != nil {
+			return fmt.Errorf("invalid scratch entry index: %v", err)
+		}
+
+		for _, entry := range set.Entries {
+			m.dbTx.PruneDataEntry(entry.Chain[:], entry.EntryHash[:])
+		}
+		m.logDebug("Pruned scratch entries", "height", height, "count", len(set.Entries))
+	}
+
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], end)
+	m.dbTx.WriteIndex(state.PruneIndex, nil, scratchPrunedKey, b[:])
+	return nil
+}
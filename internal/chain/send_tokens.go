@@ -63,6 +63,7 @@ func (SendTokens) Validate(st *StateManager, tx *transactions.GenTransaction) er
 		copy(deposit.Cause[:], tx.TransactionHash())
 		deposit.Token = tokenUrl.String()
 		deposit.Amount = *new(big.Int).SetUint64(body.To[i].Amount)
+		deposit.Source = st.OriginUrl.String()
 		st.Submit(u, deposit)
 	}
 
@@ -7,6 +7,8 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/AccumulateNetwork/accumulate/config"
+	"github.com/AccumulateNetwork/accumulate/internal/abci"
 	"github.com/AccumulateNetwork/accumulate/internal/url"
 	"github.com/AccumulateNetwork/accumulate/protocol"
 	"github.com/AccumulateNetwork/accumulate/smt/storage"
@@ -23,15 +25,34 @@ type StateManager struct {
 	chains      map[[32]byte]state.Chain
 	writes      map[storage.Key][]byte
 	submissions []*submittedTx
+	validators  []abci.ValidatorUpdate
 	storeCount  int
 	txHash      types.Bytes32
 	txType      types.TransactionType
 	synthSigs   []*state.SyntheticSignature
 	logger      log.Logger
 
+	// keyPage is the chain ID of the key page that signed the transaction
+	keyPage [32]byte
+
+	// pending is set if the transaction has not been signed by enough keys to
+	// meet the threshold of the key page
+	pending bool
+
+	// paid is the amount the submissions of a pending transaction have paid,
+	// including this one
+	paid uint64
+
 	Origin        state.Chain
 	OriginUrl     *url.URL
 	OriginChainId [32]byte
+
+	// BlockHeight is the height of the block the transaction is executed in
+	BlockHeight uint64
+
+	// Network is the configuration of the subnet the transaction is executed
+	// on. If Network is nil, every record is assumed to be local.
+	Network *config.Network
 }
 
 type storeKind int
@@ -40,6 +61,7 @@ const (
 	createRecord storeKind = iota + 1
 	updateRecord
 	updateNonce
+	updateCreditBalance
 	addDataEntry
 )
 
@@ -89,6 +111,27 @@ func NewStateManager(dbTx *state.DBTransaction, tx *transactions.GenTransaction)
 	return nil, err
 }
 
+// isLocal returns true if the URL is routed to the local subnet.
+func (m *StateManager) isLocal(u *url.URL) bool {
+	if m.Network == nil {
+		return true
+	}
+
+	if protocol.IsDnUrl(u) {
+		return m.Network.Type == config.Directory
+	}
+
+	if bvn, ok := protocol.ParseBvnUrl(u); ok {
+		return strings.EqualFold(bvn, m.Network.ID)
+	}
+
+	bvns := m.Network.BvnNames
+	if len(bvns) == 0 {
+		return true
+	}
+	return strings.EqualFold(bvns[u.Routing()%uint64(len(bvns))], m.Network.ID)
+}
+
 type submittedTx struct {
 	url  *url.URL
 	body protocol.TransactionPayload
@@ -228,9 +271,10 @@ func (m *StateManager) UpdateNonce(record state.Chain) {
 	m.store(record, updateNonce)
 }
 
-//UpdateCreditBalance update the credits used for a transaction
+//UpdateCreditBalance update the credits used for a transaction. The nonce of
+//the record may also be updated.
 func (m *StateManager) UpdateCreditBalance(record state.Chain) {
-	panic("todo: UpdateCredtedBalance needs to be implemented")
+	m.store(record, updateCreditBalance)
 }
 
 //UpdateData will cache a data associated with a DataAccount chain.
@@ -265,6 +309,12 @@ func (m *StateManager) Submit(url *url.URL, body protocol.TransactionPayload) {
 	m.submissions = append(m.submissions, &submittedTx{url, body})
 }
 
+// UpdateValidator queues a change to the validator set of the subnet. A power
+// of zero removes the validator.
+func (m *StateManager) UpdateValidator(pubKey []byte, power int64) {
+	m.validators = append(m.validators, abci.ValidatorUpdate{PubKey: pubKey, Power: power})
+}
+
 // commit writes pending records to the database.
 func (m *StateManager) Commit() error {
 	for k, v := range m.writes {
@@ -286,6 +336,14 @@ func (m *StateManager) Commit() error {
 			return fmt.Errorf("failed to marshal record: %v", err)
 		}
 
+		// Mirrored records can only be updated by their source
+		if store.kind != createRecord && m.txType != types.TxTypeSyntheticMirror {
+			err = m.checkNotMirrored(store.chainId[:])
+			if err != nil {
+				return fmt.Errorf("cannot update %q: %v", store.record.Header().ChainUrl, err)
+			}
+		}
+
 		switch store.kind {
 		case createRecord:
 			// Create: create a new record by adding it to a synthetic create
@@ -348,9 +406,9 @@ func (m *StateManager) Commit() error {
 
 			m.dbTx.AddStateEntry((*types.Bytes32)(store.chainId), &m.txHash, &state.Object{Entry: data})
 
-		case updateNonce:
+		case updateNonce, updateCreditBalance:
 			// Load the previous state of the record
-			obj, err := m.dbTx.GetPersistentEntry(store.chainId[:], false)
+			obj, err := m.dbTx.GetCurrentEntry(store.chainId[:])
 			if err != nil {
 				return fmt.Errorf("failed to load state for %q", store.record.Header().ChainUrl)
 			}
@@ -375,6 +433,12 @@ func (m *StateManager) Commit() error {
 			case types.ChainTypeLiteTokenAccount:
 				old, new := old.(*protocol.LiteTokenAccount), store.record.(*protocol.LiteTokenAccount)
 				old.Nonce = new.Nonce
+				if store.kind == updateCreditBalance {
+					if new.CreditBalance.Cmp(&old.CreditBalance) > 0 {
+						return fmt.Errorf("attempted to increase the credit balance")
+					}
+					old.CreditBalance.Set(&new.CreditBalance)
+				}
 				if !old.Equal(new) {
 					return fmt.Errorf("attempted to change more than the nonce")
 				}
@@ -384,6 +448,12 @@ func (m *StateManager) Commit() error {
 				for i := 0; i < len(old.Keys) && i < len(new.Keys); i++ {
 					old.Keys[i].Nonce = new.Keys[i].Nonce
 				}
+				if store.kind == updateCreditBalance {
+					if new.CreditBalance.Cmp(&old.CreditBalance) > 0 {
+						return fmt.Errorf("attempted to increase the credit balance")
+					}
+					old.CreditBalance.Set(&new.CreditBalance)
+				}
 				if !old.Equal(new) {
 					return fmt.Errorf("attempted to change more than a nonce")
 				}
@@ -396,8 +466,11 @@ func (m *StateManager) Commit() error {
 				return fmt.Errorf("no supporting data for data entry on %v",
 					store.record.Header().ChainUrl)
 			}
-			return m.dbTx.AddDataEntry((*types.Bytes32)(store.chainId), m.txHash[:],
+			err = m.dbTx.AddDataEntry((*types.Bytes32)(store.chainId), m.txHash[:],
 				cache.entryHash, cache.dataEntry, &state.Object{Entry: data})
+			if err != nil {
+				return err
+			}
 		default:
 			panic(fmt.Errorf("invalid store kind %d", store.kind))
 		}
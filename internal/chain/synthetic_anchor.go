@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/AccumulateNetwork/accumulate/config"
+	"github.com/AccumulateNetwork/accumulate/internal/url"
 	"github.com/AccumulateNetwork/accumulate/protocol"
 	"github.com/AccumulateNetwork/accumulate/types"
 	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
@@ -53,5 +54,68 @@ func (x SyntheticAnchor) Validate(st *StateManager, tx *transactions.GenTransact
 	chain.Synthetic = body.SynthTxnAnchor
 	chain.Chains = body.Chains
 	st.Update(chain)
+
+	// Major anchors roll up minor anchors, which carry everything else
+	if body.Major {
+		return nil
+	}
+
+	source, err := url.Parse(body.Source)
+	if err != nil {
+		return fmt.Errorf("invalid source: %v", err)
+	}
+
+	// Record the roots of the source's synthetic transaction chain and minor
+	// anchor chain. The DN relays the roots of the BVNs to the BVNs in its next
+	// anchor.
+	subnet := &protocol.SubnetAnchor{Source: body.Source, SynthTxnAnchor: body.SynthTxnAnchor, ChainAnchor: body.ChainAnchor}
+	switch {
+	case x.Network.Type == config.Directory:
+		err = recordSubnetAnchor(st, nodeUrl, subnet)
+		if err != nil {
+			return err
+		}
+		if subnet.SynthTxnAnchor != [32]byte{} || subnet.ChainAnchor != [32]byte{} {
+			err = relaySubnetAnchor(st, subnet)
+			if err != nil {
+				return err
+			}
+		}
+
+	case protocol.DnUrl().Equal(source):
+		err = recordSubnetAnchor(st, nodeUrl, subnet)
+		if err != nil {
+			return err
+		}
+		for i := range body.SubnetAnchors {
+			err = recordSubnetAnchor(st, nodeUrl, &body.SubnetAnchors[i])
+			if err != nil {
+				return err
+			}
+		}
+
+	case len(body.SubnetAnchors) > 0:
+		return fmt.Errorf("invalid source %q: only the DN can relay subnet anchors", source)
+	}
+
+	// Anchors from the DN carry the price of the DN's ACME oracle
+	if body.AcmeOraclePrice == 0 || x.Network.Type == config.Directory {
+		return nil
+	}
+
+	if !protocol.DnUrl().Equal(source) {
+		return fmt.Errorf("invalid source %q: only the DN can set the price of ACME", source)
+	}
+
+	oracle := new(protocol.AcmeOracle)
+	err = st.LoadUrlAs(nodeUrl.JoinPath(protocol.Oracle), oracle)
+	if err != nil {
+		return fmt.Errorf("failed to load the ACME oracle: %v", err)
+	}
+
+	if oracle.Price != body.AcmeOraclePrice {
+		oracle.Price = body.AcmeOraclePrice
+		st.Update(oracle)
+	}
 	return nil
 }
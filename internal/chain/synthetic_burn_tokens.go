@@ -1,7 +1,6 @@
 package chain
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/AccumulateNetwork/accumulate/protocol"
@@ -20,5 +19,23 @@ func (SyntheticBurnTokens) Validate(st *StateManager, tx *transactions.GenTransa
 		return fmt.Errorf("invalid payload: %v", err)
 	}
 
-	return errors.New("not implemented") // TODO
+	issuer, ok := st.Origin.(*protocol.TokenIssuer)
+	if !ok {
+		return fmt.Errorf("invalid origin record: want %v, got %v", types.ChainTypeTokenIssuer, st.Origin.Header().Type)
+	}
+
+	switch {
+	case body.Amount.Sign() <= 0:
+		return fmt.Errorf("amount must be positive")
+	case protocol.AcmeUrl().Equal(st.OriginUrl):
+		// ACME is created at genesis instead of being issued, so only the
+		// amount burnt is tracked
+		issuer.Burned.Add(&issuer.Burned, &body.Amount)
+	case !issuer.Burn(&body.Amount):
+		return fmt.Errorf("cannot burn %v %s: only %v have been issued", &body.Amount, issuer.Symbol, &issuer.Issued)
+	}
+
+	// Updating the issuer records the burn in the issuer's history
+	st.Update(issuer)
+	return nil
 }
@@ -0,0 +1,41 @@
+package chain_test
+
+import (
+	"testing"
+
+	. "github.com/AccumulateNetwork/accumulate/internal/chain"
+	acctesting "github.com/AccumulateNetwork/accumulate/internal/testing"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntheticBurnTokens(t *testing.T) {
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	dbTx := db.Begin()
+	require.NoError(t, acctesting.CreateTokenIssuer(dbTx, protocol.AcmeUrl().String(), "ACME", 8))
+	require.NoError(t, acctesting.CreateTokenIssuer(dbTx, "foo/tokens", "FOO", 8))
+
+	burn := func(issuer string, amount int64) (*protocol.TokenIssuer, error) {
+		body := new(protocol.SyntheticBurnTokens)
+		body.Amount.SetInt64(amount)
+		tx, err := transactions.New(issuer, 1, edSigner(generateKey(), 1), body)
+		require.NoError(t, err)
+
+		st, err := NewStateManager(dbTx, tx)
+		require.NoError(t, err)
+		return st.Origin.(*protocol.TokenIssuer), SyntheticBurnTokens{}.Validate(st, tx)
+	}
+
+	// The supply of ACME is not tracked, but the amount burnt is
+	acme, err := burn(protocol.AcmeUrl().String(), 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), acme.Burned.Int64())
+
+	// Other tokens cannot be burnt if they have not been issued
+	_, err = burn("foo/tokens", 100)
+	require.EqualError(t, err, "cannot burn 100 FOO: only 0 have been issued")
+}
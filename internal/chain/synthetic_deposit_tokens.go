@@ -34,6 +34,18 @@ func (SyntheticDepositTokens) Validate(st *StateManager, tx *transactions.GenTra
 		return fmt.Errorf("invalid token URL: %v", err)
 	}
 
+	// A failed issuance is refunded to the issuer, which un-issues the tokens
+	if issuer, ok := st.Origin.(*protocol.TokenIssuer); ok && body.IsRefund {
+		if !tokenUrl.Equal(accountUrl) {
+			return fmt.Errorf("token URL does not match issuer URL")
+		}
+		if !issuer.Unissue(&body.Amount) {
+			return fmt.Errorf("cannot un-issue %v %s: only %v have been issued", &body.Amount, issuer.Symbol, &issuer.Issued)
+		}
+		st.Update(issuer)
+		return nil
+	}
+
 	var account tokenChain
 	if st.Origin != nil {
 		switch origin := st.Origin.(type) {
@@ -44,6 +56,14 @@ func (SyntheticDepositTokens) Validate(st *StateManager, tx *transactions.GenTra
 		default:
 			return fmt.Errorf("invalid origin record: want chain type %v or %v, got %v", types.ChainTypeLiteTokenAccount, types.ChainTypeTokenAccount, origin.Header().Type)
 		}
+
+		accountToken, err := account.ParseTokenUrl()
+		if err != nil {
+			return fmt.Errorf("invalid token URL: %v", err)
+		}
+		if !tokenUrl.Equal(accountToken) {
+			return fmt.Errorf("token URL does not match account token URL")
+		}
 	} else if keyHash, tok, err := protocol.ParseLiteAddress(accountUrl); err != nil {
 		return fmt.Errorf("invalid lite token account URL: %v", err)
 	} else if keyHash == nil {
@@ -0,0 +1,77 @@
+package chain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/config"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+)
+
+type SyntheticExternalAnchor struct {
+	DB      *state.StateDB
+	Network *config.Network
+}
+
+func (SyntheticExternalAnchor) Type() types.TxType { return types.TxTypeSyntheticExternalAnchor }
+
+func (x SyntheticExternalAnchor) Validate(st *StateManager, tx *transactions.GenTransaction) error {
+	body := new(protocol.SyntheticExternalAnchor)
+	err := tx.As(body)
+	if err != nil {
+		return fmt.Errorf("invalid payload: %v", err)
+	}
+
+	// The transaction has already been verified to be signed by a validator
+	poolUrl := x.Network.NodeUrl().JoinPath(protocol.ExternalAnchorPool)
+	if !st.OriginUrl.Equal(poolUrl) {
+		return fmt.Errorf("invalid origin record: %q != %q", st.OriginUrl, poolUrl)
+	}
+
+	pool, ok := st.Origin.(*protocol.ExternalAnchor)
+	if !ok {
+		return fmt.Errorf("invalid origin record: want chain type %v, got %v", types.ChainTypeExternalAnchor, st.Origin.Header().Type)
+	}
+
+	if body.Ledger == "" {
+		return fmt.Errorf("missing ledger")
+	}
+	if len(body.TxID) == 0 {
+		return fmt.Errorf("missing transaction ID")
+	}
+
+	// Only the roots of major blocks are anchored externally
+	major, err := x.DB.MajorAnchorChain()
+	if err != nil {
+		return fmt.Errorf("failed to load the major anchor chain: %v", err)
+	}
+	_, err = major.Chain.HeightOf(body.Root[:])
+	if err != nil {
+		return fmt.Errorf("%X is not the root of a major block", body.Root)
+	}
+
+	// Every leader that sees the major block may anchor it, only record the
+	// first. An older root must not replace the pool's current anchor, so
+	// every root anchored to a ledger is indexed.
+	anchorKey := fmt.Sprintf("%s/%X", body.Ledger, body.Root)
+	_, err = st.GetIndex(state.ExternalAnchorIndex, st.OriginChainId[:], anchorKey)
+	switch {
+	case err == nil, pool.Ledger == body.Ledger && pool.Root == body.Root:
+		return fmt.Errorf("%X has already been anchored to %s", body.Root, body.Ledger)
+	case !errors.Is(err, storage.ErrNotFound):
+		return fmt.Errorf("failed to load external anchor index: %v", err)
+	}
+	st.WriteIndex(state.ExternalAnchorIndex, st.OriginChainId[:], anchorKey, body.TxID)
+
+	// Each anchor is recorded on the pool's chain, which is its history
+	pool.Ledger = body.Ledger
+	pool.Index = body.Index
+	pool.Root = body.Root
+	pool.TxID = body.TxID
+	st.Update(pool)
+	return nil
+}
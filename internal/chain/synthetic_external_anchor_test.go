@@ -0,0 +1,95 @@
+package chain_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/AccumulateNetwork/accumulate/config"
+	. "github.com/AccumulateNetwork/accumulate/internal/chain"
+	acctesting "github.com/AccumulateNetwork/accumulate/internal/testing"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntheticExternalAnchor(t *testing.T) {
+	network := &config.Network{Type: config.Directory}
+	poolUrl := protocol.DnUrl().JoinPath(protocol.ExternalAnchorPool)
+
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	// Create the pool
+	dbtx := db.Begin()
+	pool := protocol.NewExternalAnchor()
+	pool.ChainUrl = types.String(poolUrl.String())
+	require.NoError(t, acctesting.WriteStates(dbtx, pool))
+	_, err := dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	// Add a major block
+	minor, err := db.MinorAnchorChain()
+	require.NoError(t, err)
+	major, err := db.MajorAnchorChain()
+	require.NoError(t, err)
+	require.NoError(t, major.Rollup(2, time.Unix(3600, 0), minor))
+	head, err := major.Record()
+	require.NoError(t, err)
+
+	height := int64(2)
+	anchor := func(root [32]byte) error {
+		body := new(protocol.SyntheticExternalAnchor)
+		body.Ledger = "file"
+		body.Index = 2
+		body.Root = root
+		body.TxID = []byte{1}
+
+		tx, err := transactions.New(poolUrl.String(), 1, edSigner(generateKey(), 1), body)
+		require.NoError(t, err)
+
+		dbtx := db.Begin()
+		st, err := NewStateManager(dbtx, tx)
+		require.NoError(t, err)
+		err = SyntheticExternalAnchor{DB: db, Network: network}.Validate(st, tx)
+		if err != nil {
+			return err
+		}
+		require.NoError(t, st.Commit())
+		height++
+		_, err = dbtx.Commit(height, time.Unix(0, 0), nil)
+		require.NoError(t, err)
+		return nil
+	}
+
+	// Only the roots of major blocks can be anchored
+	require.Error(t, anchor(sha256.Sum256([]byte{1})))
+
+	require.NoError(t, anchor(head.ChainAnchor))
+	_, err = db.Begin().LoadChainAs(poolUrl.ResourceChain(), pool)
+	require.NoError(t, err)
+	require.Equal(t, "file", pool.Ledger)
+	require.Equal(t, head.ChainAnchor, pool.Root)
+	require.Equal(t, []byte{1}, pool.TxID)
+
+	// A root is only recorded once
+	require.Error(t, anchor(head.ChainAnchor))
+
+	// Add and anchor another major block
+	minor, err = db.MinorAnchorChain()
+	require.NoError(t, err)
+	require.NoError(t, major.Rollup(height+1, time.Unix(7200, 0), minor))
+	next, err := major.Record()
+	require.NoError(t, err)
+	require.NotEqual(t, head.ChainAnchor, next.ChainAnchor)
+	require.NoError(t, anchor(next.ChainAnchor))
+
+	// An older root cannot replace the current anchor
+	require.EqualError(t, anchor(head.ChainAnchor), fmt.Sprintf("%X has already been anchored to file", head.ChainAnchor))
+	_, err = db.Begin().LoadChainAs(poolUrl.ResourceChain(), pool)
+	require.NoError(t, err)
+	require.Equal(t, next.ChainAnchor, pool.Root)
+}
@@ -1,11 +1,30 @@
 package chain
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"math/bits"
 
+	"github.com/AccumulateNetwork/accumulate/internal/url"
 	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
 	"github.com/AccumulateNetwork/accumulate/types"
 	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+)
+
+// The mirror index records:
+//
+//   - The roots of the minor anchor chains of other subnets that have been
+//     anchored, keyed by the subnet and the root.
+//   - For each mirrored record, the subnet it was mirrored from, keyed by the
+//     record and mirrorSourceKey.
+//   - For each mirrored record, the Merkle state of the record's chain on the
+//     source, keyed by the record and mirrorStateKey.
+const (
+	mirrorSourceKey = "Source"
+	mirrorStateKey  = "State"
 )
 
 type SyntheticMirror struct{}
@@ -19,25 +38,136 @@ func (SyntheticMirror) Validate(st *StateManager, tx *transactions.GenTransactio
 		return fmt.Errorf("invalid payload: %v", err)
 	}
 
-	for _, obj := range body.Objects {
-		// TODO Check merkle tree
+	source, err := url.Parse(body.Source)
+	if err != nil {
+		return fmt.Errorf("invalid source: %v", err)
+	}
+
+	for i := range body.Objects {
+		obj := &body.Objects[i]
 
 		// Unmarshal the record
-		record, err := unmarshalRecord(obj)
+		record, err := unmarshalRecord(&obj.Record)
 		if err != nil {
 			return fmt.Errorf("failed to unmarshal record: %v", err)
 		}
 
 		// Ensure the URL is valid
-		_, err = record.Header().ParseUrl()
+		u, err := record.Header().ParseUrl()
 		if err != nil {
 			return fmt.Errorf("invalid chain URL: %v", record.Header().ChainUrl)
 		}
 
-		// TODO Save the merkle state somewhere?
-		st.logDebug("Mirroring", "url", record.Header().ChainUrl)
+		// Subnets can only mirror their own records
+		if !u.Identity().Equal(source) {
+			return fmt.Errorf("%v cannot mirror %v", source, u)
+		}
+
+		// Verify the record's Merkle state against the source's anchor chain
+		err = verifyMirroredRecord(st, source, obj)
+		if err != nil {
+			return fmt.Errorf("invalid mirror of %v: %v", u, err)
+		}
+
+		// Ignore mirrors that are older than the current copy
+		current, err := loadMirrorState(st, u)
+		switch {
+		case err == nil:
+			if current.Height > obj.Record.Height {
+				st.logDebug("Ignoring stale mirror", "url", u, "height", obj.Record.Height, "current", current.Height)
+				continue
+			}
+		case !errors.Is(err, storage.ErrNotFound):
+			return fmt.Errorf("failed to load mirror state of %v: %v", u, err)
+		}
+
+		st.logDebug("Mirroring", "url", u)
 		st.Update(record)
+
+		// Save the Merkle state of the source
+		data, err := (&state.Object{Height: obj.Record.Height, Roots: obj.Record.Roots}).MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("failed to marshal mirror state of %v: %v", u, err)
+		}
+		st.WriteIndex(state.MirrorIndex, u.ResourceChain(), mirrorSourceKey, []byte(source.String()))
+		st.WriteIndex(state.MirrorIndex, u.ResourceChain(), mirrorStateKey, data)
 	}
 
 	return nil
 }
+
+// verifyMirroredRecord verifies that the proof of a mirrored record starts
+// from the anchor of the record and its Merkle state and leads to a root of the
+// source's minor anchor chain that has been anchored.
+func verifyMirroredRecord(st *StateManager, source *url.URL, obj *protocol.AnchoredRecord) error {
+	// Each root of the Merkle state corresponds to a bit of the height
+	if bits.Len64(obj.Record.Height) != len(obj.Record.Roots) {
+		return fmt.Errorf("the Merkle state of the record does not match its height")
+	}
+	for i, root := range obj.Record.Roots {
+		if (len(root) > 0) != (obj.Record.Height&(1<<i) != 0) {
+			return fmt.Errorf("the Merkle state of the record does not match its height")
+		}
+	}
+
+	// The proof must start from the anchor of the record, which binds the
+	// record to its Merkle state
+	anchor, err := obj.Record.Anchor()
+	if err != nil {
+		return fmt.Errorf("failed to anchor the record: %v", err)
+	}
+	if !bytes.Equal(anchor, obj.Proof.Start[:]) {
+		return fmt.Errorf("proof does not start from the anchor of the record")
+	}
+
+	if !obj.Proof.Validate() {
+		return fmt.Errorf("invalid proof")
+	}
+
+	_, err = st.GetIndex(state.MirrorIndex, source.ResourceChain(), obj.Proof.Result[:])
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, storage.ErrNotFound):
+		return fmt.Errorf("anchor root %X of %v has not been anchored", obj.Proof.Result, source)
+	default:
+		return fmt.Errorf("failed to load anchor root: %v", err)
+	}
+}
+
+// recordAnchoredChainRoot records the root of a subnet's minor anchor chain as
+// anchored. Mirrored records of the subnet are proven against these roots.
+func recordAnchoredChainRoot(st *StateManager, source *url.URL, root []byte) {
+	st.WriteIndex(state.MirrorIndex, source.ResourceChain(), root, []byte{1})
+}
+
+// loadMirrorState loads the Merkle state of the source's chain of a mirrored
+// record.
+func loadMirrorState(st *StateManager, u *url.URL) (*state.Object, error) {
+	data, err := st.GetIndex(state.MirrorIndex, u.ResourceChain(), mirrorStateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := new(state.Object)
+	err = obj.UnmarshalBinary(data)
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// checkNotMirrored returns an error if the record is a mirror of a record of
+// another subnet. Mirrors are read-only copies, they are only updated by the
+// source.
+func (m *StateManager) checkNotMirrored(chainId []byte) error {
+	source, err := m.GetIndex(state.MirrorIndex, chainId, mirrorSourceKey)
+	switch {
+	case err == nil:
+		return fmt.Errorf("record is a read-only mirror of a record of %s", source)
+	case errors.Is(err, storage.ErrNotFound):
+		return nil
+	default:
+		return fmt.Errorf("failed to check for a mirror: %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package chain_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/AccumulateNetwork/accumulate/internal/chain"
+	acctesting "github.com/AccumulateNetwork/accumulate/internal/testing"
+	"github.com/AccumulateNetwork/accumulate/internal/url"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntheticMirror(t *testing.T) {
+	fooKey := generateKey()
+	fooUrl, err := url.Parse("foo")
+	require.NoError(t, err)
+
+	// Create foo on the source
+	src := new(state.StateDB)
+	require.NoError(t, src.Open("mem", true, true, nil))
+	dbtx := src.Begin()
+	require.NoError(t, acctesting.CreateADI(dbtx, fooKey, "foo"))
+	_, err = dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	// Prove the state of foo against the source's anchor chain
+	obj, _, err := src.Begin().LoadChain(fooUrl.ResourceChain())
+	require.NoError(t, err)
+	anchor, err := src.MinorAnchorChain()
+	require.NoError(t, err)
+	root, err := anchor.Chain.Entry(anchor.Height() - 1)
+	require.NoError(t, err)
+	entry, err := obj.Anchor()
+	require.NoError(t, err)
+	receipt, err := anchor.Chain.Receipt(entry, root)
+	require.NoError(t, err)
+
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	mirror := func(source string, obj *state.Object) error {
+		body := new(protocol.SyntheticMirror)
+		body.Source = source
+		body.Objects = []protocol.AnchoredRecord{{Record: *obj, Proof: *protocol.ReceiptFromManaged(receipt)}}
+
+		tx, err := transactions.New(protocol.DnUrl().String(), 1, edSigner(generateKey(), 1), body)
+		require.NoError(t, err)
+
+		dbtx := db.Begin()
+		st, _ := NewStateManager(dbtx, tx)
+		err = SyntheticMirror{}.Validate(st, tx)
+		if err != nil {
+			return err
+		}
+		require.NoError(t, st.Commit())
+		_, err = dbtx.Commit(2, time.Unix(0, 0), nil)
+		require.NoError(t, err)
+		return nil
+	}
+
+	// The anchor has not been received
+	require.EqualError(t, mirror("foo", obj), fmt.Sprintf("invalid mirror of acc://foo: anchor root %X of acc://foo has not been anchored", []byte(receipt.MDRoot)))
+
+	// Receive the anchor
+	dbtx = db.Begin()
+	dbtx.WriteIndex(state.MirrorIndex, fooUrl.ResourceChain(), []byte(receipt.MDRoot), []byte{1})
+	_, err = dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	// A subnet can only mirror its own records
+	require.EqualError(t, mirror("bar", obj), "acc://bar cannot mirror acc://foo")
+
+	// The Merkle state must match the proof
+	tampered := *obj
+	tampered.Height++
+	require.EqualError(t, mirror("foo", &tampered), "invalid mirror of acc://foo: the Merkle state of the record does not match its height")
+
+	tampered = *obj
+	tampered.Roots = append([][]byte{}, obj.Roots...)
+	tampered.Roots[len(obj.Roots)-1] = bytes.Repeat([]byte{1}, 32)
+	require.EqualError(t, mirror("foo", &tampered), "invalid mirror of acc://foo: proof does not start from the anchor of the record")
+
+	// The record must match the proof
+	tampered = *obj
+	adi := new(state.AdiState)
+	require.NoError(t, obj.As(adi))
+	adi.KeyData = []byte("bar")
+	tampered.Entry, err = adi.MarshalBinary()
+	require.NoError(t, err)
+	require.EqualError(t, mirror("foo", &tampered), "invalid mirror of acc://foo: proof does not start from the anchor of the record")
+
+	require.NoError(t, mirror("foo", obj))
+	adi = new(state.AdiState)
+	_, err = db.Begin().LoadChainAs(fooUrl.ResourceChain(), adi)
+	require.NoError(t, err)
+
+	// Mirrors are read-only
+	tx, err := transactions.New("foo", 1, edSigner(fooKey, 1), new(protocol.UpdateKeyPage))
+	require.NoError(t, err)
+	st, err := NewStateManager(db.Begin(), tx)
+	require.NoError(t, err)
+	adi.KeyData = []byte("bar")
+	st.Update(adi)
+	require.EqualError(t, st.Commit(), `cannot update "acc://foo": record is a read-only mirror of a record of acc://foo`)
+}
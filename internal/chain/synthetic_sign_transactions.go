@@ -34,7 +34,7 @@ func (SyntheticSignTransactions) Validate(st *StateManager, tx *transactions.Gen
 		synTxn.Signature = append(synTxn.Signature, &transactions.ED25519Sig{
 			Nonce:     entry.Nonce,
 			Signature: entry.Signature,
-			PublicKey: tx.Signature[0].PublicKey,
+			PublicKey: tx.Signature[0].GetPublicKey(),
 		})
 
 		// Validate it
@@ -43,7 +43,7 @@ func (SyntheticSignTransactions) Validate(st *StateManager, tx *transactions.Gen
 		}
 
 		// Queue the transaction for sending next block
-		st.AddSynthTxnSig(tx.Signature[0].PublicKey, &entry)
+		st.AddSynthTxnSig(tx.Signature[0].GetPublicKey(), &entry)
 	}
 
 	return nil
@@ -1,9 +1,10 @@
 package chain
 
 import (
-	"errors"
+	"bytes"
 	"fmt"
 
+	"github.com/AccumulateNetwork/accumulate/internal/url"
 	"github.com/AccumulateNetwork/accumulate/protocol"
 	"github.com/AccumulateNetwork/accumulate/types"
 	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
@@ -20,5 +21,41 @@ func (SyntheticWriteData) Validate(st *StateManager, tx *transactions.GenTransac
 		return fmt.Errorf("invalid payload: %v", err)
 	}
 
-	return errors.New("not implemented") // TODO
+	accountUrl, err := url.Parse(tx.SigInfo.URL)
+	if err != nil {
+		return fmt.Errorf("invalid recipient URL: %v", err)
+	}
+
+	var account *protocol.LiteDataAccount
+	if st.Origin != nil {
+		var ok bool
+		account, ok = st.Origin.(*protocol.LiteDataAccount)
+		if !ok {
+			return fmt.Errorf("invalid origin record: want %v, got %v", types.ChainTypeLiteDataAccount, st.Origin.Header().Type)
+		}
+	} else if chainId, err := protocol.ParseLiteDataAddress(accountUrl); err != nil {
+		return fmt.Errorf("invalid lite data account URL: %v", err)
+	} else if chainId == nil {
+		return fmt.Errorf("could not find data account")
+	} else if !bytes.Equal(chainId, protocol.ComputeLiteDataAccountId(&body.Entry)) {
+		return fmt.Errorf("the external IDs of the first entry do not match the lite data account URL")
+	} else {
+		// Address is lite and the account doesn't exist, so create one
+		account = protocol.NewLiteDataAccount()
+		account.ChainUrl = types.String(accountUrl.String())
+	}
+
+	//check will return error if there is too much data or no data for the entry
+	_, err = body.Entry.CheckSize()
+	if err != nil {
+		return err
+	}
+
+	dataPayload, err := body.Entry.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error marshaling data entry, %v", err)
+	}
+
+	st.UpdateData(account, body.Entry.Hash(), dataPayload)
+	return nil
 }
@@ -0,0 +1,62 @@
+package chain
+
+import (
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+)
+
+type TransferCredits struct{}
+
+func (TransferCredits) Type() types.TxType { return types.TxTypeTransferCredits }
+
+func (TransferCredits) Validate(st *StateManager, tx *transactions.GenTransaction) error {
+	body := new(protocol.TransferCredits)
+	err := tx.As(body)
+	if err != nil {
+		return fmt.Errorf("invalid payload: %v", err)
+	}
+
+	if body.Amount == 0 {
+		return fmt.Errorf("amount must be greater than zero")
+	}
+
+	recvUrl, err := checkCreditRecipient(st, tx, body.Recipient)
+	if err != nil {
+		return err
+	}
+	if recvUrl.Equal(st.OriginUrl) {
+		return fmt.Errorf("cannot transfer credits from %q to itself", st.OriginUrl)
+	}
+
+	// Reload the origin, as the fee may have been debited from it
+	origin, err := st.Load(st.OriginChainId)
+	if err != nil {
+		return fmt.Errorf("failed to load origin: %v", err)
+	}
+
+	var account creditChain
+	switch origin := origin.(type) {
+	case *protocol.LiteTokenAccount:
+		account = origin
+	case *protocol.KeyPage:
+		account = origin
+	default:
+		return fmt.Errorf("invalid origin record: want chain type %v or %v, got %v", types.ChainTypeLiteTokenAccount, types.ChainTypeKeyPage, origin.Header().Type)
+	}
+
+	if !account.DebitCredits(body.Amount) {
+		return fmt.Errorf("insufficient credits: %q cannot transfer %d credits", st.OriginUrl, body.Amount)
+	}
+	st.Update(account)
+
+	// Create the synthetic transaction
+	sdc := new(protocol.SyntheticDepositCredits)
+	copy(sdc.Cause[:], tx.TransactionHash())
+	sdc.Amount = body.Amount
+	st.Submit(recvUrl, sdc)
+
+	return nil
+}
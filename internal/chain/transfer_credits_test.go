@@ -0,0 +1,67 @@
+package chain_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/AccumulateNetwork/accumulate/internal/chain"
+	acctesting "github.com/AccumulateNetwork/accumulate/internal/testing"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferCredits(t *testing.T) {
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	fooKey := generateKey()
+	dbtx := db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbtx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateTokenAccount(dbtx, "foo/tokens", protocol.AcmeUrl().String(), 1, false))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page1"))
+	_, err := dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		Origin    string
+		Recipient string
+		Amount    uint64
+		Error     string
+	}{
+		"Key page":       {"foo/page0", "foo/page1", 1000, ""},
+		"Zero":           {"foo/page0", "foo/page1", 0, "amount must be greater than zero"},
+		"Insufficient":   {"foo/page0", "foo/page1", acctesting.TestCredits + 1, `insufficient credits: "acc://foo/page0" cannot transfer 1000000001 credits`},
+		"Self":           {"foo/page0", "foo/page0", 1000, `cannot transfer credits from "acc://foo/page0" to itself`},
+		"Bad recipient":  {"foo/page0", "foo/tokens", 1000, "invalid recipient: want chain type liteTokenAccount or keyPage, got tokenAccount"},
+		"Token account":  {"foo/tokens", "foo/page1", 1000, "invalid origin record: want chain type liteTokenAccount or keyPage, got tokenAccount"},
+		"Remote account": {"foo/page0", "bar/page0", 1000, ""},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := new(protocol.TransferCredits)
+			body.Recipient = c.Recipient
+			body.Amount = c.Amount
+
+			tx, err := transactions.New(c.Origin, 1, edSigner(fooKey, 1), body)
+			require.NoError(t, err)
+
+			st, err := NewStateManager(db.Begin(), tx)
+			require.NoError(t, err)
+
+			err = TransferCredits{}.Validate(st, tx)
+			if c.Error != "" {
+				require.EqualError(t, err, c.Error)
+				return
+			}
+			require.NoError(t, err)
+
+			page := st.Origin.(*protocol.KeyPage)
+			require.Equal(t, int64(acctesting.TestCredits-c.Amount), page.CreditBalance.Int64())
+
+			// Do not store state changes
+		})
+	}
+}
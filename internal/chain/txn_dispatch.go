@@ -40,8 +40,10 @@ func newDispatcher(opts ExecutorOptions) (*dispatcher, error) {
 	d.bvn = make([]*http.HTTP, len(opts.Network.BvnNames))
 	d.bvnBatches = make([]txBatch, len(opts.Network.BvnNames))
 
-	// If we're not a directory, make an RPC client for the DN
-	if !d.isDirectory && !d.IsTest {
+	// If we're not a directory, make an RPC client for the DN. Tests that run
+	// a single subnet do not configure a DN.
+	_, hasDirectory := opts.Network.Addresses[strings.ToLower(protocol.Directory)]
+	if !d.isDirectory && (hasDirectory || !d.IsTest) {
 		// Get the address of a directory node
 		addr := opts.Network.AddressWithPortOffset(protocol.Directory, networks.TmRpcPortOffset)
 
@@ -0,0 +1,75 @@
+package chain
+
+import (
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/internal/url"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+)
+
+type UpdateAccountAuth struct{}
+
+func (UpdateAccountAuth) Type() types.TxType { return types.TxTypeUpdateAccountAuth }
+
+func (UpdateAccountAuth) Validate(st *StateManager, tx *transactions.GenTransaction) error {
+	body := new(protocol.UpdateAccountAuth)
+	err := tx.As(body)
+	if err != nil {
+		return fmt.Errorf("invalid payload: %v", err)
+	}
+
+	// The transaction has already been authorized by the account's current
+	// key book
+	switch st.Origin.(type) {
+	case *state.AdiState, *state.TokenAccount, *protocol.DataAccount, *protocol.TokenIssuer:
+		// OK
+	default:
+		return fmt.Errorf("invalid origin record: chain type %v cannot be assigned to a key book", st.Origin.Header().Type)
+	}
+
+	if isSubnetRecord(st.OriginUrl) {
+		return fmt.Errorf("cannot reassign %q: the records of a subnet are governed by its validators", st.OriginUrl)
+	}
+
+	bookUrl, err := url.Parse(body.KeyBookUrl)
+	if err != nil {
+		return fmt.Errorf("invalid key book URL: %v", err)
+	}
+
+	if !bookUrl.Identity().Equal(st.OriginUrl.Identity()) {
+		return fmt.Errorf("%q does not belong to %q", bookUrl, st.OriginUrl.Identity())
+	}
+
+	book := new(protocol.KeyBook)
+	err = st.LoadUrlAs(bookUrl, book)
+	if err != nil {
+		return fmt.Errorf("invalid key book %q: %v", bookUrl, err)
+	}
+
+	bookId := types.Bytes(bookUrl.ResourceChain()).AsBytes32()
+	if st.Origin.Header().KeyBook == bookId {
+		return fmt.Errorf("%q is already assigned to %q", st.OriginUrl, bookUrl)
+	}
+
+	if mgr := st.Origin.Header().ManagerKeyBook; mgr != "" {
+		mgrUrl, err := url.Parse(string(mgr))
+		if err == nil && mgrUrl.Equal(bookUrl) {
+			return fmt.Errorf("%q cannot be assigned to its manager key book", st.OriginUrl)
+		}
+	}
+
+	st.Origin.Header().KeyBook = bookId
+	st.Update(st.Origin)
+	return nil
+}
+
+func (UpdateAccountAuth) CheckTx(st *StateManager, tx *transactions.GenTransaction) error {
+	return UpdateAccountAuth{}.Validate(st, tx)
+}
+
+func (UpdateAccountAuth) DeliverTx(st *StateManager, tx *transactions.GenTransaction) error {
+	return UpdateAccountAuth{}.Validate(st, tx)
+}
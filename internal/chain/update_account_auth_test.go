@@ -0,0 +1,76 @@
+package chain_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/AccumulateNetwork/accumulate/internal/chain"
+	acctesting "github.com/AccumulateNetwork/accumulate/internal/testing"
+	"github.com/AccumulateNetwork/accumulate/internal/url"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateAccountAuth(t *testing.T) {
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	fooKey, barKey, testKey := generateKey(), generateKey(), generateKey()
+	dbtx := db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbtx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateADI(dbtx, barKey, "bar"))
+	require.NoError(t, acctesting.CreateTokenAccount(dbtx, "foo/tokens", protocol.AcmeUrl().String(), 1, false))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page1", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyBook(dbtx, "foo/book1", "foo/page1"))
+	require.NoError(t, acctesting.CreateADI(dbtx, fooKey, "bvn-foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "bvn-foo/page1", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyBook(dbtx, "bvn-foo/book1", "bvn-foo/page1"))
+	_, err := dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	bookId := func(s string) types.Bytes32 {
+		u, err := url.Parse(s)
+		require.NoError(t, err)
+		return types.Bytes(u.ResourceChain()).AsBytes32()
+	}
+
+	cases := map[string]struct {
+		Origin string
+		Book   string
+		Error  string
+	}{
+		"Token account":  {"foo/tokens", "foo/book1", ""},
+		"Identity":       {"foo", "foo/book1", ""},
+		"Same book":      {"foo/tokens", "foo/book0", `"acc://foo/tokens" is already assigned to "acc://foo/book0"`},
+		"Other identity": {"foo/tokens", "bar/book0", `"acc://bar/book0" does not belong to "acc://foo"`},
+		"Not a book":     {"foo/tokens", "foo/page1", `invalid key book "acc://foo/page1": want *protocol.KeyBook, got *protocol.KeyPage`},
+		"Key page":       {"foo/page1", "foo/book1", `invalid origin record: chain type keyPage cannot be assigned to a key book`},
+		"Subnet":         {"bvn-foo", "bvn-foo/book1", `cannot reassign "acc://bvn-foo": the records of a subnet are governed by its validators`},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := new(protocol.UpdateAccountAuth)
+			body.KeyBookUrl = c.Book
+
+			tx, err := transactions.New(c.Origin, 1, edSigner(fooKey, 1), body)
+			require.NoError(t, err)
+
+			st, err := NewStateManager(db.Begin(), tx)
+			require.NoError(t, err)
+
+			err = UpdateAccountAuth{}.DeliverTx(st, tx)
+			if c.Error != "" {
+				require.EqualError(t, err, c.Error)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, bookId(c.Book), st.Origin.Header().KeyBook)
+
+			// Do not store state changes
+		})
+	}
+}
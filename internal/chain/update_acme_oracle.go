@@ -0,0 +1,44 @@
+package chain
+
+import (
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/config"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+)
+
+type UpdateAcmeOracle struct {
+	Network *config.Network
+}
+
+func (UpdateAcmeOracle) Type() types.TxType { return types.TxTypeUpdateAcmeOracle }
+
+func (x UpdateAcmeOracle) Validate(st *StateManager, tx *transactions.GenTransaction) error {
+	body := new(protocol.UpdateAcmeOracle)
+	err := tx.As(body)
+	if err != nil {
+		return fmt.Errorf("invalid payload: %v", err)
+	}
+
+	// The transaction has already been authorized by the oracle's key book
+	oracleUrl := x.Network.NodeUrl().JoinPath(protocol.Oracle)
+	if !st.OriginUrl.Equal(oracleUrl) {
+		return fmt.Errorf("invalid origin record: %q != %q", st.OriginUrl, oracleUrl)
+	}
+
+	oracle, ok := st.Origin.(*protocol.AcmeOracle)
+	if !ok {
+		return fmt.Errorf("invalid origin record: want chain type %v, got %v", types.ChainTypeAcmeOracle, st.Origin.Header().Type)
+	}
+
+	if body.Price == 0 {
+		return fmt.Errorf("the price of ACME cannot be zero")
+	}
+
+	// Each update is recorded on the oracle's chain, which is its history
+	oracle.Price = body.Price
+	st.Update(oracle)
+	return nil
+}
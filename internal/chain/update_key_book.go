@@ -0,0 +1,161 @@
+package chain
+
+import (
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/internal/url"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+)
+
+type UpdateKeyBook struct{}
+
+func (UpdateKeyBook) Type() types.TxType {
+	return types.TxTypeUpdateKeyBook
+}
+
+func (UpdateKeyBook) Validate(st *StateManager, tx *transactions.GenTransaction) error {
+	body := new(protocol.UpdateKeyBook)
+	err := tx.As(body)
+	if err != nil {
+		return fmt.Errorf("invalid payload: %v", err)
+	}
+
+	book, ok := st.Origin.(*protocol.KeyBook)
+	if !ok {
+		return fmt.Errorf("invalid origin record: want chain type %v, got %v", types.ChainTypeKeyBook, st.Origin.Header().Type)
+	}
+
+	if isValidatorRecord(st.OriginUrl) {
+		return fmt.Errorf("cannot modify %q: the validators can only be changed with %v", st.OriginUrl, types.TxTypeUpdateValidator)
+	}
+
+	pageUrl, err := url.Parse(body.Page)
+	if err != nil {
+		return fmt.Errorf("invalid key page URL: %v", err)
+	}
+
+	if !pageUrl.Identity().Equal(st.OriginUrl.Identity()) {
+		return fmt.Errorf("%q does not belong to %q", pageUrl, st.OriginUrl.Identity())
+	}
+
+	page := new(protocol.KeyPage)
+	err = st.LoadUrlAs(pageUrl, page)
+	if err != nil {
+		return fmt.Errorf("invalid key page: %v", err)
+	}
+
+	pageId := types.Bytes(pageUrl.ResourceChain()).AsBytes32()
+	priority := -1
+	for i, p := range book.Pages {
+		if p == pageId {
+			priority = i
+		}
+	}
+
+	// 0 is the highest priority, followed by 1, etc. The signing page is
+	// identified by its priority. A page cannot modify a page with a higher
+	// priority than itself, or give a page a higher priority than itself.
+	signer := tx.SigInfo.KeyPageIndex
+
+	switch body.Operation {
+	case protocol.AddPage:
+		if (page.KeyBook != types.Bytes32{}) {
+			return fmt.Errorf("%q has already been assigned to a key book", pageUrl)
+		}
+
+		// New pages are added with the lowest priority
+		book.Pages = append(book.Pages, pageId)
+		page.KeyBook = st.OriginChainId
+
+	case protocol.RemovePage:
+		if priority < 0 {
+			return fmt.Errorf("%q is not a page of %q", pageUrl, st.OriginUrl)
+		}
+		if signer > uint64(priority) {
+			return fmt.Errorf("cannot remove %q with a lower priority key page", pageUrl)
+		}
+		if page.IsLocked(st.BlockHeight) {
+			return fmt.Errorf("cannot remove %q: it is locked until height %d", pageUrl, page.LockHeight)
+		}
+		if len(book.Pages) == 1 {
+			return fmt.Errorf("cannot remove the last page of a key book")
+		}
+		// Removing the page changes the priority of every page after it
+		err = checkNoPendingTransactions(st, book.Pages[priority:])
+		if err != nil {
+			return fmt.Errorf("cannot remove %q: %v", pageUrl, err)
+		}
+
+		book.Pages = append(book.Pages[:priority], book.Pages[priority+1:]...)
+		page.KeyBook = types.Bytes32{}
+
+	case protocol.SetPagePriority:
+		if priority < 0 {
+			return fmt.Errorf("%q is not a page of %q", pageUrl, st.OriginUrl)
+		}
+		if signer > uint64(priority) {
+			return fmt.Errorf("cannot modify %q with a lower priority key page", pageUrl)
+		}
+		if page.IsLocked(st.BlockHeight) {
+			return fmt.Errorf("cannot modify %q: it is locked until height %d", pageUrl, page.LockHeight)
+		}
+		if body.Priority >= uint64(len(book.Pages)) {
+			return fmt.Errorf("priority %d is out of range: %q has %d pages", body.Priority, st.OriginUrl, len(book.Pages))
+		}
+		if signer > body.Priority {
+			return fmt.Errorf("cannot give %q a higher priority than the signing key page", pageUrl)
+		}
+		// Moving the page changes the priority of every page it moves past
+		from, to := uint64(priority), body.Priority
+		if from > to {
+			from, to = to, from
+		}
+		err = checkNoPendingTransactions(st, book.Pages[from:to+1])
+		if err != nil {
+			return fmt.Errorf("cannot modify %q: %v", pageUrl, err)
+		}
+
+		book.Pages = append(book.Pages[:priority], book.Pages[priority+1:]...)
+		book.Pages = append(book.Pages[:body.Priority], append([][32]byte{pageId}, book.Pages[body.Priority:]...)...)
+
+	default:
+		return fmt.Errorf("invalid operation: %v", body.Operation)
+	}
+
+	st.Update(book, page)
+	return nil
+}
+
+// checkNoPendingTransactions returns an error if any of the pages has pending
+// transactions. The signatures of a pending transaction identify the page that
+// signed it by its priority, so the priority of a page must not change while it
+// has pending transactions.
+func checkNoPendingTransactions(st *StateManager, pages [][32]byte) error {
+	for _, id := range pages {
+		set, err := loadPendingIndex(st.dbTx, id)
+		if err != nil {
+			return err
+		}
+		if len(set.Transactions) == 0 {
+			continue
+		}
+
+		page := new(protocol.KeyPage)
+		err = st.LoadAs(id, page)
+		if err != nil {
+			return fmt.Errorf("invalid key page %X: %v", id, err)
+		}
+		return fmt.Errorf("%q has pending transactions", page.ChainUrl)
+	}
+	return nil
+}
+
+func (UpdateKeyBook) CheckTx(st *StateManager, tx *transactions.GenTransaction) error {
+	return UpdateKeyBook{}.Validate(st, tx)
+}
+
+func (UpdateKeyBook) DeliverTx(st *StateManager, tx *transactions.GenTransaction) error {
+	return UpdateKeyBook{}.Validate(st, tx)
+}
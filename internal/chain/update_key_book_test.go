@@ -0,0 +1,166 @@
+package chain_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/AccumulateNetwork/accumulate/internal/chain"
+	acctesting "github.com/AccumulateNetwork/accumulate/internal/testing"
+	"github.com/AccumulateNetwork/accumulate/internal/url"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateKeyBook(t *testing.T) {
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	fooKey, testKey := generateKey(), generateKey()
+	locked := protocol.NewKeyPage()
+	locked.ChainUrl = "acc://foo/page4"
+	locked.Keys = []*protocol.KeySpec{{PublicKey: testKey.PubKey().Bytes()}}
+	locked.LockHeight = 20
+
+	dbtx := db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbtx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page0", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page1", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page2", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page3", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "bar/page0", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.WriteStates(dbtx, locked))
+	require.NoError(t, acctesting.CreateKeyBook(dbtx, "foo/book", "foo/page0", "foo/page1", "foo/page2", "foo/page4"))
+	_, err := dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	pageId := func(s string) [32]byte {
+		u, err := url.Parse(s)
+		require.NoError(t, err)
+		return types.Bytes(u.ResourceChain()).AsBytes32()
+	}
+
+	cases := map[string]struct {
+		Signer    uint64
+		Operation protocol.KeyBookOperation
+		Page      string
+		Priority  uint64
+		Pages     []string
+		Error     string
+	}{
+		"Add":                 {2, protocol.AddPage, "foo/page3", 0, []string{"foo/page0", "foo/page1", "foo/page2", "foo/page4", "foo/page3"}, ""},
+		"Add assigned":        {0, protocol.AddPage, "foo/page1", 0, nil, `"acc://foo/page1" has already been assigned to a key book`},
+		"Add other identity":  {0, protocol.AddPage, "bar/page0", 0, nil, `"acc://bar/page0" does not belong to "acc://foo"`},
+		"Remove":              {0, protocol.RemovePage, "foo/page1", 0, []string{"foo/page0", "foo/page2", "foo/page4"}, ""},
+		"Remove higher":       {2, protocol.RemovePage, "foo/page1", 0, nil, `cannot remove "acc://foo/page1" with a lower priority key page`},
+		"Remove not in book":  {0, protocol.RemovePage, "foo/page3", 0, nil, `"acc://foo/page3" is not a page of "acc://foo/book"`},
+		"Demote":              {0, protocol.SetPagePriority, "foo/page0", 2, []string{"foo/page1", "foo/page2", "foo/page0", "foo/page4"}, ""},
+		"Promote":             {1, protocol.SetPagePriority, "foo/page2", 1, []string{"foo/page0", "foo/page2", "foo/page1", "foo/page4"}, ""},
+		"Promote above self":  {1, protocol.SetPagePriority, "foo/page2", 0, nil, `cannot give "acc://foo/page2" a higher priority than the signing key page`},
+		"Priority too high":   {0, protocol.SetPagePriority, "foo/page2", 4, nil, `priority 4 is out of range: "acc://foo/book" has 4 pages`},
+		"Reorder higher page": {1, protocol.SetPagePriority, "foo/page0", 2, nil, `cannot modify "acc://foo/page0" with a lower priority key page`},
+		"Remove locked":       {0, protocol.RemovePage, "foo/page4", 0, nil, `cannot remove "acc://foo/page4": it is locked until height 20`},
+		"Reorder locked":      {0, protocol.SetPagePriority, "foo/page4", 0, nil, `cannot modify "acc://foo/page4": it is locked until height 20`},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := new(protocol.UpdateKeyBook)
+			body.Operation = c.Operation
+			body.Page = c.Page
+			body.Priority = c.Priority
+
+			tx, err := transactions.NewWith(&transactions.SignatureInfo{
+				URL:          "foo/book",
+				KeyPageIndex: c.Signer,
+			}, edSigner(testKey, 1), body)
+			require.NoError(t, err)
+
+			st, err := NewStateManager(db.Begin(), tx)
+			require.NoError(t, err)
+
+			err = UpdateKeyBook{}.DeliverTx(st, tx)
+			if c.Error != "" {
+				require.EqualError(t, err, c.Error)
+				return
+			}
+			require.NoError(t, err)
+
+			book := st.Origin.(*protocol.KeyBook)
+			require.Len(t, book.Pages, len(c.Pages))
+			for i, s := range c.Pages {
+				require.Equal(t, pageId(s), book.Pages[i], "Wrong page at %d", i)
+			}
+
+			// Do not store state changes
+		})
+	}
+}
+
+func TestUpdateKeyBook_PendingTransactions(t *testing.T) {
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	fooKey, testKey := generateKey(), generateKey()
+	dbtx := db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbtx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page0", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page1", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page2", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page3", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyBook(dbtx, "foo/book", "foo/page0", "foo/page1", "foo/page2", "foo/page3"))
+
+	// foo/page2 has a pending transaction
+	set := new(protocol.PendingTransactionSet)
+	set.Add([32]byte{1})
+	data, err := set.MarshalBinary()
+	require.NoError(t, err)
+	u, err := url.Parse("foo/page2")
+	require.NoError(t, err)
+	dbtx.WriteIndex(state.PendingIndex, u.ResourceChain(), "Transactions", data)
+
+	_, err = dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		Operation protocol.KeyBookOperation
+		Page      string
+		Priority  uint64
+		Error     string
+	}{
+		"Remove after":       {protocol.RemovePage, "foo/page3", 0, ""},
+		"Remove before":      {protocol.RemovePage, "foo/page1", 0, `cannot remove "acc://foo/page1": "acc://foo/page2" has pending transactions`},
+		"Remove pending":     {protocol.RemovePage, "foo/page2", 0, `cannot remove "acc://foo/page2": "acc://foo/page2" has pending transactions`},
+		"Reorder before":     {protocol.SetPagePriority, "foo/page1", 0, ""},
+		"Reorder past":       {protocol.SetPagePriority, "foo/page3", 1, `cannot modify "acc://foo/page3": "acc://foo/page2" has pending transactions`},
+		"Reorder pending":    {protocol.SetPagePriority, "foo/page2", 0, `cannot modify "acc://foo/page2": "acc://foo/page2" has pending transactions`},
+		"Reorder to pending": {protocol.SetPagePriority, "foo/page0", 2, `cannot modify "acc://foo/page0": "acc://foo/page2" has pending transactions`},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := new(protocol.UpdateKeyBook)
+			body.Operation = c.Operation
+			body.Page = c.Page
+			body.Priority = c.Priority
+
+			tx, err := transactions.NewWith(&transactions.SignatureInfo{
+				URL:          "foo/book",
+				KeyPageIndex: 0,
+			}, edSigner(testKey, 1), body)
+			require.NoError(t, err)
+
+			st, err := NewStateManager(db.Begin(), tx)
+			require.NoError(t, err)
+
+			err = UpdateKeyBook{}.DeliverTx(st, tx)
+			if c.Error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, c.Error)
+			}
+		})
+	}
+}
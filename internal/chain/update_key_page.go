@@ -2,6 +2,7 @@ package chain
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 
 	"github.com/AccumulateNetwork/accumulate/protocol"
@@ -27,6 +28,10 @@ func (UpdateKeyPage) Validate(st *StateManager, tx *transactions.GenTransaction)
 		return fmt.Errorf("invalid origin record: want chain type %v, got %v", types.ChainTypeKeyPage, st.Origin.Header().Type)
 	}
 
+	if isValidatorRecord(st.OriginUrl) {
+		return fmt.Errorf("cannot modify %q: the validators can only be changed with %v", st.OriginUrl, types.TxTypeUpdateValidator)
+	}
+
 	// We're changing the height of the key page, so reset all the nonces
 	for _, key := range page.Keys {
 		key.Nonce = 0
@@ -70,14 +75,25 @@ func (UpdateKeyPage) Validate(st *StateManager, tx *transactions.GenTransaction)
 		}
 	}
 
+	// The keys of a locked page cannot be changed, but the lock can be
+	// extended
+	if page.IsLocked(st.BlockHeight) && body.Operation != protocol.SetLock {
+		return fmt.Errorf("cannot modify %q: it is locked until height %d", st.OriginUrl, page.LockHeight)
+	}
+
 	switch body.Operation {
 	case protocol.AddKey:
 		if len(body.Key) > 0 {
 			return fmt.Errorf("trying to add a new key but you gave me an existing key")
 		}
+		err = validateKey(body.KeyAlgorithm, body.NewKey)
+		if err != nil {
+			return err
+		}
 
 		page.Keys = append(page.Keys, &protocol.KeySpec{
-			PublicKey: body.NewKey,
+			PublicKey:    body.NewKey,
+			KeyAlgorithm: body.KeyAlgorithm,
 		})
 
 	case protocol.UpdateKey:
@@ -87,8 +103,13 @@ func (UpdateKeyPage) Validate(st *StateManager, tx *transactions.GenTransaction)
 		if oldKey == nil {
 			return fmt.Errorf("no matching key found")
 		}
+		err = validateKey(body.KeyAlgorithm, body.NewKey)
+		if err != nil {
+			return err
+		}
 
 		oldKey.PublicKey = body.NewKey
+		oldKey.KeyAlgorithm = body.KeyAlgorithm
 
 	case protocol.RemoveKey:
 		if len(body.Key) == 0 {
@@ -104,6 +125,52 @@ func (UpdateKeyPage) Validate(st *StateManager, tx *transactions.GenTransaction)
 			return fmt.Errorf("cannot delete last key of the highest priority page of a key book")
 		}
 
+		if page.GetThreshold() > unexpiredKeyCount(page) {
+			return fmt.Errorf("cannot delete a key: the page would have fewer unexpired keys than its threshold of %d", page.GetThreshold())
+		}
+
+	case protocol.SetThreshold:
+		if body.Threshold == 0 {
+			return fmt.Errorf("threshold must be at least 1")
+		}
+		if body.Threshold > uint64(len(page.Keys)) {
+			return fmt.Errorf("threshold of %d is greater than the number of keys, %d", body.Threshold, len(page.Keys))
+		}
+		if n := unexpiredKeyCount(page); body.Threshold > n {
+			return fmt.Errorf("threshold of %d is greater than the number of unexpired keys, %d", body.Threshold, n)
+		}
+
+		page.Threshold = body.Threshold
+
+	case protocol.SetKeyExpiration:
+		if len(body.Key) == 0 {
+			return fmt.Errorf("trying to set the expiration of a key but you didn't give me an existing key")
+		}
+		if oldKey == nil {
+			return fmt.Errorf("no matching key found")
+		}
+		// Zero clears the expiration
+		if body.ExpirationHeight != 0 && body.ExpirationHeight <= st.BlockHeight {
+			return fmt.Errorf("expiration height %d is not after the current height %d", body.ExpirationHeight, st.BlockHeight)
+		}
+
+		oldKey.ExpirationHeight = body.ExpirationHeight
+
+		if body.ExpirationHeight != 0 && unexpiredKeyCount(page) < page.GetThreshold() {
+			return fmt.Errorf("cannot expire a key: the page would have fewer unexpired keys than its threshold of %d", page.GetThreshold())
+		}
+
+	case protocol.SetLock:
+		if body.LockBlocks == 0 {
+			return fmt.Errorf("a lock must last at least one block")
+		}
+		lockHeight := st.BlockHeight + body.LockBlocks
+		if lockHeight < page.LockHeight {
+			return fmt.Errorf("cannot shorten the lock of %q: it is locked until height %d", st.OriginUrl, page.LockHeight)
+		}
+
+		page.LockHeight = lockHeight
+
 	default:
 		return fmt.Errorf("invalid operation: %v", body.Operation)
 	}
@@ -112,6 +179,33 @@ func (UpdateKeyPage) Validate(st *StateManager, tx *transactions.GenTransaction)
 	return nil
 }
 
+// validateKey checks that a key can be added to a page. The size of an RSA key
+// is checked unless the page is given the hash of the key, in which case the
+// size is checked when a signature is verified.
+func validateKey(alg protocol.KeyAlgorithm, key []byte) error {
+	if alg != protocol.RSA || len(key) == sha256.Size {
+		return nil
+	}
+	_, err := transactions.ParseRSAPublicKey(key)
+	if err != nil {
+		return fmt.Errorf("invalid key: %v", err)
+	}
+	return nil
+}
+
+// unexpiredKeyCount returns the number of keys of the page that do not have an
+// expiration. Every key with an expiration eventually expires, so enough keys
+// without one must remain to meet the threshold for the page to stay usable.
+func unexpiredKeyCount(page *protocol.KeyPage) uint64 {
+	var count uint64
+	for _, key := range page.Keys {
+		if key.ExpirationHeight == 0 {
+			count++
+		}
+	}
+	return count
+}
+
 func (UpdateKeyPage) CheckTx(st *StateManager, tx *transactions.GenTransaction) error {
 	return UpdateKeyPage{}.Validate(st, tx)
 }
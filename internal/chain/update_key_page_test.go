@@ -2,6 +2,10 @@ package chain_test
 
 import (
 	"crypto/ed25519"
+	crand "crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
 	"fmt"
 	"testing"
 	"time"
@@ -23,8 +27,8 @@ func generateKey() tmed25519.PrivKey {
 	return tmed25519.PrivKey(key)
 }
 
-func edSigner(key tmed25519.PrivKey, nonce uint64) func(hash []byte) (*transactions.ED25519Sig, error) {
-	return func(hash []byte) (*transactions.ED25519Sig, error) {
+func edSigner(key tmed25519.PrivKey, nonce uint64) func(hash []byte) (transactions.Signature, error) {
+	return func(hash []byte) (transactions.Signature, error) {
 		sig := new(transactions.ED25519Sig)
 		return sig, sig.Sign(1, key, hash)
 	}
@@ -71,3 +75,286 @@ func TestUpdateKeyPage_Priority(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateKeyPage_Threshold(t *testing.T) {
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	fooKey, testKey1, testKey2, testKey3 := generateKey(), generateKey(), generateKey(), generateKey()
+	expiring := protocol.NewKeyPage()
+	expiring.ChainUrl = "acc://foo/page1"
+	expiring.Keys = []*protocol.KeySpec{{PublicKey: testKey1.PubKey().Bytes()}, {PublicKey: testKey2.PubKey().Bytes()}, {PublicKey: testKey3.PubKey().Bytes(), ExpirationHeight: 30}}
+	expiring.Threshold = 2
+
+	dbtx := db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbtx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page0", testKey1.PubKey().Bytes(), testKey2.PubKey().Bytes()))
+	require.NoError(t, acctesting.WriteStates(dbtx, expiring))
+	require.NoError(t, acctesting.CreateKeyBook(dbtx, "foo/book", "foo/page0", "foo/page1"))
+	_, err := dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		Page      string
+		Threshold uint64
+		Error     string
+	}{
+		"Zero":           {"foo/page0", 0, "threshold must be at least 1"},
+		"Valid":          {"foo/page0", 2, ""},
+		"Too high":       {"foo/page0", 3, "threshold of 3 is greater than the number of keys, 2"},
+		"Key expires":    {"foo/page1", 3, "threshold of 3 is greater than the number of unexpired keys, 2"},
+		"Unexpired keys": {"foo/page1", 2, ""},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := new(protocol.UpdateKeyPage)
+			body.Operation = protocol.SetThreshold
+			body.Threshold = c.Threshold
+
+			tx, err := transactions.New(c.Page, 1, edSigner(testKey1, 1), body)
+			require.NoError(t, err)
+
+			st, err := NewStateManager(db.Begin(), tx)
+			require.NoError(t, err)
+
+			err = UpdateKeyPage{}.DeliverTx(st, tx)
+			if c.Error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, c.Error)
+			}
+		})
+	}
+}
+
+func TestUpdateKeyPage_RemoveKey(t *testing.T) {
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	fooKey, testKey1, testKey2, testKey3 := generateKey(), generateKey(), generateKey(), generateKey()
+	page := protocol.NewKeyPage()
+	page.ChainUrl = "acc://foo/page0"
+	page.Keys = []*protocol.KeySpec{{PublicKey: testKey1.PubKey().Bytes()}, {PublicKey: testKey2.PubKey().Bytes()}, {PublicKey: testKey3.PubKey().Bytes(), ExpirationHeight: 30}}
+	page.Threshold = 2
+	unset := protocol.NewKeyPage()
+	unset.ChainUrl = "acc://foo/page1"
+	unset.Keys = []*protocol.KeySpec{{PublicKey: testKey1.PubKey().Bytes()}, {PublicKey: testKey3.PubKey().Bytes(), ExpirationHeight: 30}}
+
+	dbtx := db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbtx, fooKey, "foo"))
+	require.NoError(t, acctesting.WriteStates(dbtx, page, unset))
+	require.NoError(t, acctesting.CreateKeyBook(dbtx, "foo/book", "foo/page0", "foo/page1"))
+	_, err := dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		Page  string
+		Key   tmed25519.PrivKey
+		Error string
+	}{
+		"Expiring key":      {"foo/page0", testKey3, ""},
+		"Unexpired key":     {"foo/page0", testKey2, "cannot delete a key: the page would have fewer unexpired keys than its threshold of 2"},
+		"Default threshold": {"foo/page1", testKey1, "cannot delete a key: the page would have fewer unexpired keys than its threshold of 1"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := new(protocol.UpdateKeyPage)
+			body.Operation = protocol.RemoveKey
+			body.Key = c.Key.PubKey().Bytes()
+
+			tx, err := transactions.New(c.Page, 1, edSigner(testKey1, 1), body)
+			require.NoError(t, err)
+
+			st, err := NewStateManager(db.Begin(), tx)
+			require.NoError(t, err)
+			st.BlockHeight = 10
+
+			err = UpdateKeyPage{}.DeliverTx(st, tx)
+			if c.Error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, c.Error)
+			}
+		})
+	}
+}
+
+func TestUpdateKeyPage_RSAKey(t *testing.T) {
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	fooKey, testKey := generateKey(), generateKey()
+	dbtx := db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbtx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page0", testKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.CreateKeyBook(dbtx, "foo/book", "foo/page0"))
+	_, err := dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	rsaKey := func(bits int) []byte {
+		key, err := rsa.GenerateKey(crand.Reader, bits)
+		require.NoError(t, err)
+		return x509.MarshalPKCS1PublicKey(&key.PublicKey)
+	}
+	smallKey, goodKey := rsaKey(1024), rsaKey(2048)
+	smallKeyHash := sha256.Sum256(smallKey)
+
+	cases := map[string]struct {
+		Operation protocol.KeyPageOperation
+		Key       []byte
+		Error     string
+	}{
+		"Add":          {protocol.AddKey, goodKey, ""},
+		"Add small":    {protocol.AddKey, smallKey, "invalid key: RSA key is 1024 bits, must be between 2048 and 4096"},
+		"Add hash":     {protocol.AddKey, smallKeyHash[:], ""},
+		"Update":       {protocol.UpdateKey, goodKey, ""},
+		"Update small": {protocol.UpdateKey, smallKey, "invalid key: RSA key is 1024 bits, must be between 2048 and 4096"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := new(protocol.UpdateKeyPage)
+			body.Operation = c.Operation
+			body.NewKey = c.Key
+			body.KeyAlgorithm = protocol.RSA
+			if c.Operation == protocol.UpdateKey {
+				body.Key = testKey.PubKey().Bytes()
+			}
+
+			tx, err := transactions.New("foo/page0", 1, edSigner(testKey, 1), body)
+			require.NoError(t, err)
+
+			st, err := NewStateManager(db.Begin(), tx)
+			require.NoError(t, err)
+
+			err = UpdateKeyPage{}.DeliverTx(st, tx)
+			if c.Error == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, c.Error)
+			}
+		})
+	}
+}
+
+func TestUpdateKeyPage_KeyExpiration(t *testing.T) {
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	fooKey, testKey, otherKey := generateKey(), generateKey(), generateKey()
+	expiring := protocol.NewKeyPage()
+	expiring.ChainUrl = "acc://foo/page1"
+	expiring.Keys = []*protocol.KeySpec{{PublicKey: testKey.PubKey().Bytes()}, {PublicKey: otherKey.PubKey().Bytes(), ExpirationHeight: 30}}
+	multisig := protocol.NewKeyPage()
+	multisig.ChainUrl = "acc://foo/page2"
+	multisig.Keys = []*protocol.KeySpec{{PublicKey: testKey.PubKey().Bytes()}, {PublicKey: otherKey.PubKey().Bytes()}}
+	multisig.Threshold = 2
+
+	dbtx := db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbtx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateKeyPage(dbtx, "foo/page0", testKey.PubKey().Bytes(), otherKey.PubKey().Bytes()))
+	require.NoError(t, acctesting.WriteStates(dbtx, expiring, multisig))
+	require.NoError(t, acctesting.CreateKeyBook(dbtx, "foo/book", "foo/page0", "foo/page1", "foo/page2"))
+	_, err := dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		Page   string
+		Height uint64
+		Error  string
+	}{
+		"Clear":               {"foo/page0", 0, ""},
+		"Past":                {"foo/page0", 5, "expiration height 5 is not after the current height 10"},
+		"Future":              {"foo/page0", 20, ""},
+		"Other key expires":   {"foo/page1", 20, "cannot expire a key: the page would have fewer unexpired keys than its threshold of 1"},
+		"Below the threshold": {"foo/page2", 20, "cannot expire a key: the page would have fewer unexpired keys than its threshold of 2"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := new(protocol.UpdateKeyPage)
+			body.Operation = protocol.SetKeyExpiration
+			body.Key = testKey.PubKey().Bytes()
+			body.ExpirationHeight = c.Height
+
+			tx, err := transactions.New(c.Page, 1, edSigner(testKey, 1), body)
+			require.NoError(t, err)
+
+			st, err := NewStateManager(db.Begin(), tx)
+			require.NoError(t, err)
+			st.BlockHeight = 10
+
+			err = UpdateKeyPage{}.DeliverTx(st, tx)
+			if c.Error != "" {
+				require.EqualError(t, err, c.Error)
+				return
+			}
+			require.NoError(t, err)
+
+			page := st.Origin.(*protocol.KeyPage)
+			require.Equal(t, c.Height, page.Keys[0].ExpirationHeight)
+			require.Equal(t, c.Height != 0, page.Keys[0].IsExpired(c.Height))
+		})
+	}
+}
+
+func TestUpdateKeyPage_Lock(t *testing.T) {
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	fooKey, testKey, newKey := generateKey(), generateKey(), generateKey()
+	page := protocol.NewKeyPage()
+	page.ChainUrl = "acc://foo/page0"
+	page.Keys = []*protocol.KeySpec{{PublicKey: testKey.PubKey().Bytes()}}
+	page.LockHeight = 20
+
+	dbtx := db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbtx, fooKey, "foo"))
+	require.NoError(t, acctesting.WriteStates(dbtx, page))
+	require.NoError(t, acctesting.CreateKeyBook(dbtx, "foo/book", "foo/page0"))
+	_, err := dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		Height    uint64
+		Operation protocol.KeyPageOperation
+		Blocks    uint64
+		Error     string
+	}{
+		"Locked":        {10, protocol.AddKey, 0, `cannot modify "acc://foo/page0": it is locked until height 20`},
+		"Unlocked":      {20, protocol.AddKey, 0, ""},
+		"Extend lock":   {10, protocol.SetLock, 15, ""},
+		"Shorten lock":  {10, protocol.SetLock, 5, `cannot shorten the lock of "acc://foo/page0": it is locked until height 20`},
+		"Empty lock":    {20, protocol.SetLock, 0, "a lock must last at least one block"},
+		"Lock unlocked": {30, protocol.SetLock, 5, ""},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := new(protocol.UpdateKeyPage)
+			body.Operation = c.Operation
+			body.NewKey = newKey.PubKey().Bytes()
+			body.LockBlocks = c.Blocks
+
+			tx, err := transactions.New("foo/page0", 1, edSigner(testKey, 1), body)
+			require.NoError(t, err)
+
+			st, err := NewStateManager(db.Begin(), tx)
+			require.NoError(t, err)
+			st.BlockHeight = c.Height
+
+			err = UpdateKeyPage{}.DeliverTx(st, tx)
+			if c.Error != "" {
+				require.EqualError(t, err, c.Error)
+				return
+			}
+			require.NoError(t, err)
+
+			if c.Operation == protocol.SetLock {
+				require.Equal(t, c.Height+c.Blocks, st.Origin.(*protocol.KeyPage).LockHeight)
+			}
+		})
+	}
+}
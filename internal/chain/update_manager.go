@@ -0,0 +1,59 @@
+package chain
+
+import (
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+)
+
+type UpdateManager struct{}
+
+func (UpdateManager) Type() types.TxType { return types.TxTypeUpdateManager }
+
+func (UpdateManager) Validate(st *StateManager, tx *transactions.GenTransaction) error {
+	body := new(protocol.UpdateManager)
+	err := tx.As(body)
+	if err != nil {
+		return fmt.Errorf("invalid payload: %v", err)
+	}
+
+	// The transaction has already been authorized by the account's key book
+	// and by its current manager, if it has one
+	account, ok := st.Origin.(*protocol.DataAccount)
+	if !ok {
+		return fmt.Errorf("invalid origin record: want chain type %v, got %v", types.ChainTypeDataAccount, st.Origin.Header().Type)
+	}
+
+	// An empty URL removes the manager
+	if body.ManagerKeyBookUrl == "" {
+		if account.ManagerKeyBook == "" {
+			return fmt.Errorf("%q does not have a manager", st.OriginUrl)
+		}
+		account.ManagerKeyBook = ""
+		st.Update(account)
+		return nil
+	}
+
+	u, err := validateManagerKeyBook(st, body.ManagerKeyBookUrl, account.KeyBook)
+	if err != nil {
+		return err
+	}
+
+	if account.ManagerKeyBook == types.String(u.String()) {
+		return fmt.Errorf("%q is already managed by %q", st.OriginUrl, u)
+	}
+
+	account.ManagerKeyBook = types.String(u.String())
+	st.Update(account)
+	return nil
+}
+
+func (UpdateManager) CheckTx(st *StateManager, tx *transactions.GenTransaction) error {
+	return UpdateManager{}.Validate(st, tx)
+}
+
+func (UpdateManager) DeliverTx(st *StateManager, tx *transactions.GenTransaction) error {
+	return UpdateManager{}.Validate(st, tx)
+}
@@ -0,0 +1,81 @@
+package chain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AccumulateNetwork/accumulate/config"
+	. "github.com/AccumulateNetwork/accumulate/internal/chain"
+	acctesting "github.com/AccumulateNetwork/accumulate/internal/testing"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/AccumulateNetwork/accumulate/types/state"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateManager(t *testing.T) {
+	db := new(state.StateDB)
+	require.NoError(t, db.Open("mem", true, true, nil))
+
+	// foo and baz are routed to BVN1, bar is routed to BVN0
+	network := &config.Network{ID: "BVN1", BvnNames: []string{"BVN0", "BVN1"}}
+
+	fooKey, barKey, bazKey := generateKey(), generateKey(), generateKey()
+	dbtx := db.Begin()
+	require.NoError(t, acctesting.CreateADI(dbtx, fooKey, "foo"))
+	require.NoError(t, acctesting.CreateADI(dbtx, barKey, "bar"))
+	require.NoError(t, acctesting.CreateADI(dbtx, bazKey, "baz"))
+
+	for _, s := range []string{"foo/data", "foo/managed"} {
+		account := protocol.NewDataAccount()
+		account.ChainUrl = types.String("acc://" + s)
+		account.KeyBook = types.Bytes(acctesting.MustParseUrl("foo/book0").ResourceChain()).AsBytes32()
+		if s == "foo/managed" {
+			account.ManagerKeyBook = "acc://baz/book0"
+		}
+		require.NoError(t, acctesting.WriteStates(dbtx, account))
+	}
+	_, err := dbtx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		Origin  string
+		Manager string
+		Result  types.String
+		Error   string
+	}{
+		"Set":          {"foo/data", "baz/book0", "acc://baz/book0", ""},
+		"Not a book":   {"foo/managed", "foo/page0", "", `invalid manager key book "acc://foo/page0": want *protocol.KeyBook, got *protocol.KeyPage`},
+		"Same manager": {"foo/managed", "baz/book0", "", `"acc://foo/managed" is already managed by "acc://baz/book0"`},
+		"Own book":     {"foo/data", "foo/book0", "", `"acc://foo/book0" cannot manage an account assigned to it`},
+		"Other subnet": {"foo/data", "bar/book0", "", `"acc://bar/book0" cannot manage "acc://foo/data": it is not in the same subnet`},
+		"Remove":       {"foo/managed", "", "", ""},
+		"Remove none":  {"foo/data", "", "", `"acc://foo/data" does not have a manager`},
+		"Not data":     {"foo", "baz/book0", "", `invalid origin record: want chain type dataAccount, got identity`},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			body := new(protocol.UpdateManager)
+			body.ManagerKeyBookUrl = c.Manager
+
+			tx, err := transactions.New(c.Origin, 1, edSigner(fooKey, 1), body)
+			require.NoError(t, err)
+
+			st, err := NewStateManager(db.Begin(), tx)
+			require.NoError(t, err)
+			st.Network = network
+
+			err = UpdateManager{}.DeliverTx(st, tx)
+			if c.Error != "" {
+				require.EqualError(t, err, c.Error)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.Result, st.Origin.Header().ManagerKeyBook)
+
+			// Do not store state changes
+		})
+	}
+}
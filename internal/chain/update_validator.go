@@ -0,0 +1,119 @@
+package chain
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/config"
+	"github.com/AccumulateNetwork/accumulate/internal/url"
+	"github.com/AccumulateNetwork/accumulate/protocol"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+)
+
+type UpdateValidator struct {
+	Network *config.Network
+}
+
+func (UpdateValidator) Type() types.TxType { return types.TxTypeUpdateValidator }
+
+func (x UpdateValidator) Validate(st *StateManager, tx *transactions.GenTransaction) error {
+	body := new(protocol.UpdateValidator)
+	err := tx.As(body)
+	if err != nil {
+		return fmt.Errorf("invalid payload: %v", err)
+	}
+
+	// The transaction has already been authorized by the validators key book,
+	// which is the key book of the subnet's ADI
+	nodeUrl := x.Network.NodeUrl()
+	if !st.OriginUrl.Equal(nodeUrl) {
+		return fmt.Errorf("invalid origin record: %q != %q", st.OriginUrl, nodeUrl)
+	}
+
+	if len(body.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid public key: want %d bytes, got %d", ed25519.PublicKeySize, len(body.PublicKey))
+	}
+	if body.Power < 0 {
+		return fmt.Errorf("the power of a validator cannot be negative")
+	}
+
+	// The validators key page must match the validator set
+	pageUrl := nodeUrl.JoinPath("validators0")
+	page := new(protocol.KeyPage)
+	err = st.LoadUrlAs(pageUrl, page)
+	if err != nil {
+		return fmt.Errorf("failed to load %v: %v", pageUrl, err)
+	}
+
+	index := -1
+	for i, key := range page.Keys {
+		if bytes.Equal(key.PublicKey, body.PublicKey) {
+			index = i
+			break
+		}
+	}
+
+	// Changing the page changes its height, so reset all the nonces
+	if body.Power == 0 || index < 0 {
+		for _, key := range page.Keys {
+			key.Nonce = 0
+		}
+	}
+
+	switch {
+	case body.Power > 0 && index < 0:
+		// Add a validator
+		page.Keys = append(page.Keys, &protocol.KeySpec{PublicKey: body.PublicKey})
+		page.Threshold = protocol.ValidatorThreshold(len(page.Keys))
+		st.Update(page)
+
+	case body.Power > 0:
+		// Change the power of a validator, the page is not changed
+
+	case index < 0:
+		return fmt.Errorf("%X is not a validator", body.PublicKey)
+
+	default:
+		// Remove a validator
+		page.Keys = append(page.Keys[:index], page.Keys[index+1:]...)
+
+		if len(page.Keys) == 0 {
+			return fmt.Errorf("cannot remove the last validator")
+		}
+
+		page.Threshold = protocol.ValidatorThreshold(len(page.Keys))
+		st.Update(page)
+	}
+
+	st.UpdateValidator(body.PublicKey, body.Power)
+	return nil
+}
+
+// isValidatorRecord returns true if the URL is the validator key book or key
+// page of a subnet. These can only be changed with UpdateValidator, so that
+// the validator key page always matches the validator set.
+func isValidatorRecord(u *url.URL) bool {
+	var subnet *url.URL
+	if protocol.IsDnUrl(u) {
+		subnet = protocol.DnUrl()
+	} else if id, ok := protocol.ParseBvnUrl(u); ok {
+		subnet = protocol.BvnUrl(id)
+	} else {
+		return false
+	}
+
+	return u.Equal(subnet.JoinPath("validators")) || u.Equal(subnet.JoinPath("validators0"))
+}
+
+// isSubnetRecord returns true if the URL belongs to the ADI of a subnet. The
+// system transactions of a subnet are authorized by the key book of its ADI,
+// so the ADI and its records must stay assigned to the validators.
+func isSubnetRecord(u *url.URL) bool {
+	if protocol.IsDnUrl(u) {
+		return true
+	}
+	_, ok := protocol.ParseBvnUrl(u)
+	return ok
+}
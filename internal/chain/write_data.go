@@ -19,7 +19,8 @@ func (WriteData) Validate(st *StateManager, tx *transactions.GenTransaction) err
 		return fmt.Errorf("invalid payload: %v", err)
 	}
 
-	if st.Origin.Header().Type != types.ChainTypeDataAccount {
+	account, ok := st.Origin.(*protocol.DataAccount)
+	if !ok {
 		return fmt.Errorf("invalid origin record: want %v, got %v",
 			types.ChainTypeDataAccount, st.Origin.Header().Type)
 	}
@@ -60,5 +61,10 @@ func (WriteData) Validate(st *StateManager, tx *transactions.GenTransaction) err
 
 	st.UpdateData(st.Origin, sw.EntryHash[:], dataPayload)
 
+	//the bodies of scratch entries are pruned after the retention window
+	if account.Scratch {
+		return addScratchEntry(st, st.OriginChainId, sw.EntryHash)
+	}
+
 	return nil
 }
@@ -1,9 +1,9 @@
 package chain
 
 import (
-	"errors"
 	"fmt"
 
+	"github.com/AccumulateNetwork/accumulate/internal/url"
 	"github.com/AccumulateNetwork/accumulate/protocol"
 	"github.com/AccumulateNetwork/accumulate/types"
 	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
@@ -20,5 +20,29 @@ func (WriteDataTo) Validate(st *StateManager, tx *transactions.GenTransaction) e
 		return fmt.Errorf("invalid payload: %v", err)
 	}
 
-	return errors.New("not implemented") // TODO
+	recipient, err := url.Parse(body.Recipient)
+	if err != nil {
+		return fmt.Errorf("invalid recipient URL: %v", err)
+	}
+
+	chainId, err := protocol.ParseLiteDataAddress(recipient)
+	if err != nil {
+		return fmt.Errorf("invalid lite data account URL: %v", err)
+	}
+	if chainId == nil {
+		return fmt.Errorf("%q is not a lite data account", recipient)
+	}
+
+	//check will return error if there is too much data or no data for the entry
+	_, err = body.Entry.CheckSize()
+	if err != nil {
+		return err
+	}
+
+	writeData := new(protocol.SyntheticWriteData)
+	copy(writeData.Cause[:], tx.TransactionHash())
+	writeData.Entry = body.Entry
+	st.Submit(recipient, writeData)
+
+	return nil
 }
@@ -1,7 +1,10 @@
 package genesis
 
 import (
+	"encoding/binary"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/AccumulateNetwork/accumulate/config"
@@ -19,6 +22,16 @@ type InitOpts struct {
 	NetworkType config.NetworkType
 	Validators  []tmtypes.GenesisValidator
 	GenesisTime time.Time
+
+	// PendingExpiration is the number of blocks a transaction may wait for
+	// signatures before it expires. Zero disables expiration.
+	PendingExpiration uint64
+
+	// Subnets are the validators of the other subnets of the network, keyed by
+	// subnet ID. Their validator books are recorded so that synthetic
+	// transactions from those subnets can be verified before their ADIs have
+	// been mirrored.
+	Subnets map[string][]tmtypes.GenesisValidator
 }
 
 func mustParseUrl(s string) *url.URL {
@@ -36,9 +49,13 @@ func Init(kvdb storage.KeyValueDB, opts InitOpts) ([]byte, error) {
 		return nil, err
 	}
 
-	_ = kvdb.Put(storage.MakeKey("SubnetID"), []byte(opts.SubnetID))
+	db.WriteParameter("SubnetID", []byte(opts.SubnetID))
 
-	exec, err := chain.NewGenesisExecutor(db, opts.NetworkType)
+	var expiration [8]byte
+	binary.BigEndian.PutUint64(expiration[:], opts.PendingExpiration)
+	db.WriteParameter("PendingExpiration", expiration[:])
+
+	exec, err := chain.NewGenesisExecutor(db, opts.NetworkType, opts.SubnetID)
 	if err != nil {
 		return nil, err
 	}
@@ -68,32 +85,78 @@ func Init(kvdb storage.KeyValueDB, opts InitOpts) ([]byte, error) {
 			lite.ChainUrl = types.String(protocol.FaucetWallet.Addr)
 			lite.TokenUrl = protocol.AcmeUrl().String()
 			lite.Balance.SetString("314159265358979323846264338327950288419716939937510582097494459", 10)
+			lite.CreditBalance.SetUint64(protocol.FaucetCredits)
 			st.Update(lite)
 		}
 
 		// Create the ADI
-		uBook := uAdi.JoinPath("validators")
-		uPage := uAdi.JoinPath("validators0")
+		adi, book, page := validatorRecords(uAdi, opts.Validators)
+		uBook, uPage := uAdi.JoinPath("validators"), uAdi.JoinPath("validators0")
+
+		// The oracle of the DN is set by its key book, and BVNs receive the
+		// price from the DN's anchors
+		uOracle := uAdi.JoinPath(protocol.Oracle)
+		oracle := protocol.NewAcmeOracle()
+		oracle.ChainUrl = types.String(uOracle.String())
+		oracle.KeyBook = uBook.ResourceChain32()
+		oracle.Price = protocol.InitialAcmeOracleValue
+
+		st.Update(adi, book, page, oracle)
+
+		// Record the validators of the other subnets
+		ids := make([]string, 0, len(opts.Subnets))
+		for id := range opts.Subnets {
+			if !strings.EqualFold(id, opts.SubnetID) {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			u := protocol.BvnUrl(id)
+			if strings.EqualFold(id, protocol.Directory) {
+				u = protocol.DnUrl()
+			}
+			st.Update(validatorRecords(u, opts.Subnets[id]))
+		}
+
+		if opts.NetworkType != config.Directory {
+			return st.AddDirectoryEntry(uAdi, uBook, uPage, uOracle)
+		}
 
-		adi := state.NewIdentityState(types.String(uAdi.String()))
-		adi.KeyBook = uBook.ResourceChain32()
+		// The DN records the anchors of its major blocks to external ledgers
+		uPool := uAdi.JoinPath(protocol.ExternalAnchorPool)
+		pool := protocol.NewExternalAnchor()
+		pool.ChainUrl = types.String(uPool.String())
+		pool.KeyBook = uBook.ResourceChain32()
 
-		book := protocol.NewKeyBook()
-		book.ChainUrl = types.String(uBook.String())
-		book.Pages = [][32]byte{uPage.ResourceChain32()}
+		st.Update(pool)
+		return st.AddDirectoryEntry(uAdi, uBook, uPage, uOracle, uPool)
+	})
+}
 
-		page := protocol.NewKeyPage()
-		page.ChainUrl = types.String(uPage.String())
-		page.KeyBook = uBook.ResourceChain32()
+// validatorRecords creates the ADI of a subnet and its validator key book and
+// key page.
+func validatorRecords(uAdi *url.URL, validators []tmtypes.GenesisValidator) (*state.AdiState, *protocol.KeyBook, *protocol.KeyPage) {
+	uBook := uAdi.JoinPath("validators")
+	uPage := uAdi.JoinPath("validators0")
 
-		page.Keys = make([]*protocol.KeySpec, len(opts.Validators))
-		for i, val := range opts.Validators {
-			spec := new(protocol.KeySpec)
-			spec.PublicKey = val.PubKey.Bytes()
-			page.Keys[i] = spec
-		}
+	adi := state.NewIdentityState(types.String(uAdi.String()))
+	adi.KeyBook = uBook.ResourceChain32()
 
-		st.Update(adi, book, page)
-		return st.AddDirectoryEntry(uAdi, uBook, uPage)
-	})
+	book := protocol.NewKeyBook()
+	book.ChainUrl = types.String(uBook.String())
+	book.Pages = [][32]byte{uPage.ResourceChain32()}
+
+	page := protocol.NewKeyPage()
+	page.ChainUrl = types.String(uPage.String())
+	page.KeyBook = uBook.ResourceChain32()
+
+	page.Keys = make([]*protocol.KeySpec, len(validators))
+	for i, val := range validators {
+		spec := new(protocol.KeySpec)
+		spec.PublicKey = val.PubKey.Bytes()
+		page.Keys[i] = spec
+	}
+	page.Threshold = protocol.ValidatorThreshold(len(page.Keys))
+	return adi, book, page
 }
@@ -5,6 +5,7 @@
 package mock_abci
 
 import (
+	io "io"
 	reflect "reflect"
 
 	abci "github.com/AccumulateNetwork/accumulate/internal/abci"
@@ -96,9 +97,11 @@ func (mr *MockChainMockRecorder) DeliverTx(arg0 interface{}) *gomock.Call {
 }
 
 // EndBlock mocks base method.
-func (m *MockChain) EndBlock(arg0 abci.EndBlockRequest) {
+func (m *MockChain) EndBlock(arg0 abci.EndBlockRequest) abci.EndBlockResponse {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "EndBlock", arg0)
+	ret := m.ctrl.Call(m, "EndBlock", arg0)
+	ret0, _ := ret[0].(abci.EndBlockResponse)
+	return ret0
 }
 
 // EndBlock indicates an expected call of EndBlock.
@@ -175,6 +178,20 @@ func (mr *MockStateMockRecorder) BlockIndex() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockIndex", reflect.TypeOf((*MockState)(nil).BlockIndex))
 }
 
+// ReadSnapshot mocks base method.
+func (m *MockState) ReadSnapshot(r io.Reader, rootHash []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSnapshot", r, rootHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReadSnapshot indicates an expected call of ReadSnapshot.
+func (mr *MockStateMockRecorder) ReadSnapshot(r, rootHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSnapshot", reflect.TypeOf((*MockState)(nil).ReadSnapshot), r, rootHash)
+}
+
 // RootHash mocks base method.
 func (m *MockState) RootHash() []byte {
 	m.ctrl.T.Helper()
@@ -203,3 +220,18 @@ func (mr *MockStateMockRecorder) SubnetID() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubnetID", reflect.TypeOf((*MockState)(nil).SubnetID))
 }
+
+// Snapshot mocks base method.
+func (m *MockState) Snapshot() (func(io.Writer) error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Snapshot")
+	ret0, _ := ret[0].(func(io.Writer) error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Snapshot indicates an expected call of Snapshot.
+func (mr *MockStateMockRecorder) Snapshot() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Snapshot", reflect.TypeOf((*MockState)(nil).Snapshot))
+}
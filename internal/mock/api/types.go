@@ -114,6 +114,21 @@ func (mr *MockQuerierMockRecorder) QueryKeyPageIndex(url, key interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryKeyPageIndex", reflect.TypeOf((*MockQuerier)(nil).QueryKeyPageIndex), url, key)
 }
 
+// QueryPendingTransactions mocks base method.
+func (m *MockQuerier) QueryPendingTransactions(url string) (*api.QueryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryPendingTransactions", url)
+	ret0, _ := ret[0].(*api.QueryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryPendingTransactions indicates an expected call of QueryPendingTransactions.
+func (mr *MockQuerierMockRecorder) QueryPendingTransactions(url interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryPendingTransactions", reflect.TypeOf((*MockQuerier)(nil).QueryPendingTransactions), url)
+}
+
 // QueryTx mocks base method.
 func (m *MockQuerier) QueryTx(id []byte, wait time.Duration) (*api.QueryResponse, error) {
 	m.ctrl.T.Helper()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/AccumulateNetwork/accumulate/internal/accumulated"
 	"github.com/AccumulateNetwork/accumulate/internal/api"
 	apiv2 "github.com/AccumulateNetwork/accumulate/internal/api/v2"
+	"github.com/AccumulateNetwork/accumulate/internal/node"
 	"github.com/AccumulateNetwork/accumulate/internal/relay"
 	acctesting "github.com/AccumulateNetwork/accumulate/internal/testing"
 	"github.com/AccumulateNetwork/accumulate/internal/testing/e2e"
@@ -84,8 +86,14 @@ func (d *e2eDUT) WaitForTxns(txids ...[]byte) {
 	})
 
 	for _, txid := range txids {
-		_, err := q.QueryTx(txid, 10*time.Second)
+		r, err := q.QueryTx(txid, 10*time.Second)
 		d.Require().NoError(err)
+
+		// Wait for the synthetic transactions produced by the transaction
+		for _, id := range r.SyntheticTxids {
+			id := id // Do not alias the loop variable
+			d.WaitForTxns(id[:])
+		}
 	}
 }
 
@@ -115,14 +123,30 @@ func TestFaucetMultiNetwork(t *testing.T) {
 
 	const bCount, vCount = 3, 1
 	bvns := make([]string, bCount)
-	ips := acctesting.GetIPs(bCount * vCount)
+	ips := acctesting.GetIPs(bCount*vCount + 1)
+	dnIP, ips := ips[0], ips[1:]
 	for i := range bvns {
 		bvns[i] = ips[i*vCount].String()
 	}
 
-	bvc0 := initNodes(t, "BVC0", ips[0*vCount:1*vCount], 3000, vCount, bvns)
-	bvc1 := initNodes(t, "BVC1", ips[1*vCount:2*vCount], 3000, vCount, bvns)
-	bvc2 := initNodes(t, "BVC2", ips[2*vCount:3*vCount], 3000, vCount, bvns)
+	// Synthetic transactions are only sent once the DN has anchored them
+	dn := prepareNodes(t, protocol.Directory, []net.IP{dnIP}, 3000, 1, bvns)
+	dn.Config[0].Accumulate.Network.Type = config.Directory
+	subnets := []node.InitOptions{dn}
+	for i := range bvns {
+		subnets = append(subnets, prepareNodes(t, fmt.Sprintf("BVN%d", i), ips[i*vCount:(i+1)*vCount], 3000, vCount, bvns))
+	}
+	for _, subnet := range subnets {
+		for _, c := range subnet.Config {
+			c.Accumulate.Network.Addresses[protocol.Directory] = []string{fmt.Sprintf("http://%s:%d", dnIP, 3000)}
+		}
+	}
+	require.NoError(t, node.InitNetwork(subnets))
+
+	runNodes(t, dn.WorkDir, 1)
+	bvc0 := runNodes(t, subnets[1].WorkDir, vCount)
+	bvc1 := runNodes(t, subnets[2].WorkDir, vCount)
+	bvc2 := runNodes(t, subnets[3].WorkDir, vCount)
 	rpcAddrs := make([]string, 0, 3)
 	for _, bvc := range [][]*accumulated.Daemon{bvc0, bvc1, bvc2} {
 		rpcAddrs = append(rpcAddrs, bvc[0].Config.RPC.ListenAddress)
@@ -10,6 +10,7 @@ import (
 	cfg "github.com/AccumulateNetwork/accumulate/config"
 	"github.com/AccumulateNetwork/accumulate/internal/genesis"
 	"github.com/AccumulateNetwork/accumulate/networks"
+	"github.com/AccumulateNetwork/accumulate/protocol"
 	"github.com/AccumulateNetwork/accumulate/smt/storage/memory"
 	tmcfg "github.com/tendermint/tendermint/config"
 	tmlog "github.com/tendermint/tendermint/libs/log"
@@ -28,75 +29,61 @@ type InitOptions struct {
 	Config     []*cfg.Config
 	RemoteIP   []string
 	ListenIP   []string
+
+	// Subnets are the validators of the other subnets of the network, keyed by
+	// subnet ID, which are recorded in the genesis state. See InitNetwork.
+	Subnets map[string][]types.GenesisValidator
 }
 
-// Init creates the initial configuration for a set of nodes, using
-// the given configuration. Config, remoteIP, and opts.ListenIP must all be of equal
-// length.
-func Init(opts InitOptions) (err error) {
+// InitNetwork creates the initial configuration for the nodes of every subnet
+// of a network. The validators of every subnet are recorded in the genesis
+// state of the others, so each subnet can verify the synthetic transactions of
+// the others from the start.
+func InitNetwork(subnets []InitOptions) (err error) {
 	defer func() {
 		if err != nil {
-			_ = os.RemoveAll(opts.WorkDir)
+			for _, opts := range subnets {
+				_ = os.RemoveAll(opts.WorkDir)
+			}
 		}
 	}()
 
-	fmt.Println("Tendermint Initialize")
-
-	config := opts.Config
-	subnetID := config[0].Accumulate.Network.ID
-	genVals := make([]types.GenesisValidator, 0, len(config))
-
-	var networkType cfg.NetworkType
-	for i, config := range config {
-		if i == 0 {
-			networkType = config.Accumulate.Network.Type
-		} else if config.Accumulate.Network.Type != networkType {
-			return errors.New("Cannot initialize multiple networks at once")
-		}
-
-		nodeDirName := fmt.Sprintf("Node%d", i)
-		nodeDir := path.Join(opts.WorkDir, nodeDirName)
-		config.SetRoot(nodeDir)
-
-		config.P2P.ListenAddress = fmt.Sprintf("tcp://%s:%d", opts.ListenIP[i], opts.Port+networks.TmP2pPortOffset)
-		config.RPC.ListenAddress = fmt.Sprintf("tcp://%s:%d", opts.ListenIP[i], opts.Port+networks.TmRpcPortOffset)
-		config.RPC.GRPCListenAddress = fmt.Sprintf("tcp://%s:%d", opts.ListenIP[i], opts.Port+networks.TmRpcGrpcPortOffset)
-		config.Instrumentation.PrometheusListenAddr = fmt.Sprintf(":%d", opts.Port+networks.TmPrometheusPortOffset)
-
-		err = os.MkdirAll(path.Join(nodeDir, "config"), nodeDirPerm)
+	validators := make(map[string][]types.GenesisValidator, len(subnets))
+	for _, opts := range subnets {
+		_, genVals, err := initNodes(opts)
 		if err != nil {
-			return fmt.Errorf("failed to create config dir: %v", err)
+			return err
 		}
+		validators[opts.Config[0].Accumulate.Network.ID] = genVals
+	}
 
-		err = os.MkdirAll(path.Join(nodeDir, "data"), nodeDirPerm)
+	for _, opts := range subnets {
+		opts.Subnets = validators
+		err = Init(opts)
 		if err != nil {
-			return fmt.Errorf("failed to create data dir: %v", err)
-		}
-
-		if err := initFilesWithConfig(config, &subnetID); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		pvKeyFile := path.Join(nodeDir, config.PrivValidator.Key)
-		pvStateFile := path.Join(nodeDir, config.PrivValidator.State)
-		pv, err := privval.LoadFilePV(pvKeyFile, pvStateFile)
+// Init creates the initial configuration for a set of nodes, using
+// the given configuration. Config, remoteIP, and opts.ListenIP must all be of equal
+// length.
+func Init(opts InitOptions) (err error) {
+	defer func() {
 		if err != nil {
-			return fmt.Errorf("failed to load private validator: %v", err)
+			_ = os.RemoveAll(opts.WorkDir)
 		}
+	}()
 
-		pubKey, err := pv.GetPubKey(context.Background())
-		if err != nil {
-			return fmt.Errorf("failed to get public key: %v", err)
-		}
+	fmt.Println("Tendermint Initialize")
 
-		if config.Mode == tmcfg.ModeValidator {
-			genVals = append(genVals, types.GenesisValidator{
-				Address: pubKey.Address(),
-				PubKey:  pubKey,
-				Power:   1,
-				Name:    nodeDirName,
-			})
-		}
+	config := opts.Config
+	subnetID := config[0].Accumulate.Network.ID
+	networkType, genVals, err := initNodes(opts)
+	if err != nil {
+		return err
 	}
 
 	// Generate genesis doc from generated validators
@@ -107,10 +94,12 @@ func Init(opts InitOptions) (err error) {
 		db := new(memory.DB)
 		_ = db.InitDB("", nil)
 		root, err := genesis.Init(db, genesis.InitOpts{
-			SubnetID:    subnetID,
-			NetworkType: config[0].Accumulate.Network.Type,
-			GenesisTime: genTime,
-			Validators:  genVals,
+			SubnetID:          subnetID,
+			NetworkType:       networkType,
+			GenesisTime:       genTime,
+			Validators:        genVals,
+			PendingExpiration: protocol.DefaultPendingExpiration,
+			Subnets:           opts.Subnets,
 		})
 
 		state, _ := db.MarshalJSON()
@@ -191,6 +180,68 @@ func Init(opts InitOptions) (err error) {
 	return nil
 }
 
+// initNodes creates the directories, keys, and default files of a set of nodes,
+// and returns the type of their network and the genesis validators among them.
+// Existing keys are kept.
+func initNodes(opts InitOptions) (networkType cfg.NetworkType, genVals []types.GenesisValidator, err error) {
+	config := opts.Config
+	subnetID := config[0].Accumulate.Network.ID
+	genVals = make([]types.GenesisValidator, 0, len(config))
+	for i, config := range config {
+		if i == 0 {
+			networkType = config.Accumulate.Network.Type
+		} else if config.Accumulate.Network.Type != networkType {
+			return "", nil, errors.New("Cannot initialize multiple networks at once")
+		}
+
+		nodeDirName := fmt.Sprintf("Node%d", i)
+		nodeDir := path.Join(opts.WorkDir, nodeDirName)
+		config.SetRoot(nodeDir)
+
+		config.P2P.ListenAddress = fmt.Sprintf("tcp://%s:%d", opts.ListenIP[i], opts.Port+networks.TmP2pPortOffset)
+		config.RPC.ListenAddress = fmt.Sprintf("tcp://%s:%d", opts.ListenIP[i], opts.Port+networks.TmRpcPortOffset)
+		config.RPC.GRPCListenAddress = fmt.Sprintf("tcp://%s:%d", opts.ListenIP[i], opts.Port+networks.TmRpcGrpcPortOffset)
+		config.Instrumentation.PrometheusListenAddr = fmt.Sprintf(":%d", opts.Port+networks.TmPrometheusPortOffset)
+
+		err = os.MkdirAll(path.Join(nodeDir, "config"), nodeDirPerm)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to create config dir: %v", err)
+		}
+
+		err = os.MkdirAll(path.Join(nodeDir, "data"), nodeDirPerm)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to create data dir: %v", err)
+		}
+
+		if err := initFilesWithConfig(config, &subnetID); err != nil {
+			return "", nil, err
+		}
+
+		pvKeyFile := path.Join(nodeDir, config.PrivValidator.Key)
+		pvStateFile := path.Join(nodeDir, config.PrivValidator.State)
+		pv, err := privval.LoadFilePV(pvKeyFile, pvStateFile)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to load private validator: %v", err)
+		}
+
+		pubKey, err := pv.GetPubKey(context.Background())
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get public key: %v", err)
+		}
+
+		if config.Mode == tmcfg.ModeValidator {
+			genVals = append(genVals, types.GenesisValidator{
+				Address: pubKey.Address(),
+				PubKey:  pubKey,
+				Power:   1,
+				Name:    nodeDirName,
+			})
+		}
+	}
+
+	return networkType, genVals, nil
+}
+
 func initFilesWithConfig(config *cfg.Config, chainid *string) error {
 
 	logger := tmlog.NewNopLogger()
@@ -21,6 +21,15 @@ var reAlphaNum = regexp.MustCompile("[^a-zA-Z0-9]")
 
 func initNodes(t *testing.T, name string, ips []net.IP, basePort int, count int, bvnAddrs []string) []*accumulated.Daemon {
 	t.Helper()
+	opts := prepareNodes(t, name, ips, basePort, count, bvnAddrs)
+	require.NoError(t, node.Init(opts))
+	return runNodes(t, opts.WorkDir, count)
+}
+
+// prepareNodes creates the configuration of the nodes of a subnet and returns
+// the options to initialize them with.
+func prepareNodes(t *testing.T, name string, ips []net.IP, basePort int, count int, bvnAddrs []string) node.InitOptions {
+	t.Helper()
 
 	name = reAlphaNum.ReplaceAllString(name, "-")
 
@@ -51,14 +60,18 @@ func initNodes(t *testing.T, name string, ips []net.IP, basePort int, count int,
 		}
 	}
 
-	workDir := t.TempDir()
-	require.NoError(t, node.Init(node.InitOptions{
-		WorkDir:  workDir,
+	return node.InitOptions{
+		WorkDir:  t.TempDir(),
 		Port:     basePort,
 		Config:   config,
 		RemoteIP: IPs,
 		ListenIP: IPs,
-	}))
+	}
+}
+
+// runNodes runs the nodes of a subnet.
+func runNodes(t *testing.T, workDir string, count int) []*accumulated.Daemon {
+	t.Helper()
 
 	daemons := make([]*accumulated.Daemon, count)
 	for i := range daemons {
@@ -92,9 +92,11 @@ func (c *ABCIApplicationClient) Shutdown() {
 		return
 	}
 
+	// Do not close txCh: a late submission, such as a synthetic transaction
+	// broadcast from a goroutine, may race with the wait group and would panic
+	// sending on a closed channel. Receivers return when stop is closed.
 	close(c.stop)
 	c.stopped.Wait()
-	close(c.txCh)
 }
 
 func (c *ABCIApplicationClient) App() abci.Application {
@@ -330,7 +332,17 @@ func (c *ABCIApplicationClient) execute(interval time.Duration) {
 			}
 		}
 
-		c.app.EndBlock(abci.RequestEndBlock{})
+		// Tendermint rejects updates that change a validator more than once
+		end := c.app.EndBlock(abci.RequestEndBlock{})
+		updated := map[string]bool{}
+		for _, update := range end.ValidatorUpdates {
+			key := update.PubKey.String()
+			if updated[key] {
+				c.onError(fmt.Errorf("duplicate entry %s in validator updates", key))
+			}
+			updated[key] = true
+		}
+
 		c.app.Commit()
 
 		for _, sub := range queue {
@@ -63,7 +63,7 @@ func (s *Suite) newTx(sponsor *url.URL, key tmed25519.PrivKey, nonce uint64, bod
 		URL:           sponsor.String(),
 		KeyPageHeight: s.dut.GetRecordHeight(sponsor.String()),
 		Nonce:         nonce,
-	}, func(hash []byte) (*transactions.ED25519Sig, error) {
+	}, func(hash []byte) (transactions.Signature, error) {
 		sig := new(transactions.ED25519Sig)
 		return sig, sig.Sign(nonce, key, hash)
 	}, body)
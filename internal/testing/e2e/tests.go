@@ -2,10 +2,12 @@ package e2e
 
 import (
 	"testing"
+	"time"
 
 	acctesting "github.com/AccumulateNetwork/accumulate/internal/testing"
 	"github.com/AccumulateNetwork/accumulate/internal/url"
 	"github.com/AccumulateNetwork/accumulate/protocol"
+	tmed25519 "github.com/tendermint/tendermint/crypto/ed25519"
 )
 
 func (s *Suite) TestGenesis() {
@@ -23,14 +25,25 @@ func (s *Suite) TestCreateLiteAccount() {
 	senderUrl, err := protocol.LiteAddress(sender.PubKey().Bytes(), protocol.ACME)
 	s.Require().NoError(err)
 
-	tx, err := acctesting.CreateFakeSyntheticDepositTx(sender)
-	s.Require().NoError(err)
+	// Fund the account from the faucet
+	faucet := new(protocol.AcmeFaucet)
+	faucet.Url = senderUrl.String()
+	faucetKey := tmed25519.PrivKey(protocol.FaucetWallet.PrivateKey)
+	tx := s.newTx(protocol.FaucetUrl, faucetKey, uint64(time.Now().UnixNano()), faucet)
 	s.dut.SubmitTxn(tx)
 	s.dut.WaitForTxns(tx.TransactionHash())
 
 	account := new(protocol.LiteTokenAccount)
 	s.dut.GetRecordAs(senderUrl.String(), account)
-	s.Require().Equal(int64(5e4*acctesting.TokenMx), account.Balance.Int64())
+	s.Require().Equal(int64(10*acctesting.TokenMx), account.Balance.Int64())
+
+	// Convert 1 ACME into credits to pay for the transactions
+	credits := new(protocol.AddCredits)
+	credits.Recipient = senderUrl.String()
+	credits.Amount = protocol.CreditsPerFiatUnit * protocol.InitialAcmeOracleValue / protocol.AcmeOraclePrecision
+	tx = s.newTx(senderUrl, sender, 1, credits)
+	s.dut.SubmitTxn(tx)
+	s.dut.WaitForTxns(tx.TransactionHash())
 
 	recipients := make([]*url.URL, 10)
 	for i := range recipients {
@@ -57,7 +70,7 @@ func (s *Suite) TestCreateLiteAccount() {
 			total += 1000
 		}
 
-		tx := s.newTx(senderUrl, sender, uint64(i+1), exch)
+		tx := s.newTx(senderUrl, sender, uint64(i+2), exch)
 		s.dut.SubmitTxn(tx)
 		txids = append(txids, tx.TransactionHash())
 	}
@@ -66,5 +79,5 @@ func (s *Suite) TestCreateLiteAccount() {
 
 	account = new(protocol.LiteTokenAccount)
 	s.dut.GetRecordAs(senderUrl.String(), account)
-	s.Require().Equal(int64(5e4*acctesting.TokenMx-total), account.Balance.Int64())
+	s.Require().Equal(int64(10*acctesting.TokenMx-acctesting.TokenMx-total), account.Balance.Int64())
 }
@@ -26,6 +26,10 @@ type DB interface {
 // Token multiplier
 const TokenMx = protocol.AcmePrecision
 
+// TestCredits is the credit balance given to test key pages and lite accounts,
+// so they can pay transaction fees
+const TestCredits = 1e9
+
 func CreateFakeSyntheticDepositTx(recipient tmed25519.PrivKey) (*transactions.GenTransaction, error) {
 	recipientAdi := types.String(AcmeLiteAddressTmPriv(recipient).String())
 
@@ -103,6 +107,7 @@ func CreateADI(db DB, key tmed25519.PrivKey, urlStr types.String) error {
 	mss := protocol.NewKeyPage()
 	mss.ChainUrl = types.String(pageUrl.String())
 	mss.Keys = append(mss.Keys, ss)
+	mss.CreditBalance.SetInt64(TestCredits)
 
 	book := protocol.NewKeyBook()
 	book.ChainUrl = types.String(bookUrl.String()) // TODO Allow override
@@ -128,6 +133,7 @@ func CreateTokenAccount(db DB, accUrl, tokenUrl string, tokens float64, lite boo
 		account.ChainUrl = types.String(u.String())
 		account.TokenUrl = tokenUrl
 		account.Balance.SetInt64(int64(tokens * TokenMx))
+		account.CreditBalance.SetInt64(TestCredits)
 		account.TxCount++
 		chain = account
 	} else {
@@ -147,6 +153,21 @@ func CreateTokenAccount(db DB, accUrl, tokenUrl string, tokens float64, lite boo
 	return nil
 }
 
+func CreateTokenIssuer(db DB, urlStr, symbol string, precision uint64) error {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return err
+	}
+
+	issuer := protocol.NewTokenIssuer()
+	issuer.ChainUrl = types.String(u.String())
+	issuer.KeyBook = types.Bytes(u.Identity().JoinPath("book0").ResourceChain()).AsBytes32() // assume the book is adi/book0
+	issuer.Symbol = symbol
+	issuer.Precision = precision
+
+	return WriteStates(db, issuer)
+}
+
 func CreateKeyPage(db DB, urlStr types.String, keys ...tmed25519.PubKey) error {
 	u, err := url.Parse(*urlStr.AsString())
 	if err != nil {
@@ -155,6 +176,7 @@ func CreateKeyPage(db DB, urlStr types.String, keys ...tmed25519.PubKey) error {
 
 	mss := protocol.NewKeyPage()
 	mss.ChainUrl = types.String(u.String())
+	mss.CreditBalance.SetInt64(TestCredits)
 	mss.Keys = make([]*protocol.KeySpec, len(keys))
 	for i, key := range keys {
 		mss.Keys[i] = &protocol.KeySpec{
@@ -71,3 +71,46 @@ func (acct *LiteTokenAccount) NextTx() uint64 {
 func (acct *LiteTokenAccount) ParseTokenUrl() (*url.URL, error) {
 	return url.Parse(acct.TokenUrl)
 }
+
+func (issuer *TokenIssuer) CanIssue(amount *big.Int) bool {
+	if amount == nil || amount.Sign() <= 0 {
+		return false
+	}
+
+	// A supply limit of zero means the supply is unlimited
+	if issuer.SupplyLimit.Sign() == 0 {
+		return true
+	}
+
+	issued := new(big.Int).Add(&issuer.Issued, amount)
+	return issued.Cmp(&issuer.SupplyLimit) <= 0
+}
+
+func (issuer *TokenIssuer) Issue(amount *big.Int) bool {
+	if !issuer.CanIssue(amount) {
+		return false
+	}
+
+	issuer.Issued.Add(&issuer.Issued, amount)
+	return true
+}
+
+func (issuer *TokenIssuer) Burn(amount *big.Int) bool {
+	if amount == nil || amount.Sign() <= 0 || amount.Cmp(&issuer.Issued) > 0 {
+		return false
+	}
+
+	issuer.Issued.Sub(&issuer.Issued, amount)
+	issuer.Burned.Add(&issuer.Burned, amount)
+	return true
+}
+
+// Unissue reverses the issuance of tokens that could not be deposited.
+func (issuer *TokenIssuer) Unissue(amount *big.Int) bool {
+	if amount == nil || amount.Sign() <= 0 || amount.Cmp(&issuer.Issued) > 0 {
+		return false
+	}
+
+	issuer.Issued.Sub(&issuer.Issued, amount)
+	return true
+}
@@ -33,6 +33,10 @@ func NewChain(typ types.ChainType) (state.Chain, error) {
 		return new(LiteDataAccount), nil
 	case types.ChainTypeSyntheticTransactions:
 		return new(state.SyntheticTransactionChain), nil
+	case types.ChainTypeAcmeOracle:
+		return new(AcmeOracle), nil
+	case types.ChainTypeExternalAnchor:
+		return new(ExternalAnchor), nil
 	default:
 		return nil, fmt.Errorf("unknown chain type %v", typ)
 	}
@@ -50,6 +50,8 @@ const (
 	CodeDataUrlError ErrorCode = 24
 	//CodeDataEntryHashError is returned when an entry hash query fails on a data chain
 	CodeDataEntryHashError ErrorCode = 24
+	//CodeInsufficientCredits is returned when the signator cannot pay the fee for a txn
+	CodeInsufficientCredits ErrorCode = 25
 )
 
 type Error struct {
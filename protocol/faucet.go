@@ -11,6 +11,12 @@ import (
 var FaucetWallet transactions.WalletEntry
 var FaucetUrl *url.URL
 
+// FaucetCredits is the credit balance of the faucet at genesis. Faucet
+// transactions are free, so the credits only pay for other transactions signed
+// by the faucet. The faucet key is not secret, so the balance is bounded to
+// limit what anyone can spend with it.
+const FaucetCredits = 1e6
+
 // TODO Set the balance to 0 and/or use a bogus URL for the faucet. Otherwise, a
 // bad actor could generate the faucet private key using the same method we do,
 // then sign arbitrary transactions using the faucet.
@@ -59,11 +59,31 @@ const (
 	// FeeUpdateKeyPage $0.03
 	FeeUpdateKeyPage Fee = 300
 
+	// FeeUpdateKeyBook $0.03
+	FeeUpdateKeyBook Fee = 300
+
+	// FeeUpdateAccountAuth $0.03
+	FeeUpdateAccountAuth Fee = 300
+
+	// FeeUpdateManager $0.03
+	FeeUpdateManager Fee = 300
+
+	// FeeTransferCredits $0.03
+	FeeTransferCredits Fee = 300
+
 	// FeeCreateScratchChain $0.25
 	FeeCreateScratchChain Fee = 2500
 
 	//FeeWriteScratchData $0.0001 / 256 bytes
 	FeeWriteScratchData Fee = 1
+
+	// FeeFailedMaximum $0.01 is the most a failed transaction is charged
+	FeeFailedMaximum Fee = 100
+
+	// FeePendingSubmission $0.01 is charged for each submission of a
+	// transaction that has not been signed by enough keys. It is credited
+	// against the fee of the transaction.
+	FeePendingSubmission Fee = 100
 )
 
 func ComputeFee(tx *transactions.GenTransaction) (int, error) {
@@ -71,6 +91,10 @@ func ComputeFee(tx *transactions.GenTransaction) (int, error) {
 	if n == 0 {
 		return 0, fmt.Errorf("cannot compute fee with no data defined for transaction")
 	}
+	if types.TransactionType(txType).IsSystem() {
+		// System transactions are sent by the validators
+		return 0, nil
+	}
 	switch types.TransactionType(txType) {
 	case types.TxTypeCreateIdentity:
 		return FeeCreateIdentity.AsInt(), nil
@@ -79,16 +103,17 @@ func ComputeFee(tx *transactions.GenTransaction) (int, error) {
 	case types.TxTypeSendTokens:
 		return FeeSendTokens.AsInt(), nil
 	case types.TxTypeCreateDataAccount:
-		return FeeCreateDataAccount.AsInt(), nil
-	case types.TxTypeWriteData:
-		size := len(tx.Transaction) - n
-		if size > WriteDataMax {
-			return 0, fmt.Errorf("data amount exceeds %v byte entry limit", WriteDataMax)
+		body := new(CreateDataAccount)
+		err := body.UnmarshalBinary(tx.Transaction)
+		if err != nil {
+			return 0, fmt.Errorf("invalid payload: %v", err)
 		}
-		if size <= 0 {
-			return 0, fmt.Errorf("insufficient data provided for %v needed to compute cost", txType)
+		if body.Scratch {
+			return FeeCreateScratchChain.AsInt(), nil
 		}
-		return FeeWriteData.AsInt() * (size/256 + 1), nil
+		return FeeCreateDataAccount.AsInt(), nil
+	case types.TxTypeWriteData:
+		return computeWriteDataFee(tx, FeeWriteData)
 	case types.TxTypeWriteDataTo:
 		return FeeWriteDataTo.AsInt(), nil
 	case types.TxTypeAcmeFaucet:
@@ -107,8 +132,40 @@ func ComputeFee(tx *transactions.GenTransaction) (int, error) {
 		return FeeAddCredits.AsInt(), nil
 	case types.TxTypeUpdateKeyPage:
 		return FeeUpdateKeyPage.AsInt(), nil
+	case types.TxTypeUpdateKeyBook:
+		return FeeUpdateKeyBook.AsInt(), nil
+	case types.TxTypeUpdateAccountAuth:
+		return FeeUpdateAccountAuth.AsInt(), nil
+	case types.TxTypeUpdateManager:
+		return FeeUpdateManager.AsInt(), nil
+	case types.TxTypeTransferCredits:
+		return FeeTransferCredits.AsInt(), nil
 	default:
 		//by default assume if type isn't specified, there is no charge for tx
 		return 0, nil
 	}
 }
+
+// ComputeScratchFee computes the fee of a transaction whose origin is a scratch
+// data account. Entries written to a scratch data account are charged the
+// scratch rate.
+func ComputeScratchFee(tx *transactions.GenTransaction) (int, error) {
+	if tx.TransactionType() != types.TxTypeWriteData {
+		return ComputeFee(tx)
+	}
+	return computeWriteDataFee(tx, FeeWriteScratchData)
+}
+
+// computeWriteDataFee computes the fee of a data entry at the given rate per
+// 256 bytes.
+func computeWriteDataFee(tx *transactions.GenTransaction, rate Fee) (int, error) {
+	txType, n := binary.Uvarint(tx.Transaction)
+	size := len(tx.Transaction) - n
+	if size > WriteDataMax {
+		return 0, fmt.Errorf("data amount exceeds %v byte entry limit", WriteDataMax)
+	}
+	if size <= 0 {
+		return 0, fmt.Errorf("insufficient data provided for %v needed to compute cost", txType)
+	}
+	return rate.AsInt() * (size/256 + 1), nil
+}
@@ -4,8 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
 )
 
+// KeyAlgorithm is the algorithm of a key. ECDSA keys are secp256k1 keys. RSA
+// keys are verified with RSA-PSS.
 type KeyAlgorithm uint8
 
 const (
@@ -15,6 +19,21 @@ const (
 	ED25519
 )
 
+// SignatureKeyAlgorithm returns the algorithm of the key that produced a
+// signature of the given type.
+func SignatureKeyAlgorithm(typ transactions.SignatureType) KeyAlgorithm {
+	switch typ {
+	case transactions.ED25519SignatureType:
+		return ED25519
+	case transactions.Secp256k1SignatureType:
+		return ECDSA
+	case transactions.RSAPSSSignatureType:
+		return RSA
+	default:
+		return UnknownKeyAlgorithm
+	}
+}
+
 func KeyAlgorithmByName(s string) KeyAlgorithm {
 	switch strings.ToUpper(s) {
 	case "RSA":
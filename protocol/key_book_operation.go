@@ -0,0 +1,75 @@
+package protocol
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type KeyBookOperation uint8
+
+const (
+	AddPage KeyBookOperation = iota + 1
+	RemovePage
+	SetPagePriority
+)
+
+func KeyBookOperationByName(s string) KeyBookOperation {
+	switch strings.ToLower(s) {
+	case "addpage":
+		return AddPage
+	case "removepage":
+		return RemovePage
+	case "setpagepriority":
+		return SetPagePriority
+	default:
+		return KeyBookOperation(0)
+	}
+}
+
+func (op KeyBookOperation) String() string {
+	switch op {
+	case AddPage:
+		return "addPage"
+	case RemovePage:
+		return "removePage"
+	case SetPagePriority:
+		return "setPagePriority"
+	default:
+		return fmt.Sprintf("KeyBookOperation:%d", op)
+	}
+}
+
+func (op KeyBookOperation) BinarySize() int {
+	return 1
+}
+
+func (op KeyBookOperation) MarshalBinary() ([]byte, error) {
+	return []byte{byte(op)}, nil
+}
+
+func (op *KeyBookOperation) UnmarshalBinary(b []byte) error {
+	if len(b) == 0 {
+		return ErrNotEnoughData
+	}
+	*op = KeyBookOperation(b[0])
+	return nil
+}
+
+func (op KeyBookOperation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(op.String())
+}
+
+func (op *KeyBookOperation) UnmarshalJSON(b []byte) error {
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+
+	*op = KeyBookOperationByName(s)
+	if *op == 0 {
+		return fmt.Errorf("invalid key book operation: %q", s)
+	}
+	return nil
+}
@@ -12,6 +12,9 @@ const (
 	UpdateKey KeyPageOperation = iota + 1
 	AddKey
 	RemoveKey
+	SetThreshold
+	SetKeyExpiration
+	SetLock
 )
 
 func KeyPageOperationByName(s string) KeyPageOperation {
@@ -22,6 +25,12 @@ func KeyPageOperationByName(s string) KeyPageOperation {
 		return AddKey
 	case "remove":
 		return RemoveKey
+	case "setthreshold":
+		return SetThreshold
+	case "setkeyexpiration":
+		return SetKeyExpiration
+	case "setlock":
+		return SetLock
 	default:
 		return KeyPageOperation(0)
 	}
@@ -35,6 +44,12 @@ func (op KeyPageOperation) String() string {
 		return "add"
 	case RemoveKey:
 		return "remove"
+	case SetThreshold:
+		return "setThreshold"
+	case SetKeyExpiration:
+		return "setKeyExpiration"
+	case SetLock:
+		return "setLock"
 	default:
 		return fmt.Sprintf("KeyPageOperation:%d", op)
 	}
@@ -17,3 +17,33 @@ func (ms *KeyPage) FindKey(pubKey []byte) *KeySpec {
 
 	return nil
 }
+
+// GetThreshold returns the number of distinct keys of the page that must sign
+// a transaction before it is executed. A threshold of zero is treated as one.
+func (ms *KeyPage) GetThreshold() uint64 {
+	if ms.Threshold == 0 {
+		return 1
+	}
+	return ms.Threshold
+}
+
+// GetKeyAlgorithm returns the algorithm of the key. Keys that predate key
+// algorithms are ED25519.
+func (ks *KeySpec) GetKeyAlgorithm() KeyAlgorithm {
+	if ks.KeyAlgorithm == UnknownKeyAlgorithm {
+		return ED25519
+	}
+	return ks.KeyAlgorithm
+}
+
+// IsExpired returns true if the key has an expiration height and the given
+// block height has reached it.
+func (ks *KeySpec) IsExpired(height uint64) bool {
+	return ks.ExpirationHeight != 0 && height >= ks.ExpirationHeight
+}
+
+// IsLocked returns true if the page is locked at the given block height. The
+// keys of a locked page cannot be changed.
+func (ms *KeyPage) IsLocked(height uint64) bool {
+	return height < ms.LockHeight
+}
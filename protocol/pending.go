@@ -0,0 +1,27 @@
+package protocol
+
+// Add adds a transaction to the set. Add returns false if the transaction is
+// already in the set.
+func (s *PendingTransactionSet) Add(txid [32]byte) bool {
+	for _, id := range s.Transactions {
+		if id == txid {
+			return false
+		}
+	}
+
+	s.Transactions = append(s.Transactions, txid)
+	return true
+}
+
+// Remove removes a transaction from the set. Remove returns false if the
+// transaction is not in the set.
+func (s *PendingTransactionSet) Remove(txid [32]byte) bool {
+	for i, id := range s.Transactions {
+		if id == txid {
+			s.Transactions = append(s.Transactions[:i], s.Transactions[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
@@ -12,6 +12,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/AccumulateNetwork/accumulate/internal/url"
+	"golang.org/x/crypto/ripemd160"
 )
 
 const (
@@ -30,11 +31,33 @@ func AcmeUrl() *url.URL {
 // AcmePrecision is the precision of ACME token amounts.
 const AcmePrecision = 1e8
 
-// FiatUnitsPerAcmeToken fixes the conversion between ACME tokens and fiat
-// currency to 1:1, as in $1 per 1 ACME token.
-//
-// As soon as we have an oracle, this must be removed.
-const FiatUnitsPerAcmeToken = 1
+// AcmeOraclePrecision is the precision of the ACME oracle price, which is the
+// number of fiat units per ACME token.
+const AcmeOraclePrecision = 1e4
+
+// InitialAcmeOracleValue is the price of ACME at genesis, $1 per ACME token.
+const InitialAcmeOracleValue = 1 * AcmeOraclePrecision
+
+// DefaultPendingExpiration is the number of blocks a transaction may wait for
+// signatures before it expires, about two weeks at one block per second. The
+// window is set at genesis, since every node of a subnet must use the same
+// value.
+const DefaultPendingExpiration = 14 * 24 * 60 * 60
+
+// Oracle is the name of the ACME oracle record of a subnet, such as
+// `acc://dn/oracle`.
+const Oracle = "oracle"
+
+// ExternalAnchorPool is the name of the record of the DN that records the
+// anchors of major blocks to external ledgers, `acc://dn/external-anchor-pool`.
+const ExternalAnchorPool = "external-anchor-pool"
+
+// ValidatorThreshold returns the signature threshold of the validator key page
+// of a subnet with the given number of validators. Like Tendermint consensus,
+// changes made by the validators require more than two thirds of them.
+func ValidatorThreshold(validators int) uint64 {
+	return uint64(validators*2/3 + 1)
+}
 
 // CreditPrecision is the precision of credit balances.
 const CreditPrecision = 1e2
@@ -63,7 +86,16 @@ const CreditsPerFiatUnit = 1e2 * CreditPrecision
 // The resulting URL is
 //
 //   "acc://aec070645fe53ee3b3763059376134f058cc337226e2a324/ACME"
+//
+// LiteAddress assumes the key is an ED25519 key. Use LiteAddressFor for other
+// key algorithms.
 func LiteAddress(pubKey []byte, tokenUrlStr string) (*url.URL, error) {
+	return LiteAddressFor(ED25519, pubKey, tokenUrlStr)
+}
+
+// LiteAddressFor returns a lite address for the given public key, key
+// algorithm, and token URL. The key hash is computed by LiteKeyHash.
+func LiteAddressFor(alg KeyAlgorithm, pubKey []byte, tokenUrlStr string) (*url.URL, error) {
 	tokenUrl, err := url.Parse(tokenUrlStr)
 	if err != nil {
 		return nil, err
@@ -91,8 +123,7 @@ func LiteAddress(pubKey []byte, tokenUrlStr string) (*url.URL, error) {
 	}
 
 	liteUrl := new(url.URL)
-	keyHash := sha256.Sum256(pubKey)
-	keyStr := fmt.Sprintf("%x", keyHash[:20])
+	keyStr := fmt.Sprintf("%x", LiteKeyHash(alg, pubKey))
 	checkSum := sha256.Sum256([]byte(keyStr))
 	checkStr := fmt.Sprintf("%x", checkSum[28:])
 	liteUrl.Authority = keyStr + checkStr
@@ -100,6 +131,23 @@ func LiteAddress(pubKey []byte, tokenUrlStr string) (*url.URL, error) {
 	return liteUrl, nil
 }
 
+// LiteKeyHash returns the 20 byte key hash used in the lite address of a key.
+//
+// For secp256k1 (ECDSA) keys, the public key must be the 33 byte compressed
+// point, and the key hash is RIPEMD-160(SHA-256(key)), the same as the HASH160
+// used for Bitcoin P2PKH addresses. For ED25519 and RSA keys, the key hash is
+// the first 20 bytes of SHA-256(key), where an RSA key is PKCS #1 DER encoded.
+func LiteKeyHash(alg KeyAlgorithm, pubKey []byte) []byte {
+	keyHash := sha256.Sum256(pubKey)
+	if alg != ECDSA {
+		return keyHash[:20]
+	}
+
+	hasher := ripemd160.New()
+	_, _ = hasher.Write(keyHash[:])
+	return hasher.Sum(nil)
+}
+
 // ParseLiteAddress extracts the key hash and token URL from an lite token
 // account URL. Returns `nil, nil, nil` if the URL is not an lite token account
 // URL. Returns an error if the checksum is invalid.
@@ -128,12 +176,61 @@ func ParseLiteAddress(u *url.URL) ([]byte, *url.URL, error) {
 		v.Authority = u.Path[1:]
 		v.Path = ""
 	} else {
-		v.Authority = u.Path[1:i]
-		v.Path = u.Path[i:]
+		v.Authority = u.Path[1 : i+1]
+		v.Path = u.Path[i+1:]
 	}
 	return b[:20], &v, nil
 }
 
+// ComputeLiteDataAccountId computes the chain ID of a lite data account from
+// the external IDs of its first entry. The chain ID is calculated the same way
+// Factom calculates chain IDs:
+//
+//   sha256(sha256(ExtIds[0]) + sha256(ExtIds[1]) + ... + sha256(ExtIds[n]))
+func ComputeLiteDataAccountId(firstEntry *DataEntry) []byte {
+	hash := sha256.New()
+	for _, id := range firstEntry.ExtIds {
+		h := sha256.Sum256(id)
+		hash.Write(h[:])
+	}
+	return hash.Sum(nil)
+}
+
+// LiteDataAddress returns a lite data account URL for the given chain ID. The
+// URL is the hex-encoded chain ID, for example:
+//
+//   "acc://b36c1c4073305a41edc6353a094329c24ffa54c0a47fb56227a04477bcb78923"
+func LiteDataAddress(chainId []byte) (*url.URL, error) {
+	if len(chainId) != 32 {
+		return nil, fmt.Errorf("chain ID must be 32 bytes, got %d", len(chainId))
+	}
+
+	liteUrl := new(url.URL)
+	liteUrl.Authority = fmt.Sprintf("%x", chainId)
+	return liteUrl, nil
+}
+
+// ParseLiteDataAddress extracts the chain ID from a lite data account URL.
+// Returns `nil, nil` if the URL is not a lite data account URL.
+func ParseLiteDataAddress(u *url.URL) ([]byte, error) {
+	if u.Path != "" && u.Path != "/" {
+		// A lite data account URL does not have a path
+		return nil, nil
+	}
+
+	b, err := hex.DecodeString(u.Hostname())
+	if err != nil || len(b) != 32 {
+		// Hostname is not hex or is the wrong length, therefore the URL is not lite
+		return nil, nil
+	}
+
+	if u.UserInfo != "" || u.Port() != "" || u.Query != "" || u.Fragment != "" {
+		return nil, errors.New("lite data account URLs cannot include user info, a port number, a query, or a fragment")
+	}
+
+	return b, nil
+}
+
 var reDigits10 = regexp.MustCompile("^[0-9]+$")
 var reDigits16 = regexp.MustCompile("^[0-9a-fA-F]+$")
 
@@ -145,7 +242,7 @@ var reDigits16 = regexp.MustCompile("^[0-9a-fA-F]+$")
 // 3) Must have a (non-empty) hostname.
 // 4) Hostname must not include dots (cannot be a domain).
 // 5) Hostname must not be a number.
-// 6) Hostname must not be 48 hexidecimal digits.
+// 6) Hostname must not be 48 or 64 hexidecimal digits.
 // 7) Must not have a path, query, or fragment.
 // 8) Must not be a reserved URL, such as ACME, DN, or BVN-*
 func IsValidAdiUrl(u *url.URL) error {
@@ -169,6 +266,9 @@ func IsValidAdiUrl(u *url.URL) error {
 	if reDigits16.MatchString(u.Authority) && len(u.Authority) == 48 {
 		errs = append(errs, "identity could be a lite account key")
 	}
+	if reDigits16.MatchString(u.Authority) && len(u.Authority) == 64 {
+		errs = append(errs, "identity could be a lite data account")
+	}
 	if u.Path != "" {
 		errs = append(errs, "path is not empty")
 	}
@@ -41,6 +41,7 @@ func TestIsValidAdiUrl(t *testing.T) {
 		"Identity has space":      {URL{Authority: "foo bar"}, "illegal character ' '"},
 		"Looks like lite acct lc": {URL{Authority: strings.ToLower(randHex(24))}, "identity could be a lite account key"},
 		"Looks like lite acct uc": {URL{Authority: strings.ToUpper(randHex(24))}, "identity could be a lite account key"},
+		"Looks like lite data":    {URL{Authority: randHex(32)}, "identity could be a lite data account"},
 	}
 
 	for name, str := range good {
@@ -75,9 +76,24 @@ func TestLiteAddress(t *testing.T) {
 			if name[:4] == "good" {
 				require.NoError(t, err, "%s should be valid", str)
 				fmt.Println(url.String())
+
+				_, tokenUrl, err := ParseLiteAddress(url)
+				require.NoError(t, err)
+				require.Equal(t, strings.ToLower("acc://"+str), strings.ToLower(tokenUrl.String()))
 			} else {
 				require.Errorf(t, err, " %s should be invalid", str)
 			}
 		})
 	}
 }
+
+func TestLiteKeyHash(t *testing.T) {
+	// The compressed secp256k1 public key for the private key 1, and its
+	// Bitcoin HASH160
+	pubKey, err := hex.DecodeString("0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	require.NoError(t, err)
+	require.Equal(t, "751e76e8199196d454941c45d1b3a323f1433bd6", hex.EncodeToString(LiteKeyHash(ECDSA, pubKey)))
+
+	keyHash := sha256.Sum256(pubKey)
+	require.Equal(t, keyHash[:20], LiteKeyHash(ED25519, pubKey))
+}
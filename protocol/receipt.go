@@ -0,0 +1,35 @@
+package protocol
+
+import "github.com/AccumulateNetwork/accumulate/smt/managed"
+
+// ReceiptFromManaged converts a Merkle receipt into a receipt that can be
+// included in a transaction.
+func ReceiptFromManaged(src *managed.Receipt) *Receipt {
+	r := new(Receipt)
+	copy(r.Start[:], src.Element)
+	copy(r.Result[:], src.MDRoot)
+	r.Entries = make([]ReceiptEntry, len(src.Nodes))
+	for i, node := range src.Nodes {
+		r.Entries[i].Right = node.Right
+		copy(r.Entries[i].Hash[:], node.Hash)
+	}
+	return r
+}
+
+// Convert converts the receipt into a Merkle receipt.
+func (r *Receipt) Convert() *managed.Receipt {
+	receipt := new(managed.Receipt)
+	receipt.Element = append(managed.Hash{}, r.Start[:]...)
+	receipt.MDRoot = append(managed.Hash{}, r.Result[:]...)
+	receipt.Nodes = make([]*managed.Node, len(r.Entries))
+	for i, entry := range r.Entries {
+		receipt.Nodes[i] = &managed.Node{Right: entry.Right, Hash: append(managed.Hash{}, entry.Hash[:]...)}
+	}
+	return receipt
+}
+
+// Validate returns true if applying the entries of the receipt to the start
+// results in the result.
+func (r *Receipt) Validate() bool {
+	return r.Convert().Validate()
+}
@@ -18,11 +18,21 @@ type AcmeFaucet struct {
 	Url string `json:"url,omitempty" form:"url" query:"url" validate:"required,acc-url"`
 }
 
+type AcmeOracle struct {
+	state.ChainHeader
+	Price uint64 `json:"price,omitempty" form:"price" query:"price" validate:"required"`
+}
+
 type AddCredits struct {
 	Recipient string `json:"recipient,omitempty" form:"recipient" query:"recipient" validate:"required"`
 	Amount    uint64 `json:"amount,omitempty" form:"amount" query:"amount" validate:"required"`
 }
 
+type AnchoredRecord struct {
+	Record state.Object `json:"record,omitempty" form:"record" query:"record" validate:"required"`
+	Proof  Receipt      `json:"proof,omitempty" form:"proof" query:"proof" validate:"required"`
+}
+
 type BurnTokens struct {
 	Amount big.Int `json:"amount,omitempty" form:"amount" query:"amount" validate:"required"`
 }
@@ -36,6 +46,7 @@ type CreateDataAccount struct {
 	Url               string `json:"url,omitempty" form:"url" query:"url" validate:"required,acc-url"`
 	KeyBookUrl        string `json:"keyBookUrl,omitempty" form:"keyBookUrl" query:"keyBookUrl" validate:"acc-url"`
 	ManagerKeyBookUrl string `json:"managerKeyBookUrl,omitempty" form:"managerKeyBookUrl" query:"managerKeyBookUrl" validate:"acc-url"`
+	Scratch           bool   `json:"scratch,omitempty" form:"scratch" query:"scratch"`
 }
 
 type CreateKeyBook struct {
@@ -49,14 +60,17 @@ type CreateKeyPage struct {
 }
 
 type CreateToken struct {
-	Url        string `json:"url,omitempty" form:"url" query:"url" validate:"required,acc-url"`
-	Symbol     string `json:"symbol,omitempty" form:"symbol" query:"symbol" validate:"required"`
-	Precision  uint64 `json:"precision,omitempty" form:"precision" query:"precision" validate:"required"`
-	Properties string `json:"properties,omitempty" form:"properties" query:"properties" validate:"acc-url"`
+	Url         string  `json:"url,omitempty" form:"url" query:"url" validate:"required,acc-url"`
+	KeyBookUrl  string  `json:"keyBookUrl,omitempty" form:"keyBookUrl" query:"keyBookUrl" validate:"acc-url"`
+	Symbol      string  `json:"symbol,omitempty" form:"symbol" query:"symbol" validate:"required"`
+	Precision   uint64  `json:"precision,omitempty" form:"precision" query:"precision" validate:"required"`
+	Properties  string  `json:"properties,omitempty" form:"properties" query:"properties" validate:"acc-url"`
+	SupplyLimit big.Int `json:"supplyLimit,omitempty" form:"supplyLimit" query:"supplyLimit"`
 }
 
 type DataAccount struct {
 	state.ChainHeader
+	Scratch bool `json:"scratch,omitempty" form:"scratch" query:"scratch"`
 }
 
 type DataEntry struct {
@@ -74,11 +88,20 @@ type DirectoryQueryResult struct {
 	Total           uint64          `json:"total" form:"total" query:"total" validate:"required"`
 }
 
+type ExternalAnchor struct {
+	state.ChainHeader
+	Ledger string   `json:"ledger,omitempty" form:"ledger" query:"ledger" validate:"required"`
+	Index  int64    `json:"index,omitempty" form:"index" query:"index" validate:"required"`
+	Root   [32]byte `json:"root,omitempty" form:"root" query:"root" validate:"required"`
+	TxID   []byte   `json:"txID,omitempty" form:"txID" query:"txID" validate:"required"`
+}
+
 type IdentityCreate struct {
-	Url         string `json:"url,omitempty" form:"url" query:"url" validate:"required,acc-url"`
-	PublicKey   []byte `json:"publicKey,omitempty" form:"publicKey" query:"publicKey" validate:"required"`
-	KeyBookName string `json:"keyBookName,omitempty" form:"keyBookName" query:"keyBookName"`
-	KeyPageName string `json:"keyPageName,omitempty" form:"keyPageName" query:"keyPageName"`
+	Url          string       `json:"url,omitempty" form:"url" query:"url" validate:"required,acc-url"`
+	PublicKey    []byte       `json:"publicKey,omitempty" form:"publicKey" query:"publicKey" validate:"required"`
+	KeyBookName  string       `json:"keyBookName,omitempty" form:"keyBookName" query:"keyBookName"`
+	KeyPageName  string       `json:"keyPageName,omitempty" form:"keyPageName" query:"keyPageName"`
+	KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty" form:"keyAlgorithm" query:"keyAlgorithm"`
 }
 
 type IssueTokens struct {
@@ -95,15 +118,20 @@ type KeyPage struct {
 	state.ChainHeader
 	CreditBalance big.Int    `json:"creditBalance,omitempty" form:"creditBalance" query:"creditBalance" validate:"required"`
 	Keys          []*KeySpec `json:"keys,omitempty" form:"keys" query:"keys" validate:"required"`
+	Threshold     uint64     `json:"threshold,omitempty" form:"threshold" query:"threshold" validate:"required"`
+	LockHeight    uint64     `json:"lockHeight,omitempty" form:"lockHeight" query:"lockHeight"`
 }
 
 type KeySpec struct {
-	PublicKey []byte `json:"publicKey,omitempty" form:"publicKey" query:"publicKey" validate:"required"`
-	Nonce     uint64 `json:"nonce,omitempty" form:"nonce" query:"nonce" validate:"required"`
+	PublicKey        []byte       `json:"publicKey,omitempty" form:"publicKey" query:"publicKey" validate:"required"`
+	Nonce            uint64       `json:"nonce,omitempty" form:"nonce" query:"nonce" validate:"required"`
+	KeyAlgorithm     KeyAlgorithm `json:"keyAlgorithm,omitempty" form:"keyAlgorithm" query:"keyAlgorithm"`
+	ExpirationHeight uint64       `json:"expirationHeight,omitempty" form:"expirationHeight" query:"expirationHeight"`
 }
 
 type KeySpecParams struct {
-	PublicKey []byte `json:"publicKey,omitempty" form:"publicKey" query:"publicKey" validate:"required"`
+	PublicKey    []byte       `json:"publicKey,omitempty" form:"publicKey" query:"publicKey" validate:"required"`
+	KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty" form:"keyAlgorithm" query:"keyAlgorithm"`
 }
 
 type LiteDataAccount struct {
@@ -129,6 +157,21 @@ type MetricsResponse struct {
 	Value interface{} `json:"value,omitempty" form:"value" query:"value" validate:"required"`
 }
 
+type PendingTransactionSet struct {
+	Transactions [][32]byte `json:"transactions,omitempty" form:"transactions" query:"transactions" validate:"required"`
+}
+
+type Receipt struct {
+	Start   [32]byte       `json:"start,omitempty" form:"start" query:"start" validate:"required"`
+	Result  [32]byte       `json:"result,omitempty" form:"result" query:"result" validate:"required"`
+	Entries []ReceiptEntry `json:"entries,omitempty" form:"entries" query:"entries"`
+}
+
+type ReceiptEntry struct {
+	Right bool     `json:"right,omitempty" form:"right" query:"right" validate:"required"`
+	Hash  [32]byte `json:"hash,omitempty" form:"hash" query:"hash" validate:"required"`
+}
+
 type RequestDataEntry struct {
 	Url       string   `json:"url,omitempty" form:"url" query:"url" validate:"required,acc-url"`
 	EntryHash [32]byte `json:"entryHash,omitempty" form:"entryHash" query:"entryHash"`
@@ -151,6 +194,15 @@ type ResponseDataEntrySet struct {
 	Total       uint64              `json:"total,omitempty" form:"total" query:"total" validate:"required"`
 }
 
+type ScratchEntry struct {
+	Chain     [32]byte `json:"chain,omitempty" form:"chain" query:"chain" validate:"required"`
+	EntryHash [32]byte `json:"entryHash,omitempty" form:"entryHash" query:"entryHash" validate:"required"`
+}
+
+type ScratchEntrySet struct {
+	Entries []ScratchEntry `json:"entries,omitempty" form:"entries" query:"entries" validate:"required"`
+}
+
 type SegWitDataEntry struct {
 	Cause     [32]byte `json:"cause,omitempty" form:"cause" query:"cause" validate:"required"`
 	EntryUrl  string   `json:"entryUrl,omitempty" form:"entryUrl" query:"entryUrl" validate:"required,acc-url"`
@@ -163,20 +215,33 @@ type SendTokens struct {
 	To   []*TokenRecipient `json:"to,omitempty" form:"to" query:"to" validate:"required"`
 }
 
+type SubnetAnchor struct {
+	Source         string   `json:"source,omitempty" form:"source" query:"source" validate:"required,acc-url"`
+	SynthTxnAnchor [32]byte `json:"synthTxnAnchor,omitempty" form:"synthTxnAnchor" query:"synthTxnAnchor" validate:"required"`
+	ChainAnchor    [32]byte `json:"chainAnchor,omitempty" form:"chainAnchor" query:"chainAnchor"`
+}
+
+type SubnetAnchorSet struct {
+	Anchors []SubnetAnchor `json:"anchors,omitempty" form:"anchors" query:"anchors" validate:"required"`
+}
+
 type SyntheticAnchor struct {
-	Source         string     `json:"source,omitempty" form:"source" query:"source" validate:"required,acc-url"`
-	Major          bool       `json:"major,omitempty" form:"major" query:"major" validate:"required"`
-	Index          int64      `json:"index,omitempty" form:"index" query:"index" validate:"required"`
-	Timestamp      time.Time  `json:"timestamp,omitempty" form:"timestamp" query:"timestamp" validate:"required"`
-	Root           [32]byte   `json:"root,omitempty" form:"root" query:"root" validate:"required"`
-	SynthTxnAnchor [32]byte   `json:"synthTxnAnchor,omitempty" form:"synthTxnAnchor" query:"synthTxnAnchor" validate:"required"`
-	ChainAnchor    [32]byte   `json:"chainAnchor,omitempty" form:"chainAnchor" query:"chainAnchor" validate:"required"`
-	Chains         [][32]byte `json:"chains,omitempty" form:"chains" query:"chains" validate:"required"`
+	Source          string         `json:"source,omitempty" form:"source" query:"source" validate:"required,acc-url"`
+	Major           bool           `json:"major,omitempty" form:"major" query:"major" validate:"required"`
+	Index           int64          `json:"index,omitempty" form:"index" query:"index" validate:"required"`
+	Timestamp       time.Time      `json:"timestamp,omitempty" form:"timestamp" query:"timestamp" validate:"required"`
+	Root            [32]byte       `json:"root,omitempty" form:"root" query:"root" validate:"required"`
+	SynthTxnAnchor  [32]byte       `json:"synthTxnAnchor,omitempty" form:"synthTxnAnchor" query:"synthTxnAnchor" validate:"required"`
+	ChainAnchor     [32]byte       `json:"chainAnchor,omitempty" form:"chainAnchor" query:"chainAnchor" validate:"required"`
+	Chains          [][32]byte     `json:"chains,omitempty" form:"chains" query:"chains" validate:"required"`
+	AcmeOraclePrice uint64         `json:"acmeOraclePrice,omitempty" form:"acmeOraclePrice" query:"acmeOraclePrice"`
+	SubnetAnchors   []SubnetAnchor `json:"subnetAnchors,omitempty" form:"subnetAnchors" query:"subnetAnchors"`
 }
 
 type SyntheticBurnTokens struct {
 	Cause  [32]byte `json:"cause,omitempty" form:"cause" query:"cause" validate:"required"`
 	Amount big.Int  `json:"amount,omitempty" form:"amount" query:"amount" validate:"required"`
+	Source string   `json:"source,omitempty" form:"source" query:"source" validate:"acc-url"`
 }
 
 type SyntheticCreateChain struct {
@@ -190,16 +255,26 @@ type SyntheticDepositCredits struct {
 }
 
 type SyntheticDepositTokens struct {
-	Cause  [32]byte `json:"cause,omitempty" form:"cause" query:"cause" validate:"required"`
-	Token  string   `json:"token,omitempty" form:"token" query:"token" validate:"required,acc-url"`
-	Amount big.Int  `json:"amount,omitempty" form:"amount" query:"amount" validate:"required"`
+	Cause    [32]byte `json:"cause,omitempty" form:"cause" query:"cause" validate:"required"`
+	Token    string   `json:"token,omitempty" form:"token" query:"token" validate:"required,acc-url"`
+	Amount   big.Int  `json:"amount,omitempty" form:"amount" query:"amount" validate:"required"`
+	Source   string   `json:"source,omitempty" form:"source" query:"source" validate:"acc-url"`
+	IsRefund bool     `json:"isRefund,omitempty" form:"isRefund" query:"isRefund"`
+}
+
+type SyntheticExternalAnchor struct {
+	Ledger string   `json:"ledger,omitempty" form:"ledger" query:"ledger" validate:"required"`
+	Index  int64    `json:"index,omitempty" form:"index" query:"index" validate:"required"`
+	Root   [32]byte `json:"root,omitempty" form:"root" query:"root" validate:"required"`
+	TxID   []byte   `json:"txID,omitempty" form:"txID" query:"txID" validate:"required"`
 }
 
 type SyntheticGenesis struct {
 }
 
 type SyntheticMirror struct {
-	Objects []*state.Object `json:"objects,omitempty" form:"objects" query:"objects" validate:"required"`
+	Source  string           `json:"source,omitempty" form:"source" query:"source" validate:"required,acc-url"`
+	Objects []AnchoredRecord `json:"objects,omitempty" form:"objects" query:"objects" validate:"required"`
 }
 
 type SyntheticSignTransactions struct {
@@ -213,8 +288,8 @@ type SyntheticSignature struct {
 }
 
 type SyntheticWriteData struct {
-	Cause [32]byte `json:"cause,omitempty" form:"cause" query:"cause" validate:"required"`
-	Data  []byte   `json:"data,omitempty" form:"data" query:"data" validate:"required"`
+	Cause [32]byte  `json:"cause,omitempty" form:"cause" query:"cause" validate:"required"`
+	Entry DataEntry `json:"entry,omitempty" form:"entry" query:"entry" validate:"required"`
 }
 
 type TokenAccountCreate struct {
@@ -225,9 +300,12 @@ type TokenAccountCreate struct {
 
 type TokenIssuer struct {
 	state.ChainHeader
-	Symbol     string `json:"symbol,omitempty" form:"symbol" query:"symbol" validate:"required"`
-	Precision  uint64 `json:"precision,omitempty" form:"precision" query:"precision" validate:"required"`
-	Properties string `json:"properties,omitempty" form:"properties" query:"properties" validate:"required,acc-url"`
+	Symbol      string  `json:"symbol,omitempty" form:"symbol" query:"symbol" validate:"required"`
+	Precision   uint64  `json:"precision,omitempty" form:"precision" query:"precision" validate:"required"`
+	Properties  string  `json:"properties,omitempty" form:"properties" query:"properties" validate:"acc-url"`
+	SupplyLimit big.Int `json:"supplyLimit,omitempty" form:"supplyLimit" query:"supplyLimit"`
+	Issued      big.Int `json:"issued,omitempty" form:"issued" query:"issued"`
+	Burned      big.Int `json:"burned,omitempty" form:"burned" query:"burned"`
 }
 
 type TokenRecipient struct {
@@ -235,10 +313,42 @@ type TokenRecipient struct {
 	Amount uint64 `json:"amount,omitempty" form:"amount" query:"amount" validate:"required"`
 }
 
+type TransferCredits struct {
+	Recipient string `json:"recipient,omitempty" form:"recipient" query:"recipient" validate:"required"`
+	Amount    uint64 `json:"amount,omitempty" form:"amount" query:"amount" validate:"required"`
+}
+
+type UpdateAccountAuth struct {
+	KeyBookUrl string `json:"keyBookUrl,omitempty" form:"keyBookUrl" query:"keyBookUrl" validate:"required,acc-url"`
+}
+
+type UpdateAcmeOracle struct {
+	Price uint64 `json:"price,omitempty" form:"price" query:"price" validate:"required"`
+}
+
+type UpdateKeyBook struct {
+	Operation KeyBookOperation `json:"operation,omitempty" form:"operation" query:"operation" validate:"required"`
+	Page      string           `json:"page,omitempty" form:"page" query:"page" validate:"required,acc-url"`
+	Priority  uint64           `json:"priority,omitempty" form:"priority" query:"priority"`
+}
+
 type UpdateKeyPage struct {
-	Operation KeyPageOperation `json:"operation,omitempty" form:"operation" query:"operation" validate:"required"`
-	Key       []byte           `json:"key,omitempty" form:"key" query:"key"`
-	NewKey    []byte           `json:"newKey,omitempty" form:"newKey" query:"newKey"`
+	Operation        KeyPageOperation `json:"operation,omitempty" form:"operation" query:"operation" validate:"required"`
+	Key              []byte           `json:"key,omitempty" form:"key" query:"key"`
+	NewKey           []byte           `json:"newKey,omitempty" form:"newKey" query:"newKey"`
+	Threshold        uint64           `json:"threshold,omitempty" form:"threshold" query:"threshold"`
+	KeyAlgorithm     KeyAlgorithm     `json:"keyAlgorithm,omitempty" form:"keyAlgorithm" query:"keyAlgorithm"`
+	ExpirationHeight uint64           `json:"expirationHeight,omitempty" form:"expirationHeight" query:"expirationHeight"`
+	LockBlocks       uint64           `json:"lockBlocks,omitempty" form:"lockBlocks" query:"lockBlocks"`
+}
+
+type UpdateManager struct {
+	ManagerKeyBookUrl string `json:"managerKeyBookUrl,omitempty" form:"managerKeyBookUrl" query:"managerKeyBookUrl" validate:"acc-url"`
+}
+
+type UpdateValidator struct {
+	PublicKey []byte `json:"publicKey,omitempty" form:"publicKey" query:"publicKey" validate:"required"`
+	Power     int64  `json:"power,omitempty" form:"power" query:"power"`
 }
 
 type WriteData struct {
@@ -250,12 +360,24 @@ type WriteDataTo struct {
 	Entry     DataEntry `json:"entry,omitempty" form:"entry" query:"entry" validate:"required"`
 }
 
+func NewAcmeOracle() *AcmeOracle {
+	v := new(AcmeOracle)
+	v.Type = types.ChainTypeAcmeOracle
+	return v
+}
+
 func NewDataAccount() *DataAccount {
 	v := new(DataAccount)
 	v.Type = types.ChainTypeDataAccount
 	return v
 }
 
+func NewExternalAnchor() *ExternalAnchor {
+	v := new(ExternalAnchor)
+	v.Type = types.ChainTypeExternalAnchor
+	return v
+}
+
 func NewKeyBook() *KeyBook {
 	v := new(KeyBook)
 	v.Type = types.ChainTypeKeyBook
@@ -322,6 +444,10 @@ func (*SyntheticDepositTokens) GetType() types.TransactionType {
 	return types.TxTypeSyntheticDepositTokens
 }
 
+func (*SyntheticExternalAnchor) GetType() types.TransactionType {
+	return types.TxTypeSyntheticExternalAnchor
+}
+
 func (*SyntheticGenesis) GetType() types.TransactionType { return types.TxTypeSyntheticGenesis }
 
 func (*SyntheticMirror) GetType() types.TransactionType { return types.TxTypeSyntheticMirror }
@@ -334,8 +460,20 @@ func (*SyntheticWriteData) GetType() types.TransactionType { return types.TxType
 
 func (*TokenAccountCreate) GetType() types.TransactionType { return types.TxTypeCreateTokenAccount }
 
+func (*TransferCredits) GetType() types.TransactionType { return types.TxTypeTransferCredits }
+
+func (*UpdateAccountAuth) GetType() types.TransactionType { return types.TxTypeUpdateAccountAuth }
+
+func (*UpdateAcmeOracle) GetType() types.TransactionType { return types.TxTypeUpdateAcmeOracle }
+
+func (*UpdateKeyBook) GetType() types.TransactionType { return types.TxTypeUpdateKeyBook }
+
 func (*UpdateKeyPage) GetType() types.TransactionType { return types.TxTypeUpdateKeyPage }
 
+func (*UpdateManager) GetType() types.TransactionType { return types.TxTypeUpdateManager }
+
+func (*UpdateValidator) GetType() types.TransactionType { return types.TxTypeUpdateValidator }
+
 func (*WriteData) GetType() types.TransactionType { return types.TxTypeWriteData }
 
 func (*WriteDataTo) GetType() types.TransactionType { return types.TxTypeWriteDataTo }
@@ -348,6 +486,18 @@ func (v *AcmeFaucet) Equal(u *AcmeFaucet) bool {
 	return true
 }
 
+func (v *AcmeOracle) Equal(u *AcmeOracle) bool {
+	if !v.ChainHeader.Equal(&u.ChainHeader) {
+		return false
+	}
+
+	if !(v.Price == u.Price) {
+		return false
+	}
+
+	return true
+}
+
 func (v *AddCredits) Equal(u *AddCredits) bool {
 	if !(v.Recipient == u.Recipient) {
 		return false
@@ -360,6 +510,18 @@ func (v *AddCredits) Equal(u *AddCredits) bool {
 	return true
 }
 
+func (v *AnchoredRecord) Equal(u *AnchoredRecord) bool {
+	if !(v.Record.Equal(&u.Record)) {
+		return false
+	}
+
+	if !(v.Proof.Equal(&u.Proof)) {
+		return false
+	}
+
+	return true
+}
+
 func (v *BurnTokens) Equal(u *BurnTokens) bool {
 	if !(v.Amount.Cmp(&u.Amount) == 0) {
 		return false
@@ -393,6 +555,10 @@ func (v *CreateDataAccount) Equal(u *CreateDataAccount) bool {
 		return false
 	}
 
+	if !(v.Scratch == u.Scratch) {
+		return false
+	}
+
 	return true
 }
 
@@ -439,6 +605,10 @@ func (v *CreateToken) Equal(u *CreateToken) bool {
 		return false
 	}
 
+	if !(v.KeyBookUrl == u.KeyBookUrl) {
+		return false
+	}
+
 	if !(v.Symbol == u.Symbol) {
 		return false
 	}
@@ -451,6 +621,10 @@ func (v *CreateToken) Equal(u *CreateToken) bool {
 		return false
 	}
 
+	if !(v.SupplyLimit.Cmp(&u.SupplyLimit) == 0) {
+		return false
+	}
+
 	return true
 }
 
@@ -459,6 +633,10 @@ func (v *DataAccount) Equal(u *DataAccount) bool {
 		return false
 	}
 
+	if !(v.Scratch == u.Scratch) {
+		return false
+	}
+
 	return true
 }
 
@@ -522,6 +700,30 @@ func (v *DirectoryQueryResult) Equal(u *DirectoryQueryResult) bool {
 	return true
 }
 
+func (v *ExternalAnchor) Equal(u *ExternalAnchor) bool {
+	if !v.ChainHeader.Equal(&u.ChainHeader) {
+		return false
+	}
+
+	if !(v.Ledger == u.Ledger) {
+		return false
+	}
+
+	if !(v.Index == u.Index) {
+		return false
+	}
+
+	if !(v.Root == u.Root) {
+		return false
+	}
+
+	if !(bytes.Equal(v.TxID, u.TxID)) {
+		return false
+	}
+
+	return true
+}
+
 func (v *IdentityCreate) Equal(u *IdentityCreate) bool {
 	if !(v.Url == u.Url) {
 		return false
@@ -539,6 +741,10 @@ func (v *IdentityCreate) Equal(u *IdentityCreate) bool {
 		return false
 	}
 
+	if !(v.KeyAlgorithm == u.KeyAlgorithm) {
+		return false
+	}
+
 	return true
 }
 
@@ -593,6 +799,14 @@ func (v *KeyPage) Equal(u *KeyPage) bool {
 
 	}
 
+	if !(v.Threshold == u.Threshold) {
+		return false
+	}
+
+	if !(v.LockHeight == u.LockHeight) {
+		return false
+	}
+
 	return true
 }
 
@@ -605,6 +819,14 @@ func (v *KeySpec) Equal(u *KeySpec) bool {
 		return false
 	}
 
+	if !(v.KeyAlgorithm == u.KeyAlgorithm) {
+		return false
+	}
+
+	if !(v.ExpirationHeight == u.ExpirationHeight) {
+		return false
+	}
+
 	return true
 }
 
@@ -613,6 +835,10 @@ func (v *KeySpecParams) Equal(u *KeySpecParams) bool {
 		return false
 	}
 
+	if !(v.KeyAlgorithm == u.KeyAlgorithm) {
+		return false
+	}
+
 	return true
 }
 
@@ -668,6 +894,56 @@ func (v *MetricsRequest) Equal(u *MetricsRequest) bool {
 	return true
 }
 
+func (v *PendingTransactionSet) Equal(u *PendingTransactionSet) bool {
+	if !(len(v.Transactions) == len(u.Transactions)) {
+		return false
+	}
+
+	for i := range v.Transactions {
+		if v.Transactions[i] != u.Transactions[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v *Receipt) Equal(u *Receipt) bool {
+	if !(v.Start == u.Start) {
+		return false
+	}
+
+	if !(v.Result == u.Result) {
+		return false
+	}
+
+	if !(len(v.Entries) == len(u.Entries)) {
+		return false
+	}
+
+	for i := range v.Entries {
+		v, u := v.Entries[i], u.Entries[i]
+		if !(v.Equal(&u)) {
+			return false
+		}
+
+	}
+
+	return true
+}
+
+func (v *ReceiptEntry) Equal(u *ReceiptEntry) bool {
+	if !(v.Right == u.Right) {
+		return false
+	}
+
+	if !(v.Hash == u.Hash) {
+		return false
+	}
+
+	return true
+}
+
 func (v *RequestDataEntry) Equal(u *RequestDataEntry) bool {
 	if !(v.Url == u.Url) {
 		return false
@@ -732,6 +1008,34 @@ func (v *ResponseDataEntrySet) Equal(u *ResponseDataEntrySet) bool {
 	return true
 }
 
+func (v *ScratchEntry) Equal(u *ScratchEntry) bool {
+	if !(v.Chain == u.Chain) {
+		return false
+	}
+
+	if !(v.EntryHash == u.EntryHash) {
+		return false
+	}
+
+	return true
+}
+
+func (v *ScratchEntrySet) Equal(u *ScratchEntrySet) bool {
+	if !(len(v.Entries) == len(u.Entries)) {
+		return false
+	}
+
+	for i := range v.Entries {
+		v, u := v.Entries[i], u.Entries[i]
+		if !(v.Equal(&u)) {
+			return false
+		}
+
+	}
+
+	return true
+}
+
 func (v *SegWitDataEntry) Equal(u *SegWitDataEntry) bool {
 	if !(v.Cause == u.Cause) {
 		return false
@@ -772,6 +1076,38 @@ func (v *SendTokens) Equal(u *SendTokens) bool {
 	return true
 }
 
+func (v *SubnetAnchor) Equal(u *SubnetAnchor) bool {
+	if !(v.Source == u.Source) {
+		return false
+	}
+
+	if !(v.SynthTxnAnchor == u.SynthTxnAnchor) {
+		return false
+	}
+
+	if !(v.ChainAnchor == u.ChainAnchor) {
+		return false
+	}
+
+	return true
+}
+
+func (v *SubnetAnchorSet) Equal(u *SubnetAnchorSet) bool {
+	if !(len(v.Anchors) == len(u.Anchors)) {
+		return false
+	}
+
+	for i := range v.Anchors {
+		v, u := v.Anchors[i], u.Anchors[i]
+		if !(v.Equal(&u)) {
+			return false
+		}
+
+	}
+
+	return true
+}
+
 func (v *SyntheticAnchor) Equal(u *SyntheticAnchor) bool {
 	if !(v.Source == u.Source) {
 		return false
@@ -811,6 +1147,22 @@ func (v *SyntheticAnchor) Equal(u *SyntheticAnchor) bool {
 		}
 	}
 
+	if !(v.AcmeOraclePrice == u.AcmeOraclePrice) {
+		return false
+	}
+
+	if !(len(v.SubnetAnchors) == len(u.SubnetAnchors)) {
+		return false
+	}
+
+	for i := range v.SubnetAnchors {
+		v, u := v.SubnetAnchors[i], u.SubnetAnchors[i]
+		if !(v.Equal(&u)) {
+			return false
+		}
+
+	}
+
 	return true
 }
 
@@ -823,6 +1175,10 @@ func (v *SyntheticBurnTokens) Equal(u *SyntheticBurnTokens) bool {
 		return false
 	}
 
+	if !(v.Source == u.Source) {
+		return false
+	}
+
 	return true
 }
 
@@ -871,22 +1227,54 @@ func (v *SyntheticDepositTokens) Equal(u *SyntheticDepositTokens) bool {
 		return false
 	}
 
-	return true
-}
+	if !(v.Source == u.Source) {
+		return false
+	}
 
-func (v *SyntheticGenesis) Equal(u *SyntheticGenesis) bool {
+	if !(v.IsRefund == u.IsRefund) {
+		return false
+	}
 
 	return true
 }
 
-func (v *SyntheticMirror) Equal(u *SyntheticMirror) bool {
-	if !(len(v.Objects) == len(u.Objects)) {
+func (v *SyntheticExternalAnchor) Equal(u *SyntheticExternalAnchor) bool {
+	if !(v.Ledger == u.Ledger) {
 		return false
 	}
 
-	for i := range v.Objects {
+	if !(v.Index == u.Index) {
+		return false
+	}
+
+	if !(v.Root == u.Root) {
+		return false
+	}
+
+	if !(bytes.Equal(v.TxID, u.TxID)) {
+		return false
+	}
+
+	return true
+}
+
+func (v *SyntheticGenesis) Equal(u *SyntheticGenesis) bool {
+
+	return true
+}
+
+func (v *SyntheticMirror) Equal(u *SyntheticMirror) bool {
+	if !(v.Source == u.Source) {
+		return false
+	}
+
+	if !(len(v.Objects) == len(u.Objects)) {
+		return false
+	}
+
+	for i := range v.Objects {
 		v, u := v.Objects[i], u.Objects[i]
-		if !(v.Equal(u)) {
+		if !(v.Equal(&u)) {
 			return false
 		}
 
@@ -932,7 +1320,7 @@ func (v *SyntheticWriteData) Equal(u *SyntheticWriteData) bool {
 		return false
 	}
 
-	if !(bytes.Equal(v.Data, u.Data)) {
+	if !(v.Entry.Equal(&u.Entry)) {
 		return false
 	}
 
@@ -972,6 +1360,18 @@ func (v *TokenIssuer) Equal(u *TokenIssuer) bool {
 		return false
 	}
 
+	if !(v.SupplyLimit.Cmp(&u.SupplyLimit) == 0) {
+		return false
+	}
+
+	if !(v.Issued.Cmp(&u.Issued) == 0) {
+		return false
+	}
+
+	if !(v.Burned.Cmp(&u.Burned) == 0) {
+		return false
+	}
+
 	return true
 }
 
@@ -987,6 +1387,50 @@ func (v *TokenRecipient) Equal(u *TokenRecipient) bool {
 	return true
 }
 
+func (v *TransferCredits) Equal(u *TransferCredits) bool {
+	if !(v.Recipient == u.Recipient) {
+		return false
+	}
+
+	if !(v.Amount == u.Amount) {
+		return false
+	}
+
+	return true
+}
+
+func (v *UpdateAccountAuth) Equal(u *UpdateAccountAuth) bool {
+	if !(v.KeyBookUrl == u.KeyBookUrl) {
+		return false
+	}
+
+	return true
+}
+
+func (v *UpdateAcmeOracle) Equal(u *UpdateAcmeOracle) bool {
+	if !(v.Price == u.Price) {
+		return false
+	}
+
+	return true
+}
+
+func (v *UpdateKeyBook) Equal(u *UpdateKeyBook) bool {
+	if !(v.Operation == u.Operation) {
+		return false
+	}
+
+	if !(v.Page == u.Page) {
+		return false
+	}
+
+	if !(v.Priority == u.Priority) {
+		return false
+	}
+
+	return true
+}
+
 func (v *UpdateKeyPage) Equal(u *UpdateKeyPage) bool {
 	if !(v.Operation == u.Operation) {
 		return false
@@ -1000,6 +1444,42 @@ func (v *UpdateKeyPage) Equal(u *UpdateKeyPage) bool {
 		return false
 	}
 
+	if !(v.Threshold == u.Threshold) {
+		return false
+	}
+
+	if !(v.KeyAlgorithm == u.KeyAlgorithm) {
+		return false
+	}
+
+	if !(v.ExpirationHeight == u.ExpirationHeight) {
+		return false
+	}
+
+	if !(v.LockBlocks == u.LockBlocks) {
+		return false
+	}
+
+	return true
+}
+
+func (v *UpdateManager) Equal(u *UpdateManager) bool {
+	if !(v.ManagerKeyBookUrl == u.ManagerKeyBookUrl) {
+		return false
+	}
+
+	return true
+}
+
+func (v *UpdateValidator) Equal(u *UpdateValidator) bool {
+	if !(bytes.Equal(v.PublicKey, u.PublicKey)) {
+		return false
+	}
+
+	if !(v.Power == u.Power) {
+		return false
+	}
+
 	return true
 }
 
@@ -1033,6 +1513,19 @@ func (v *AcmeFaucet) BinarySize() int {
 	return n
 }
 
+func (v *AcmeOracle) BinarySize() int {
+	var n int
+
+	// Enforce sanity
+	v.Type = types.ChainTypeAcmeOracle
+
+	n += v.ChainHeader.GetHeaderSize()
+
+	n += encoding.UvarintBinarySize(v.Price)
+
+	return n
+}
+
 func (v *AddCredits) BinarySize() int {
 	var n int
 
@@ -1045,6 +1538,16 @@ func (v *AddCredits) BinarySize() int {
 	return n
 }
 
+func (v *AnchoredRecord) BinarySize() int {
+	var n int
+
+	n += v.Record.BinarySize()
+
+	n += v.Proof.BinarySize()
+
+	return n
+}
+
 func (v *BurnTokens) BinarySize() int {
 	var n int
 
@@ -1076,6 +1579,8 @@ func (v *CreateDataAccount) BinarySize() int {
 
 	n += encoding.StringBinarySize(v.ManagerKeyBookUrl)
 
+	n += encoding.BoolBinarySize(v.Scratch)
+
 	return n
 }
 
@@ -1115,12 +1620,16 @@ func (v *CreateToken) BinarySize() int {
 
 	n += encoding.StringBinarySize(v.Url)
 
+	n += encoding.StringBinarySize(v.KeyBookUrl)
+
 	n += encoding.StringBinarySize(v.Symbol)
 
 	n += encoding.UvarintBinarySize(v.Precision)
 
 	n += encoding.StringBinarySize(v.Properties)
 
+	n += encoding.BigintBinarySize(&v.SupplyLimit)
+
 	return n
 }
 
@@ -1132,6 +1641,8 @@ func (v *DataAccount) BinarySize() int {
 
 	n += v.ChainHeader.GetHeaderSize()
 
+	n += encoding.BoolBinarySize(v.Scratch)
+
 	return n
 }
 
@@ -1180,6 +1691,25 @@ func (v *DirectoryQueryResult) BinarySize() int {
 	return n
 }
 
+func (v *ExternalAnchor) BinarySize() int {
+	var n int
+
+	// Enforce sanity
+	v.Type = types.ChainTypeExternalAnchor
+
+	n += v.ChainHeader.GetHeaderSize()
+
+	n += encoding.StringBinarySize(v.Ledger)
+
+	n += encoding.VarintBinarySize(v.Index)
+
+	n += encoding.ChainBinarySize(&v.Root)
+
+	n += encoding.BytesBinarySize(v.TxID)
+
+	return n
+}
+
 func (v *IdentityCreate) BinarySize() int {
 	var n int
 
@@ -1193,6 +1723,8 @@ func (v *IdentityCreate) BinarySize() int {
 
 	n += encoding.StringBinarySize(v.KeyPageName)
 
+	n += v.KeyAlgorithm.BinarySize()
+
 	return n
 }
 
@@ -1238,6 +1770,10 @@ func (v *KeyPage) BinarySize() int {
 
 	}
 
+	n += encoding.UvarintBinarySize(v.Threshold)
+
+	n += encoding.UvarintBinarySize(v.LockHeight)
+
 	return n
 }
 
@@ -1248,6 +1784,10 @@ func (v *KeySpec) BinarySize() int {
 
 	n += encoding.UvarintBinarySize(v.Nonce)
 
+	n += v.KeyAlgorithm.BinarySize()
+
+	n += encoding.UvarintBinarySize(v.ExpirationHeight)
+
 	return n
 }
 
@@ -1256,6 +1796,8 @@ func (v *KeySpecParams) BinarySize() int {
 
 	n += encoding.BytesBinarySize(v.PublicKey)
 
+	n += v.KeyAlgorithm.BinarySize()
+
 	return n
 }
 
@@ -1303,6 +1845,41 @@ func (v *MetricsRequest) BinarySize() int {
 	return n
 }
 
+func (v *PendingTransactionSet) BinarySize() int {
+	var n int
+
+	n += encoding.ChainSetBinarySize(v.Transactions)
+
+	return n
+}
+
+func (v *Receipt) BinarySize() int {
+	var n int
+
+	n += encoding.ChainBinarySize(&v.Start)
+
+	n += encoding.ChainBinarySize(&v.Result)
+
+	n += encoding.UvarintBinarySize(uint64(len(v.Entries)))
+
+	for _, v := range v.Entries {
+		n += v.BinarySize()
+
+	}
+
+	return n
+}
+
+func (v *ReceiptEntry) BinarySize() int {
+	var n int
+
+	n += encoding.BoolBinarySize(v.Right)
+
+	n += encoding.ChainBinarySize(&v.Hash)
+
+	return n
+}
+
 func (v *RequestDataEntry) BinarySize() int {
 	var n int
 
@@ -1352,6 +1929,29 @@ func (v *ResponseDataEntrySet) BinarySize() int {
 	return n
 }
 
+func (v *ScratchEntry) BinarySize() int {
+	var n int
+
+	n += encoding.ChainBinarySize(&v.Chain)
+
+	n += encoding.ChainBinarySize(&v.EntryHash)
+
+	return n
+}
+
+func (v *ScratchEntrySet) BinarySize() int {
+	var n int
+
+	n += encoding.UvarintBinarySize(uint64(len(v.Entries)))
+
+	for _, v := range v.Entries {
+		n += v.BinarySize()
+
+	}
+
+	return n
+}
+
 func (v *SegWitDataEntry) BinarySize() int {
 	var n int
 
@@ -1385,6 +1985,31 @@ func (v *SendTokens) BinarySize() int {
 	return n
 }
 
+func (v *SubnetAnchor) BinarySize() int {
+	var n int
+
+	n += encoding.StringBinarySize(v.Source)
+
+	n += encoding.ChainBinarySize(&v.SynthTxnAnchor)
+
+	n += encoding.ChainBinarySize(&v.ChainAnchor)
+
+	return n
+}
+
+func (v *SubnetAnchorSet) BinarySize() int {
+	var n int
+
+	n += encoding.UvarintBinarySize(uint64(len(v.Anchors)))
+
+	for _, v := range v.Anchors {
+		n += v.BinarySize()
+
+	}
+
+	return n
+}
+
 func (v *SyntheticAnchor) BinarySize() int {
 	var n int
 
@@ -1406,6 +2031,15 @@ func (v *SyntheticAnchor) BinarySize() int {
 
 	n += encoding.ChainSetBinarySize(v.Chains)
 
+	n += encoding.UvarintBinarySize(v.AcmeOraclePrice)
+
+	n += encoding.UvarintBinarySize(uint64(len(v.SubnetAnchors)))
+
+	for _, v := range v.SubnetAnchors {
+		n += v.BinarySize()
+
+	}
+
 	return n
 }
 
@@ -1418,6 +2052,8 @@ func (v *SyntheticBurnTokens) BinarySize() int {
 
 	n += encoding.BigintBinarySize(&v.Amount)
 
+	n += encoding.StringBinarySize(v.Source)
+
 	return n
 }
 
@@ -1461,6 +2097,26 @@ func (v *SyntheticDepositTokens) BinarySize() int {
 
 	n += encoding.BigintBinarySize(&v.Amount)
 
+	n += encoding.StringBinarySize(v.Source)
+
+	n += encoding.BoolBinarySize(v.IsRefund)
+
+	return n
+}
+
+func (v *SyntheticExternalAnchor) BinarySize() int {
+	var n int
+
+	n += encoding.UvarintBinarySize(types.TxTypeSyntheticExternalAnchor.ID())
+
+	n += encoding.StringBinarySize(v.Ledger)
+
+	n += encoding.VarintBinarySize(v.Index)
+
+	n += encoding.ChainBinarySize(&v.Root)
+
+	n += encoding.BytesBinarySize(v.TxID)
+
 	return n
 }
 
@@ -1477,6 +2133,8 @@ func (v *SyntheticMirror) BinarySize() int {
 
 	n += encoding.UvarintBinarySize(types.TxTypeSyntheticMirror.ID())
 
+	n += encoding.StringBinarySize(v.Source)
+
 	n += encoding.UvarintBinarySize(uint64(len(v.Objects)))
 
 	for _, v := range v.Objects {
@@ -1521,7 +2179,7 @@ func (v *SyntheticWriteData) BinarySize() int {
 
 	n += encoding.ChainBinarySize(&v.Cause)
 
-	n += encoding.BytesBinarySize(v.Data)
+	n += v.Entry.BinarySize()
 
 	return n
 }
@@ -1554,6 +2212,12 @@ func (v *TokenIssuer) BinarySize() int {
 
 	n += encoding.StringBinarySize(v.Properties)
 
+	n += encoding.BigintBinarySize(&v.SupplyLimit)
+
+	n += encoding.BigintBinarySize(&v.Issued)
+
+	n += encoding.BigintBinarySize(&v.Burned)
+
 	return n
 }
 
@@ -1567,17 +2231,93 @@ func (v *TokenRecipient) BinarySize() int {
 	return n
 }
 
-func (v *UpdateKeyPage) BinarySize() int {
+func (v *TransferCredits) BinarySize() int {
 	var n int
 
-	n += encoding.UvarintBinarySize(types.TxTypeUpdateKeyPage.ID())
+	n += encoding.UvarintBinarySize(types.TxTypeTransferCredits.ID())
 
-	n += v.Operation.BinarySize()
+	n += encoding.StringBinarySize(v.Recipient)
 
-	n += encoding.BytesBinarySize(v.Key)
+	n += encoding.UvarintBinarySize(v.Amount)
+
+	return n
+}
+
+func (v *UpdateAccountAuth) BinarySize() int {
+	var n int
+
+	n += encoding.UvarintBinarySize(types.TxTypeUpdateAccountAuth.ID())
+
+	n += encoding.StringBinarySize(v.KeyBookUrl)
+
+	return n
+}
+
+func (v *UpdateAcmeOracle) BinarySize() int {
+	var n int
+
+	n += encoding.UvarintBinarySize(types.TxTypeUpdateAcmeOracle.ID())
+
+	n += encoding.UvarintBinarySize(v.Price)
+
+	return n
+}
+
+func (v *UpdateKeyBook) BinarySize() int {
+	var n int
+
+	n += encoding.UvarintBinarySize(types.TxTypeUpdateKeyBook.ID())
+
+	n += v.Operation.BinarySize()
+
+	n += encoding.StringBinarySize(v.Page)
+
+	n += encoding.UvarintBinarySize(v.Priority)
+
+	return n
+}
+
+func (v *UpdateKeyPage) BinarySize() int {
+	var n int
+
+	n += encoding.UvarintBinarySize(types.TxTypeUpdateKeyPage.ID())
+
+	n += v.Operation.BinarySize()
+
+	n += encoding.BytesBinarySize(v.Key)
 
 	n += encoding.BytesBinarySize(v.NewKey)
 
+	n += encoding.UvarintBinarySize(v.Threshold)
+
+	n += v.KeyAlgorithm.BinarySize()
+
+	n += encoding.UvarintBinarySize(v.ExpirationHeight)
+
+	n += encoding.UvarintBinarySize(v.LockBlocks)
+
+	return n
+}
+
+func (v *UpdateManager) BinarySize() int {
+	var n int
+
+	n += encoding.UvarintBinarySize(types.TxTypeUpdateManager.ID())
+
+	n += encoding.StringBinarySize(v.ManagerKeyBookUrl)
+
+	return n
+}
+
+func (v *UpdateValidator) BinarySize() int {
+	var n int
+
+	n += encoding.UvarintBinarySize(types.TxTypeUpdateValidator.ID())
+
+	n += encoding.BytesBinarySize(v.PublicKey)
+
+	n += encoding.VarintBinarySize(v.Power)
+
 	return n
 }
 
@@ -1613,6 +2353,22 @@ func (v *AcmeFaucet) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+func (v *AcmeOracle) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	// Enforce sanity
+	v.Type = types.ChainTypeAcmeOracle
+
+	if b, err := v.ChainHeader.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("error encoding header: %w", err)
+	} else {
+		buffer.Write(b)
+	}
+	buffer.Write(encoding.UvarintMarshalBinary(v.Price))
+
+	return buffer.Bytes(), nil
+}
+
 func (v *AddCredits) MarshalBinary() ([]byte, error) {
 	var buffer bytes.Buffer
 
@@ -1625,6 +2381,24 @@ func (v *AddCredits) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+func (v *AnchoredRecord) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if b, err := v.Record.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("error encoding Record: %w", err)
+	} else {
+		buffer.Write(b)
+	}
+
+	if b, err := v.Proof.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("error encoding Proof: %w", err)
+	} else {
+		buffer.Write(b)
+	}
+
+	return buffer.Bytes(), nil
+}
+
 func (v *BurnTokens) MarshalBinary() ([]byte, error) {
 	var buffer bytes.Buffer
 
@@ -1656,6 +2430,8 @@ func (v *CreateDataAccount) MarshalBinary() ([]byte, error) {
 
 	buffer.Write(encoding.StringMarshalBinary(v.ManagerKeyBookUrl))
 
+	buffer.Write(encoding.BoolMarshalBinary(v.Scratch))
+
 	return buffer.Bytes(), nil
 }
 
@@ -1699,12 +2475,16 @@ func (v *CreateToken) MarshalBinary() ([]byte, error) {
 
 	buffer.Write(encoding.StringMarshalBinary(v.Url))
 
+	buffer.Write(encoding.StringMarshalBinary(v.KeyBookUrl))
+
 	buffer.Write(encoding.StringMarshalBinary(v.Symbol))
 
 	buffer.Write(encoding.UvarintMarshalBinary(v.Precision))
 
 	buffer.Write(encoding.StringMarshalBinary(v.Properties))
 
+	buffer.Write(encoding.BigintMarshalBinary(&v.SupplyLimit))
+
 	return buffer.Bytes(), nil
 }
 
@@ -1719,6 +2499,7 @@ func (v *DataAccount) MarshalBinary() ([]byte, error) {
 	} else {
 		buffer.Write(b)
 	}
+	buffer.Write(encoding.BoolMarshalBinary(v.Scratch))
 
 	return buffer.Bytes(), nil
 }
@@ -1772,6 +2553,28 @@ func (v *DirectoryQueryResult) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+func (v *ExternalAnchor) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	// Enforce sanity
+	v.Type = types.ChainTypeExternalAnchor
+
+	if b, err := v.ChainHeader.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("error encoding header: %w", err)
+	} else {
+		buffer.Write(b)
+	}
+	buffer.Write(encoding.StringMarshalBinary(v.Ledger))
+
+	buffer.Write(encoding.VarintMarshalBinary(v.Index))
+
+	buffer.Write(encoding.ChainMarshalBinary(&v.Root))
+
+	buffer.Write(encoding.BytesMarshalBinary(v.TxID))
+
+	return buffer.Bytes(), nil
+}
+
 func (v *IdentityCreate) MarshalBinary() ([]byte, error) {
 	var buffer bytes.Buffer
 
@@ -1785,6 +2588,12 @@ func (v *IdentityCreate) MarshalBinary() ([]byte, error) {
 
 	buffer.Write(encoding.StringMarshalBinary(v.KeyPageName))
 
+	if b, err := v.KeyAlgorithm.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("error encoding KeyAlgorithm: %w", err)
+	} else {
+		buffer.Write(b)
+	}
+
 	return buffer.Bytes(), nil
 }
 
@@ -1840,6 +2649,10 @@ func (v *KeyPage) MarshalBinary() ([]byte, error) {
 
 	}
 
+	buffer.Write(encoding.UvarintMarshalBinary(v.Threshold))
+
+	buffer.Write(encoding.UvarintMarshalBinary(v.LockHeight))
+
 	return buffer.Bytes(), nil
 }
 
@@ -1850,6 +2663,14 @@ func (v *KeySpec) MarshalBinary() ([]byte, error) {
 
 	buffer.Write(encoding.UvarintMarshalBinary(v.Nonce))
 
+	if b, err := v.KeyAlgorithm.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("error encoding KeyAlgorithm: %w", err)
+	} else {
+		buffer.Write(b)
+	}
+
+	buffer.Write(encoding.UvarintMarshalBinary(v.ExpirationHeight))
+
 	return buffer.Bytes(), nil
 }
 
@@ -1858,6 +2679,12 @@ func (v *KeySpecParams) MarshalBinary() ([]byte, error) {
 
 	buffer.Write(encoding.BytesMarshalBinary(v.PublicKey))
 
+	if b, err := v.KeyAlgorithm.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("error encoding KeyAlgorithm: %w", err)
+	} else {
+		buffer.Write(b)
+	}
+
 	return buffer.Bytes(), nil
 }
 
@@ -1911,6 +2738,45 @@ func (v *MetricsRequest) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+func (v *PendingTransactionSet) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.ChainSetMarshalBinary(v.Transactions))
+
+	return buffer.Bytes(), nil
+}
+
+func (v *Receipt) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.ChainMarshalBinary(&v.Start))
+
+	buffer.Write(encoding.ChainMarshalBinary(&v.Result))
+
+	buffer.Write(encoding.UvarintMarshalBinary(uint64(len(v.Entries))))
+	for i, v := range v.Entries {
+		_ = i
+		if b, err := v.MarshalBinary(); err != nil {
+			return nil, fmt.Errorf("error encoding Entries[%d]: %w", i, err)
+		} else {
+			buffer.Write(b)
+		}
+
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (v *ReceiptEntry) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.BoolMarshalBinary(v.Right))
+
+	buffer.Write(encoding.ChainMarshalBinary(&v.Hash))
+
+	return buffer.Bytes(), nil
+}
+
 func (v *RequestDataEntry) MarshalBinary() ([]byte, error) {
 	var buffer bytes.Buffer
 
@@ -1968,6 +2834,33 @@ func (v *ResponseDataEntrySet) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+func (v *ScratchEntry) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.ChainMarshalBinary(&v.Chain))
+
+	buffer.Write(encoding.ChainMarshalBinary(&v.EntryHash))
+
+	return buffer.Bytes(), nil
+}
+
+func (v *ScratchEntrySet) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.UvarintMarshalBinary(uint64(len(v.Entries))))
+	for i, v := range v.Entries {
+		_ = i
+		if b, err := v.MarshalBinary(); err != nil {
+			return nil, fmt.Errorf("error encoding Entries[%d]: %w", i, err)
+		} else {
+			buffer.Write(b)
+		}
+
+	}
+
+	return buffer.Bytes(), nil
+}
+
 func (v *SegWitDataEntry) MarshalBinary() ([]byte, error) {
 	var buffer bytes.Buffer
 
@@ -2005,6 +2898,35 @@ func (v *SendTokens) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+func (v *SubnetAnchor) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.StringMarshalBinary(v.Source))
+
+	buffer.Write(encoding.ChainMarshalBinary(&v.SynthTxnAnchor))
+
+	buffer.Write(encoding.ChainMarshalBinary(&v.ChainAnchor))
+
+	return buffer.Bytes(), nil
+}
+
+func (v *SubnetAnchorSet) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.UvarintMarshalBinary(uint64(len(v.Anchors))))
+	for i, v := range v.Anchors {
+		_ = i
+		if b, err := v.MarshalBinary(); err != nil {
+			return nil, fmt.Errorf("error encoding Anchors[%d]: %w", i, err)
+		} else {
+			buffer.Write(b)
+		}
+
+	}
+
+	return buffer.Bytes(), nil
+}
+
 func (v *SyntheticAnchor) MarshalBinary() ([]byte, error) {
 	var buffer bytes.Buffer
 
@@ -2026,6 +2948,19 @@ func (v *SyntheticAnchor) MarshalBinary() ([]byte, error) {
 
 	buffer.Write(encoding.ChainSetMarshalBinary(v.Chains))
 
+	buffer.Write(encoding.UvarintMarshalBinary(v.AcmeOraclePrice))
+
+	buffer.Write(encoding.UvarintMarshalBinary(uint64(len(v.SubnetAnchors))))
+	for i, v := range v.SubnetAnchors {
+		_ = i
+		if b, err := v.MarshalBinary(); err != nil {
+			return nil, fmt.Errorf("error encoding SubnetAnchors[%d]: %w", i, err)
+		} else {
+			buffer.Write(b)
+		}
+
+	}
+
 	return buffer.Bytes(), nil
 }
 
@@ -2038,6 +2973,8 @@ func (v *SyntheticBurnTokens) MarshalBinary() ([]byte, error) {
 
 	buffer.Write(encoding.BigintMarshalBinary(&v.Amount))
 
+	buffer.Write(encoding.StringMarshalBinary(v.Source))
+
 	return buffer.Bytes(), nil
 }
 
@@ -2085,6 +3022,26 @@ func (v *SyntheticDepositTokens) MarshalBinary() ([]byte, error) {
 
 	buffer.Write(encoding.BigintMarshalBinary(&v.Amount))
 
+	buffer.Write(encoding.StringMarshalBinary(v.Source))
+
+	buffer.Write(encoding.BoolMarshalBinary(v.IsRefund))
+
+	return buffer.Bytes(), nil
+}
+
+func (v *SyntheticExternalAnchor) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.UvarintMarshalBinary(types.TxTypeSyntheticExternalAnchor.ID()))
+
+	buffer.Write(encoding.StringMarshalBinary(v.Ledger))
+
+	buffer.Write(encoding.VarintMarshalBinary(v.Index))
+
+	buffer.Write(encoding.ChainMarshalBinary(&v.Root))
+
+	buffer.Write(encoding.BytesMarshalBinary(v.TxID))
+
 	return buffer.Bytes(), nil
 }
 
@@ -2101,6 +3058,8 @@ func (v *SyntheticMirror) MarshalBinary() ([]byte, error) {
 
 	buffer.Write(encoding.UvarintMarshalBinary(types.TxTypeSyntheticMirror.ID()))
 
+	buffer.Write(encoding.StringMarshalBinary(v.Source))
+
 	buffer.Write(encoding.UvarintMarshalBinary(uint64(len(v.Objects))))
 	for i, v := range v.Objects {
 		_ = i
@@ -2153,7 +3112,11 @@ func (v *SyntheticWriteData) MarshalBinary() ([]byte, error) {
 
 	buffer.Write(encoding.ChainMarshalBinary(&v.Cause))
 
-	buffer.Write(encoding.BytesMarshalBinary(v.Data))
+	if b, err := v.Entry.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("error encoding Entry: %w", err)
+	} else {
+		buffer.Write(b)
+	}
 
 	return buffer.Bytes(), nil
 }
@@ -2189,6 +3152,12 @@ func (v *TokenIssuer) MarshalBinary() ([]byte, error) {
 
 	buffer.Write(encoding.StringMarshalBinary(v.Properties))
 
+	buffer.Write(encoding.BigintMarshalBinary(&v.SupplyLimit))
+
+	buffer.Write(encoding.BigintMarshalBinary(&v.Issued))
+
+	buffer.Write(encoding.BigintMarshalBinary(&v.Burned))
+
 	return buffer.Bytes(), nil
 }
 
@@ -2202,34 +3171,118 @@ func (v *TokenRecipient) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
-func (v *UpdateKeyPage) MarshalBinary() ([]byte, error) {
+func (v *TransferCredits) MarshalBinary() ([]byte, error) {
 	var buffer bytes.Buffer
 
-	buffer.Write(encoding.UvarintMarshalBinary(types.TxTypeUpdateKeyPage.ID()))
-
-	if b, err := v.Operation.MarshalBinary(); err != nil {
-		return nil, fmt.Errorf("error encoding Operation: %w", err)
-	} else {
-		buffer.Write(b)
-	}
+	buffer.Write(encoding.UvarintMarshalBinary(types.TxTypeTransferCredits.ID()))
 
-	buffer.Write(encoding.BytesMarshalBinary(v.Key))
+	buffer.Write(encoding.StringMarshalBinary(v.Recipient))
 
-	buffer.Write(encoding.BytesMarshalBinary(v.NewKey))
+	buffer.Write(encoding.UvarintMarshalBinary(v.Amount))
 
 	return buffer.Bytes(), nil
 }
 
-func (v *WriteData) MarshalBinary() ([]byte, error) {
+func (v *UpdateAccountAuth) MarshalBinary() ([]byte, error) {
 	var buffer bytes.Buffer
 
-	buffer.Write(encoding.UvarintMarshalBinary(types.TxTypeWriteData.ID()))
+	buffer.Write(encoding.UvarintMarshalBinary(types.TxTypeUpdateAccountAuth.ID()))
 
-	if b, err := v.Entry.MarshalBinary(); err != nil {
-		return nil, fmt.Errorf("error encoding Entry: %w", err)
-	} else {
-		buffer.Write(b)
-	}
+	buffer.Write(encoding.StringMarshalBinary(v.KeyBookUrl))
+
+	return buffer.Bytes(), nil
+}
+
+func (v *UpdateAcmeOracle) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.UvarintMarshalBinary(types.TxTypeUpdateAcmeOracle.ID()))
+
+	buffer.Write(encoding.UvarintMarshalBinary(v.Price))
+
+	return buffer.Bytes(), nil
+}
+
+func (v *UpdateKeyBook) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.UvarintMarshalBinary(types.TxTypeUpdateKeyBook.ID()))
+
+	if b, err := v.Operation.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("error encoding Operation: %w", err)
+	} else {
+		buffer.Write(b)
+	}
+
+	buffer.Write(encoding.StringMarshalBinary(v.Page))
+
+	buffer.Write(encoding.UvarintMarshalBinary(v.Priority))
+
+	return buffer.Bytes(), nil
+}
+
+func (v *UpdateKeyPage) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.UvarintMarshalBinary(types.TxTypeUpdateKeyPage.ID()))
+
+	if b, err := v.Operation.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("error encoding Operation: %w", err)
+	} else {
+		buffer.Write(b)
+	}
+
+	buffer.Write(encoding.BytesMarshalBinary(v.Key))
+
+	buffer.Write(encoding.BytesMarshalBinary(v.NewKey))
+
+	buffer.Write(encoding.UvarintMarshalBinary(v.Threshold))
+
+	if b, err := v.KeyAlgorithm.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("error encoding KeyAlgorithm: %w", err)
+	} else {
+		buffer.Write(b)
+	}
+
+	buffer.Write(encoding.UvarintMarshalBinary(v.ExpirationHeight))
+
+	buffer.Write(encoding.UvarintMarshalBinary(v.LockBlocks))
+
+	return buffer.Bytes(), nil
+}
+
+func (v *UpdateManager) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.UvarintMarshalBinary(types.TxTypeUpdateManager.ID()))
+
+	buffer.Write(encoding.StringMarshalBinary(v.ManagerKeyBookUrl))
+
+	return buffer.Bytes(), nil
+}
+
+func (v *UpdateValidator) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.UvarintMarshalBinary(types.TxTypeUpdateValidator.ID()))
+
+	buffer.Write(encoding.BytesMarshalBinary(v.PublicKey))
+
+	buffer.Write(encoding.VarintMarshalBinary(v.Power))
+
+	return buffer.Bytes(), nil
+}
+
+func (v *WriteData) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.UvarintMarshalBinary(types.TxTypeWriteData.ID()))
+
+	if b, err := v.Entry.MarshalBinary(); err != nil {
+		return nil, fmt.Errorf("error encoding Entry: %w", err)
+	} else {
+		buffer.Write(b)
+	}
 
 	return buffer.Bytes(), nil
 }
@@ -2269,6 +3322,25 @@ func (v *AcmeFaucet) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+func (v *AcmeOracle) UnmarshalBinary(data []byte) error {
+	typ := types.ChainTypeAcmeOracle
+	if err := v.ChainHeader.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding header: %w", err)
+	} else if v.Type != typ {
+		return fmt.Errorf("invalid chain type: want %v, got %v", typ, v.Type)
+	}
+	data = data[v.GetHeaderSize():]
+
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Price: %w", err)
+	} else {
+		v.Price = x
+	}
+	data = data[encoding.UvarintBinarySize(v.Price):]
+
+	return nil
+}
+
 func (v *AddCredits) UnmarshalBinary(data []byte) error {
 	typ := types.TxTypeAddCredits
 	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
@@ -2295,6 +3367,20 @@ func (v *AddCredits) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+func (v *AnchoredRecord) UnmarshalBinary(data []byte) error {
+	if err := v.Record.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Record: %w", err)
+	}
+	data = data[v.Record.BinarySize():]
+
+	if err := v.Proof.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Proof: %w", err)
+	}
+	data = data[v.Proof.BinarySize():]
+
+	return nil
+}
+
 func (v *BurnTokens) UnmarshalBinary(data []byte) error {
 	typ := types.TxTypeBurnTokens
 	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
@@ -2362,6 +3448,13 @@ func (v *CreateDataAccount) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.StringBinarySize(v.ManagerKeyBookUrl):]
 
+	if x, err := encoding.BoolUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Scratch: %w", err)
+	} else {
+		v.Scratch = x
+	}
+	data = data[encoding.BoolBinarySize(v.Scratch):]
+
 	return nil
 }
 
@@ -2445,6 +3538,13 @@ func (v *CreateToken) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.StringBinarySize(v.Url):]
 
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding KeyBookUrl: %w", err)
+	} else {
+		v.KeyBookUrl = x
+	}
+	data = data[encoding.StringBinarySize(v.KeyBookUrl):]
+
 	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
 		return fmt.Errorf("error decoding Symbol: %w", err)
 	} else {
@@ -2466,6 +3566,13 @@ func (v *CreateToken) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.StringBinarySize(v.Properties):]
 
+	if x, err := encoding.BigintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding SupplyLimit: %w", err)
+	} else {
+		v.SupplyLimit.Set(x)
+	}
+	data = data[encoding.BigintBinarySize(&v.SupplyLimit):]
+
 	return nil
 }
 
@@ -2478,6 +3585,13 @@ func (v *DataAccount) UnmarshalBinary(data []byte) error {
 	}
 	data = data[v.GetHeaderSize():]
 
+	if x, err := encoding.BoolUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Scratch: %w", err)
+	} else {
+		v.Scratch = x
+	}
+	data = data[encoding.BoolBinarySize(v.Scratch):]
+
 	return nil
 }
 
@@ -2571,6 +3685,46 @@ func (v *DirectoryQueryResult) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+func (v *ExternalAnchor) UnmarshalBinary(data []byte) error {
+	typ := types.ChainTypeExternalAnchor
+	if err := v.ChainHeader.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding header: %w", err)
+	} else if v.Type != typ {
+		return fmt.Errorf("invalid chain type: want %v, got %v", typ, v.Type)
+	}
+	data = data[v.GetHeaderSize():]
+
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Ledger: %w", err)
+	} else {
+		v.Ledger = x
+	}
+	data = data[encoding.StringBinarySize(v.Ledger):]
+
+	if x, err := encoding.VarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Index: %w", err)
+	} else {
+		v.Index = x
+	}
+	data = data[encoding.VarintBinarySize(v.Index):]
+
+	if x, err := encoding.ChainUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Root: %w", err)
+	} else {
+		v.Root = x
+	}
+	data = data[encoding.ChainBinarySize(&v.Root):]
+
+	if x, err := encoding.BytesUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding TxID: %w", err)
+	} else {
+		v.TxID = x
+	}
+	data = data[encoding.BytesBinarySize(v.TxID):]
+
+	return nil
+}
+
 func (v *IdentityCreate) UnmarshalBinary(data []byte) error {
 	typ := types.TxTypeCreateIdentity
 	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
@@ -2608,6 +3762,11 @@ func (v *IdentityCreate) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.StringBinarySize(v.KeyPageName):]
 
+	if err := v.KeyAlgorithm.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding KeyAlgorithm: %w", err)
+	}
+	data = data[v.KeyAlgorithm.BinarySize():]
+
 	return nil
 }
 
@@ -2691,6 +3850,20 @@ func (v *KeyPage) UnmarshalBinary(data []byte) error {
 		v.Keys[i] = x
 	}
 
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Threshold: %w", err)
+	} else {
+		v.Threshold = x
+	}
+	data = data[encoding.UvarintBinarySize(v.Threshold):]
+
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding LockHeight: %w", err)
+	} else {
+		v.LockHeight = x
+	}
+	data = data[encoding.UvarintBinarySize(v.LockHeight):]
+
 	return nil
 }
 
@@ -2709,6 +3882,18 @@ func (v *KeySpec) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.UvarintBinarySize(v.Nonce):]
 
+	if err := v.KeyAlgorithm.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding KeyAlgorithm: %w", err)
+	}
+	data = data[v.KeyAlgorithm.BinarySize():]
+
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding ExpirationHeight: %w", err)
+	} else {
+		v.ExpirationHeight = x
+	}
+	data = data[encoding.UvarintBinarySize(v.ExpirationHeight):]
+
 	return nil
 }
 
@@ -2720,6 +3905,11 @@ func (v *KeySpecParams) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.BytesBinarySize(v.PublicKey):]
 
+	if err := v.KeyAlgorithm.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding KeyAlgorithm: %w", err)
+	}
+	data = data[v.KeyAlgorithm.BinarySize():]
+
 	return nil
 }
 
@@ -2807,6 +3997,70 @@ func (v *MetricsRequest) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+func (v *PendingTransactionSet) UnmarshalBinary(data []byte) error {
+	if x, err := encoding.ChainSetUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Transactions: %w", err)
+	} else {
+		v.Transactions = x
+	}
+	data = data[encoding.ChainSetBinarySize(v.Transactions):]
+
+	return nil
+}
+
+func (v *Receipt) UnmarshalBinary(data []byte) error {
+	if x, err := encoding.ChainUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Start: %w", err)
+	} else {
+		v.Start = x
+	}
+	data = data[encoding.ChainBinarySize(&v.Start):]
+
+	if x, err := encoding.ChainUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Result: %w", err)
+	} else {
+		v.Result = x
+	}
+	data = data[encoding.ChainBinarySize(&v.Result):]
+
+	var lenEntries uint64
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Entries: %w", err)
+	} else {
+		lenEntries = x
+	}
+	data = data[encoding.UvarintBinarySize(lenEntries):]
+
+	v.Entries = make([]ReceiptEntry, lenEntries)
+	for i := range v.Entries {
+		if err := v.Entries[i].UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("error decoding Entries[%d]: %w", i, err)
+		}
+		data = data[v.Entries[i].BinarySize():]
+
+	}
+
+	return nil
+}
+
+func (v *ReceiptEntry) UnmarshalBinary(data []byte) error {
+	if x, err := encoding.BoolUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Right: %w", err)
+	} else {
+		v.Right = x
+	}
+	data = data[encoding.BoolBinarySize(v.Right):]
+
+	if x, err := encoding.ChainUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Hash: %w", err)
+	} else {
+		v.Hash = x
+	}
+	data = data[encoding.ChainBinarySize(&v.Hash):]
+
+	return nil
+}
+
 func (v *RequestDataEntry) UnmarshalBinary(data []byte) error {
 	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
 		return fmt.Errorf("error decoding Url: %w", err)
@@ -2901,6 +4155,45 @@ func (v *ResponseDataEntrySet) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+func (v *ScratchEntry) UnmarshalBinary(data []byte) error {
+	if x, err := encoding.ChainUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Chain: %w", err)
+	} else {
+		v.Chain = x
+	}
+	data = data[encoding.ChainBinarySize(&v.Chain):]
+
+	if x, err := encoding.ChainUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding EntryHash: %w", err)
+	} else {
+		v.EntryHash = x
+	}
+	data = data[encoding.ChainBinarySize(&v.EntryHash):]
+
+	return nil
+}
+
+func (v *ScratchEntrySet) UnmarshalBinary(data []byte) error {
+	var lenEntries uint64
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Entries: %w", err)
+	} else {
+		lenEntries = x
+	}
+	data = data[encoding.UvarintBinarySize(lenEntries):]
+
+	v.Entries = make([]ScratchEntry, lenEntries)
+	for i := range v.Entries {
+		if err := v.Entries[i].UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("error decoding Entries[%d]: %w", i, err)
+		}
+		data = data[v.Entries[i].BinarySize():]
+
+	}
+
+	return nil
+}
+
 func (v *SegWitDataEntry) UnmarshalBinary(data []byte) error {
 	typ := types.TxTypeSegWitDataEntry
 	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
@@ -2979,15 +4272,61 @@ func (v *SendTokens) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
-func (v *SyntheticAnchor) UnmarshalBinary(data []byte) error {
-	typ := types.TxTypeSyntheticAnchor
-	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
-		return fmt.Errorf("error decoding TX type: %w", err)
-	} else if v != uint64(typ) {
-		return fmt.Errorf("invalid TX type: want %v, got %v", typ, types.TransactionType(v))
-	}
-	data = data[encoding.UvarintBinarySize(uint64(typ)):]
-
+func (v *SubnetAnchor) UnmarshalBinary(data []byte) error {
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Source: %w", err)
+	} else {
+		v.Source = x
+	}
+	data = data[encoding.StringBinarySize(v.Source):]
+
+	if x, err := encoding.ChainUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding SynthTxnAnchor: %w", err)
+	} else {
+		v.SynthTxnAnchor = x
+	}
+	data = data[encoding.ChainBinarySize(&v.SynthTxnAnchor):]
+
+	if x, err := encoding.ChainUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding ChainAnchor: %w", err)
+	} else {
+		v.ChainAnchor = x
+	}
+	data = data[encoding.ChainBinarySize(&v.ChainAnchor):]
+
+	return nil
+}
+
+func (v *SubnetAnchorSet) UnmarshalBinary(data []byte) error {
+	var lenAnchors uint64
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Anchors: %w", err)
+	} else {
+		lenAnchors = x
+	}
+	data = data[encoding.UvarintBinarySize(lenAnchors):]
+
+	v.Anchors = make([]SubnetAnchor, lenAnchors)
+	for i := range v.Anchors {
+		if err := v.Anchors[i].UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("error decoding Anchors[%d]: %w", i, err)
+		}
+		data = data[v.Anchors[i].BinarySize():]
+
+	}
+
+	return nil
+}
+
+func (v *SyntheticAnchor) UnmarshalBinary(data []byte) error {
+	typ := types.TxTypeSyntheticAnchor
+	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding TX type: %w", err)
+	} else if v != uint64(typ) {
+		return fmt.Errorf("invalid TX type: want %v, got %v", typ, types.TransactionType(v))
+	}
+	data = data[encoding.UvarintBinarySize(uint64(typ)):]
+
 	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
 		return fmt.Errorf("error decoding Source: %w", err)
 	} else {
@@ -3044,6 +4383,30 @@ func (v *SyntheticAnchor) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.ChainSetBinarySize(v.Chains):]
 
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding AcmeOraclePrice: %w", err)
+	} else {
+		v.AcmeOraclePrice = x
+	}
+	data = data[encoding.UvarintBinarySize(v.AcmeOraclePrice):]
+
+	var lenSubnetAnchors uint64
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding SubnetAnchors: %w", err)
+	} else {
+		lenSubnetAnchors = x
+	}
+	data = data[encoding.UvarintBinarySize(lenSubnetAnchors):]
+
+	v.SubnetAnchors = make([]SubnetAnchor, lenSubnetAnchors)
+	for i := range v.SubnetAnchors {
+		if err := v.SubnetAnchors[i].UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("error decoding SubnetAnchors[%d]: %w", i, err)
+		}
+		data = data[v.SubnetAnchors[i].BinarySize():]
+
+	}
+
 	return nil
 }
 
@@ -3070,6 +4433,13 @@ func (v *SyntheticBurnTokens) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.BigintBinarySize(&v.Amount):]
 
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Source: %w", err)
+	} else {
+		v.Source = x
+	}
+	data = data[encoding.StringBinarySize(v.Source):]
+
 	return nil
 }
 
@@ -3165,6 +4535,60 @@ func (v *SyntheticDepositTokens) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.BigintBinarySize(&v.Amount):]
 
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Source: %w", err)
+	} else {
+		v.Source = x
+	}
+	data = data[encoding.StringBinarySize(v.Source):]
+
+	if x, err := encoding.BoolUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding IsRefund: %w", err)
+	} else {
+		v.IsRefund = x
+	}
+	data = data[encoding.BoolBinarySize(v.IsRefund):]
+
+	return nil
+}
+
+func (v *SyntheticExternalAnchor) UnmarshalBinary(data []byte) error {
+	typ := types.TxTypeSyntheticExternalAnchor
+	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding TX type: %w", err)
+	} else if v != uint64(typ) {
+		return fmt.Errorf("invalid TX type: want %v, got %v", typ, types.TransactionType(v))
+	}
+	data = data[encoding.UvarintBinarySize(uint64(typ)):]
+
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Ledger: %w", err)
+	} else {
+		v.Ledger = x
+	}
+	data = data[encoding.StringBinarySize(v.Ledger):]
+
+	if x, err := encoding.VarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Index: %w", err)
+	} else {
+		v.Index = x
+	}
+	data = data[encoding.VarintBinarySize(v.Index):]
+
+	if x, err := encoding.ChainUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Root: %w", err)
+	} else {
+		v.Root = x
+	}
+	data = data[encoding.ChainBinarySize(&v.Root):]
+
+	if x, err := encoding.BytesUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding TxID: %w", err)
+	} else {
+		v.TxID = x
+	}
+	data = data[encoding.BytesBinarySize(v.TxID):]
+
 	return nil
 }
 
@@ -3189,6 +4613,13 @@ func (v *SyntheticMirror) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.UvarintBinarySize(uint64(typ)):]
 
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Source: %w", err)
+	} else {
+		v.Source = x
+	}
+	data = data[encoding.StringBinarySize(v.Source):]
+
 	var lenObjects uint64
 	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
 		return fmt.Errorf("error decoding Objects: %w", err)
@@ -3197,15 +4628,13 @@ func (v *SyntheticMirror) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.UvarintBinarySize(lenObjects):]
 
-	v.Objects = make([]*state.Object, lenObjects)
+	v.Objects = make([]AnchoredRecord, lenObjects)
 	for i := range v.Objects {
-		x := new(state.Object)
-		if err := x.UnmarshalBinary(data); err != nil {
+		if err := v.Objects[i].UnmarshalBinary(data); err != nil {
 			return fmt.Errorf("error decoding Objects[%d]: %w", i, err)
 		}
-		data = data[x.BinarySize():]
+		data = data[v.Objects[i].BinarySize():]
 
-		v.Objects[i] = x
 	}
 
 	return nil
@@ -3281,12 +4710,10 @@ func (v *SyntheticWriteData) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.ChainBinarySize(&v.Cause):]
 
-	if x, err := encoding.BytesUnmarshalBinary(data); err != nil {
-		return fmt.Errorf("error decoding Data: %w", err)
-	} else {
-		v.Data = x
+	if err := v.Entry.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Entry: %w", err)
 	}
-	data = data[encoding.BytesBinarySize(v.Data):]
+	data = data[v.Entry.BinarySize():]
 
 	return nil
 }
@@ -3343,40 +4770,232 @@ func (v *TokenIssuer) UnmarshalBinary(data []byte) error {
 	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
 		return fmt.Errorf("error decoding Precision: %w", err)
 	} else {
-		v.Precision = x
+		v.Precision = x
+	}
+	data = data[encoding.UvarintBinarySize(v.Precision):]
+
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Properties: %w", err)
+	} else {
+		v.Properties = x
+	}
+	data = data[encoding.StringBinarySize(v.Properties):]
+
+	if x, err := encoding.BigintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding SupplyLimit: %w", err)
+	} else {
+		v.SupplyLimit.Set(x)
+	}
+	data = data[encoding.BigintBinarySize(&v.SupplyLimit):]
+
+	if x, err := encoding.BigintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Issued: %w", err)
+	} else {
+		v.Issued.Set(x)
+	}
+	data = data[encoding.BigintBinarySize(&v.Issued):]
+
+	if x, err := encoding.BigintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Burned: %w", err)
+	} else {
+		v.Burned.Set(x)
+	}
+	data = data[encoding.BigintBinarySize(&v.Burned):]
+
+	return nil
+}
+
+func (v *TokenRecipient) UnmarshalBinary(data []byte) error {
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Url: %w", err)
+	} else {
+		v.Url = x
+	}
+	data = data[encoding.StringBinarySize(v.Url):]
+
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Amount: %w", err)
+	} else {
+		v.Amount = x
+	}
+	data = data[encoding.UvarintBinarySize(v.Amount):]
+
+	return nil
+}
+
+func (v *TransferCredits) UnmarshalBinary(data []byte) error {
+	typ := types.TxTypeTransferCredits
+	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding TX type: %w", err)
+	} else if v != uint64(typ) {
+		return fmt.Errorf("invalid TX type: want %v, got %v", typ, types.TransactionType(v))
+	}
+	data = data[encoding.UvarintBinarySize(uint64(typ)):]
+
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Recipient: %w", err)
+	} else {
+		v.Recipient = x
+	}
+	data = data[encoding.StringBinarySize(v.Recipient):]
+
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Amount: %w", err)
+	} else {
+		v.Amount = x
+	}
+	data = data[encoding.UvarintBinarySize(v.Amount):]
+
+	return nil
+}
+
+func (v *UpdateAccountAuth) UnmarshalBinary(data []byte) error {
+	typ := types.TxTypeUpdateAccountAuth
+	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding TX type: %w", err)
+	} else if v != uint64(typ) {
+		return fmt.Errorf("invalid TX type: want %v, got %v", typ, types.TransactionType(v))
+	}
+	data = data[encoding.UvarintBinarySize(uint64(typ)):]
+
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding KeyBookUrl: %w", err)
+	} else {
+		v.KeyBookUrl = x
+	}
+	data = data[encoding.StringBinarySize(v.KeyBookUrl):]
+
+	return nil
+}
+
+func (v *UpdateAcmeOracle) UnmarshalBinary(data []byte) error {
+	typ := types.TxTypeUpdateAcmeOracle
+	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding TX type: %w", err)
+	} else if v != uint64(typ) {
+		return fmt.Errorf("invalid TX type: want %v, got %v", typ, types.TransactionType(v))
+	}
+	data = data[encoding.UvarintBinarySize(uint64(typ)):]
+
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Price: %w", err)
+	} else {
+		v.Price = x
+	}
+	data = data[encoding.UvarintBinarySize(v.Price):]
+
+	return nil
+}
+
+func (v *UpdateKeyBook) UnmarshalBinary(data []byte) error {
+	typ := types.TxTypeUpdateKeyBook
+	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding TX type: %w", err)
+	} else if v != uint64(typ) {
+		return fmt.Errorf("invalid TX type: want %v, got %v", typ, types.TransactionType(v))
+	}
+	data = data[encoding.UvarintBinarySize(uint64(typ)):]
+
+	if err := v.Operation.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Operation: %w", err)
+	}
+	data = data[v.Operation.BinarySize():]
+
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Page: %w", err)
+	} else {
+		v.Page = x
+	}
+	data = data[encoding.StringBinarySize(v.Page):]
+
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Priority: %w", err)
+	} else {
+		v.Priority = x
+	}
+	data = data[encoding.UvarintBinarySize(v.Priority):]
+
+	return nil
+}
+
+func (v *UpdateKeyPage) UnmarshalBinary(data []byte) error {
+	typ := types.TxTypeUpdateKeyPage
+	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding TX type: %w", err)
+	} else if v != uint64(typ) {
+		return fmt.Errorf("invalid TX type: want %v, got %v", typ, types.TransactionType(v))
+	}
+	data = data[encoding.UvarintBinarySize(uint64(typ)):]
+
+	if err := v.Operation.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Operation: %w", err)
+	}
+	data = data[v.Operation.BinarySize():]
+
+	if x, err := encoding.BytesUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Key: %w", err)
+	} else {
+		v.Key = x
+	}
+	data = data[encoding.BytesBinarySize(v.Key):]
+
+	if x, err := encoding.BytesUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding NewKey: %w", err)
+	} else {
+		v.NewKey = x
+	}
+	data = data[encoding.BytesBinarySize(v.NewKey):]
+
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Threshold: %w", err)
+	} else {
+		v.Threshold = x
+	}
+	data = data[encoding.UvarintBinarySize(v.Threshold):]
+
+	if err := v.KeyAlgorithm.UnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding KeyAlgorithm: %w", err)
+	}
+	data = data[v.KeyAlgorithm.BinarySize():]
+
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding ExpirationHeight: %w", err)
+	} else {
+		v.ExpirationHeight = x
 	}
-	data = data[encoding.UvarintBinarySize(v.Precision):]
+	data = data[encoding.UvarintBinarySize(v.ExpirationHeight):]
 
-	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
-		return fmt.Errorf("error decoding Properties: %w", err)
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding LockBlocks: %w", err)
 	} else {
-		v.Properties = x
+		v.LockBlocks = x
 	}
-	data = data[encoding.StringBinarySize(v.Properties):]
+	data = data[encoding.UvarintBinarySize(v.LockBlocks):]
 
 	return nil
 }
 
-func (v *TokenRecipient) UnmarshalBinary(data []byte) error {
-	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
-		return fmt.Errorf("error decoding Url: %w", err)
-	} else {
-		v.Url = x
+func (v *UpdateManager) UnmarshalBinary(data []byte) error {
+	typ := types.TxTypeUpdateManager
+	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding TX type: %w", err)
+	} else if v != uint64(typ) {
+		return fmt.Errorf("invalid TX type: want %v, got %v", typ, types.TransactionType(v))
 	}
-	data = data[encoding.StringBinarySize(v.Url):]
+	data = data[encoding.UvarintBinarySize(uint64(typ)):]
 
-	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
-		return fmt.Errorf("error decoding Amount: %w", err)
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding ManagerKeyBookUrl: %w", err)
 	} else {
-		v.Amount = x
+		v.ManagerKeyBookUrl = x
 	}
-	data = data[encoding.UvarintBinarySize(v.Amount):]
+	data = data[encoding.StringBinarySize(v.ManagerKeyBookUrl):]
 
 	return nil
 }
 
-func (v *UpdateKeyPage) UnmarshalBinary(data []byte) error {
-	typ := types.TxTypeUpdateKeyPage
+func (v *UpdateValidator) UnmarshalBinary(data []byte) error {
+	typ := types.TxTypeUpdateValidator
 	if v, err := encoding.UvarintUnmarshalBinary(data); err != nil {
 		return fmt.Errorf("error decoding TX type: %w", err)
 	} else if v != uint64(typ) {
@@ -3384,24 +5003,19 @@ func (v *UpdateKeyPage) UnmarshalBinary(data []byte) error {
 	}
 	data = data[encoding.UvarintBinarySize(uint64(typ)):]
 
-	if err := v.Operation.UnmarshalBinary(data); err != nil {
-		return fmt.Errorf("error decoding Operation: %w", err)
-	}
-	data = data[v.Operation.BinarySize():]
-
 	if x, err := encoding.BytesUnmarshalBinary(data); err != nil {
-		return fmt.Errorf("error decoding Key: %w", err)
+		return fmt.Errorf("error decoding PublicKey: %w", err)
 	} else {
-		v.Key = x
+		v.PublicKey = x
 	}
-	data = data[encoding.BytesBinarySize(v.Key):]
+	data = data[encoding.BytesBinarySize(v.PublicKey):]
 
-	if x, err := encoding.BytesUnmarshalBinary(data); err != nil {
-		return fmt.Errorf("error decoding NewKey: %w", err)
+	if x, err := encoding.VarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Power: %w", err)
 	} else {
-		v.NewKey = x
+		v.Power = x
 	}
-	data = data[encoding.BytesBinarySize(v.NewKey):]
+	data = data[encoding.VarintBinarySize(v.Power):]
 
 	return nil
 }
@@ -3480,17 +5094,35 @@ func (v *DataEntry) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *ExternalAnchor) MarshalJSON() ([]byte, error) {
+	u := struct {
+		state.ChainHeader
+		Ledger string  `json:"ledger,omitempty"`
+		Index  int64   `json:"index,omitempty"`
+		Root   string  `json:"root,omitempty"`
+		TxID   *string `json:"txID,omitempty"`
+	}{}
+	u.ChainHeader = v.ChainHeader
+	u.Ledger = v.Ledger
+	u.Index = v.Index
+	u.Root = encoding.ChainToJSON(v.Root)
+	u.TxID = encoding.BytesToJSON(v.TxID)
+	return json.Marshal(&u)
+}
+
 func (v *IdentityCreate) MarshalJSON() ([]byte, error) {
 	u := struct {
-		Url         string  `json:"url,omitempty"`
-		PublicKey   *string `json:"publicKey,omitempty"`
-		KeyBookName string  `json:"keyBookName,omitempty"`
-		KeyPageName string  `json:"keyPageName,omitempty"`
+		Url          string       `json:"url,omitempty"`
+		PublicKey    *string      `json:"publicKey,omitempty"`
+		KeyBookName  string       `json:"keyBookName,omitempty"`
+		KeyPageName  string       `json:"keyPageName,omitempty"`
+		KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
 	}{}
 	u.Url = v.Url
 	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
 	u.KeyBookName = v.KeyBookName
 	u.KeyPageName = v.KeyPageName
+	u.KeyAlgorithm = v.KeyAlgorithm
 	return json.Marshal(&u)
 }
 
@@ -3506,19 +5138,25 @@ func (v *KeyBook) MarshalJSON() ([]byte, error) {
 
 func (v *KeySpec) MarshalJSON() ([]byte, error) {
 	u := struct {
-		PublicKey *string `json:"publicKey,omitempty"`
-		Nonce     uint64  `json:"nonce,omitempty"`
+		PublicKey        *string      `json:"publicKey,omitempty"`
+		Nonce            uint64       `json:"nonce,omitempty"`
+		KeyAlgorithm     KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+		ExpirationHeight uint64       `json:"expirationHeight,omitempty"`
 	}{}
 	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
 	u.Nonce = v.Nonce
+	u.KeyAlgorithm = v.KeyAlgorithm
+	u.ExpirationHeight = v.ExpirationHeight
 	return json.Marshal(&u)
 }
 
 func (v *KeySpecParams) MarshalJSON() ([]byte, error) {
 	u := struct {
-		PublicKey *string `json:"publicKey,omitempty"`
+		PublicKey    *string      `json:"publicKey,omitempty"`
+		KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
 	}{}
 	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
+	u.KeyAlgorithm = v.KeyAlgorithm
 	return json.Marshal(&u)
 }
 
@@ -3542,6 +5180,36 @@ func (v *MetricsRequest) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *PendingTransactionSet) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Transactions []string `json:"transactions,omitempty"`
+	}{}
+	u.Transactions = encoding.ChainSetToJSON(v.Transactions)
+	return json.Marshal(&u)
+}
+
+func (v *Receipt) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Start   string         `json:"start,omitempty"`
+		Result  string         `json:"result,omitempty"`
+		Entries []ReceiptEntry `json:"entries,omitempty"`
+	}{}
+	u.Start = encoding.ChainToJSON(v.Start)
+	u.Result = encoding.ChainToJSON(v.Result)
+	u.Entries = v.Entries
+	return json.Marshal(&u)
+}
+
+func (v *ReceiptEntry) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Right bool   `json:"right,omitempty"`
+		Hash  string `json:"hash,omitempty"`
+	}{}
+	u.Right = v.Right
+	u.Hash = encoding.ChainToJSON(v.Hash)
+	return json.Marshal(&u)
+}
+
 func (v *RequestDataEntry) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Url       string `json:"url,omitempty"`
@@ -3562,6 +5230,16 @@ func (v *ResponseDataEntry) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *ScratchEntry) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Chain     string `json:"chain,omitempty"`
+		EntryHash string `json:"entryHash,omitempty"`
+	}{}
+	u.Chain = encoding.ChainToJSON(v.Chain)
+	u.EntryHash = encoding.ChainToJSON(v.EntryHash)
+	return json.Marshal(&u)
+}
+
 func (v *SegWitDataEntry) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Cause     string `json:"cause,omitempty"`
@@ -3586,16 +5264,30 @@ func (v *SendTokens) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *SubnetAnchor) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Source         string `json:"source,omitempty"`
+		SynthTxnAnchor string `json:"synthTxnAnchor,omitempty"`
+		ChainAnchor    string `json:"chainAnchor,omitempty"`
+	}{}
+	u.Source = v.Source
+	u.SynthTxnAnchor = encoding.ChainToJSON(v.SynthTxnAnchor)
+	u.ChainAnchor = encoding.ChainToJSON(v.ChainAnchor)
+	return json.Marshal(&u)
+}
+
 func (v *SyntheticAnchor) MarshalJSON() ([]byte, error) {
 	u := struct {
-		Source         string    `json:"source,omitempty"`
-		Major          bool      `json:"major,omitempty"`
-		Index          int64     `json:"index,omitempty"`
-		Timestamp      time.Time `json:"timestamp,omitempty"`
-		Root           string    `json:"root,omitempty"`
-		SynthTxnAnchor string    `json:"synthTxnAnchor,omitempty"`
-		ChainAnchor    string    `json:"chainAnchor,omitempty"`
-		Chains         []string  `json:"chains,omitempty"`
+		Source          string         `json:"source,omitempty"`
+		Major           bool           `json:"major,omitempty"`
+		Index           int64          `json:"index,omitempty"`
+		Timestamp       time.Time      `json:"timestamp,omitempty"`
+		Root            string         `json:"root,omitempty"`
+		SynthTxnAnchor  string         `json:"synthTxnAnchor,omitempty"`
+		ChainAnchor     string         `json:"chainAnchor,omitempty"`
+		Chains          []string       `json:"chains,omitempty"`
+		AcmeOraclePrice uint64         `json:"acmeOraclePrice,omitempty"`
+		SubnetAnchors   []SubnetAnchor `json:"subnetAnchors,omitempty"`
 	}{}
 	u.Source = v.Source
 	u.Major = v.Major
@@ -3605,6 +5297,8 @@ func (v *SyntheticAnchor) MarshalJSON() ([]byte, error) {
 	u.SynthTxnAnchor = encoding.ChainToJSON(v.SynthTxnAnchor)
 	u.ChainAnchor = encoding.ChainToJSON(v.ChainAnchor)
 	u.Chains = encoding.ChainSetToJSON(v.Chains)
+	u.AcmeOraclePrice = v.AcmeOraclePrice
+	u.SubnetAnchors = v.SubnetAnchors
 	return json.Marshal(&u)
 }
 
@@ -3612,9 +5306,11 @@ func (v *SyntheticBurnTokens) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Cause  string  `json:"cause,omitempty"`
 		Amount big.Int `json:"amount,omitempty"`
+		Source string  `json:"source,omitempty"`
 	}{}
 	u.Cause = encoding.ChainToJSON(v.Cause)
 	u.Amount = v.Amount
+	u.Source = v.Source
 	return json.Marshal(&u)
 }
 
@@ -3640,13 +5336,31 @@ func (v *SyntheticDepositCredits) MarshalJSON() ([]byte, error) {
 
 func (v *SyntheticDepositTokens) MarshalJSON() ([]byte, error) {
 	u := struct {
-		Cause  string  `json:"cause,omitempty"`
-		Token  string  `json:"token,omitempty"`
-		Amount big.Int `json:"amount,omitempty"`
+		Cause    string  `json:"cause,omitempty"`
+		Token    string  `json:"token,omitempty"`
+		Amount   big.Int `json:"amount,omitempty"`
+		Source   string  `json:"source,omitempty"`
+		IsRefund bool    `json:"isRefund,omitempty"`
 	}{}
 	u.Cause = encoding.ChainToJSON(v.Cause)
 	u.Token = v.Token
 	u.Amount = v.Amount
+	u.Source = v.Source
+	u.IsRefund = v.IsRefund
+	return json.Marshal(&u)
+}
+
+func (v *SyntheticExternalAnchor) MarshalJSON() ([]byte, error) {
+	u := struct {
+		Ledger string  `json:"ledger,omitempty"`
+		Index  int64   `json:"index,omitempty"`
+		Root   string  `json:"root,omitempty"`
+		TxID   *string `json:"txID,omitempty"`
+	}{}
+	u.Ledger = v.Ledger
+	u.Index = v.Index
+	u.Root = encoding.ChainToJSON(v.Root)
+	u.TxID = encoding.BytesToJSON(v.TxID)
 	return json.Marshal(&u)
 }
 
@@ -3664,23 +5378,41 @@ func (v *SyntheticSignature) MarshalJSON() ([]byte, error) {
 
 func (v *SyntheticWriteData) MarshalJSON() ([]byte, error) {
 	u := struct {
-		Cause string  `json:"cause,omitempty"`
-		Data  *string `json:"data,omitempty"`
+		Cause string    `json:"cause,omitempty"`
+		Entry DataEntry `json:"entry,omitempty"`
 	}{}
 	u.Cause = encoding.ChainToJSON(v.Cause)
-	u.Data = encoding.BytesToJSON(v.Data)
+	u.Entry = v.Entry
 	return json.Marshal(&u)
 }
 
 func (v *UpdateKeyPage) MarshalJSON() ([]byte, error) {
 	u := struct {
-		Operation KeyPageOperation `json:"operation,omitempty"`
-		Key       *string          `json:"key,omitempty"`
-		NewKey    *string          `json:"newKey,omitempty"`
+		Operation        KeyPageOperation `json:"operation,omitempty"`
+		Key              *string          `json:"key,omitempty"`
+		NewKey           *string          `json:"newKey,omitempty"`
+		Threshold        uint64           `json:"threshold,omitempty"`
+		KeyAlgorithm     KeyAlgorithm     `json:"keyAlgorithm,omitempty"`
+		ExpirationHeight uint64           `json:"expirationHeight,omitempty"`
+		LockBlocks       uint64           `json:"lockBlocks,omitempty"`
 	}{}
 	u.Operation = v.Operation
 	u.Key = encoding.BytesToJSON(v.Key)
 	u.NewKey = encoding.BytesToJSON(v.NewKey)
+	u.Threshold = v.Threshold
+	u.KeyAlgorithm = v.KeyAlgorithm
+	u.ExpirationHeight = v.ExpirationHeight
+	u.LockBlocks = v.LockBlocks
+	return json.Marshal(&u)
+}
+
+func (v *UpdateValidator) MarshalJSON() ([]byte, error) {
+	u := struct {
+		PublicKey *string `json:"publicKey,omitempty"`
+		Power     int64   `json:"power,omitempty"`
+	}{}
+	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
+	u.Power = v.Power
 	return json.Marshal(&u)
 }
 
@@ -3751,17 +5483,51 @@ func (v *DataEntry) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *ExternalAnchor) UnmarshalJSON(data []byte) error {
+	u := struct {
+		state.ChainHeader
+		Ledger string  `json:"ledger,omitempty"`
+		Index  int64   `json:"index,omitempty"`
+		Root   string  `json:"root,omitempty"`
+		TxID   *string `json:"txID,omitempty"`
+	}{}
+	u.ChainHeader = v.ChainHeader
+	u.Ledger = v.Ledger
+	u.Index = v.Index
+	u.Root = encoding.ChainToJSON(v.Root)
+	u.TxID = encoding.BytesToJSON(v.TxID)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.ChainHeader = u.ChainHeader
+	v.Ledger = u.Ledger
+	v.Index = u.Index
+	if x, err := encoding.ChainFromJSON(u.Root); err != nil {
+		return fmt.Errorf("error decoding Root: %w", err)
+	} else {
+		v.Root = x
+	}
+	if x, err := encoding.BytesFromJSON(u.TxID); err != nil {
+		return fmt.Errorf("error decoding TxID: %w", err)
+	} else {
+		v.TxID = x
+	}
+	return nil
+}
+
 func (v *IdentityCreate) UnmarshalJSON(data []byte) error {
 	u := struct {
-		Url         string  `json:"url,omitempty"`
-		PublicKey   *string `json:"publicKey,omitempty"`
-		KeyBookName string  `json:"keyBookName,omitempty"`
-		KeyPageName string  `json:"keyPageName,omitempty"`
+		Url          string       `json:"url,omitempty"`
+		PublicKey    *string      `json:"publicKey,omitempty"`
+		KeyBookName  string       `json:"keyBookName,omitempty"`
+		KeyPageName  string       `json:"keyPageName,omitempty"`
+		KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
 	}{}
 	u.Url = v.Url
 	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
 	u.KeyBookName = v.KeyBookName
 	u.KeyPageName = v.KeyPageName
+	u.KeyAlgorithm = v.KeyAlgorithm
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -3773,6 +5539,7 @@ func (v *IdentityCreate) UnmarshalJSON(data []byte) error {
 	}
 	v.KeyBookName = u.KeyBookName
 	v.KeyPageName = u.KeyPageName
+	v.KeyAlgorithm = u.KeyAlgorithm
 	return nil
 }
 
@@ -3797,11 +5564,15 @@ func (v *KeyBook) UnmarshalJSON(data []byte) error {
 
 func (v *KeySpec) UnmarshalJSON(data []byte) error {
 	u := struct {
-		PublicKey *string `json:"publicKey,omitempty"`
-		Nonce     uint64  `json:"nonce,omitempty"`
+		PublicKey        *string      `json:"publicKey,omitempty"`
+		Nonce            uint64       `json:"nonce,omitempty"`
+		KeyAlgorithm     KeyAlgorithm `json:"keyAlgorithm,omitempty"`
+		ExpirationHeight uint64       `json:"expirationHeight,omitempty"`
 	}{}
 	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
 	u.Nonce = v.Nonce
+	u.KeyAlgorithm = v.KeyAlgorithm
+	u.ExpirationHeight = v.ExpirationHeight
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -3811,14 +5582,18 @@ func (v *KeySpec) UnmarshalJSON(data []byte) error {
 		v.PublicKey = x
 	}
 	v.Nonce = u.Nonce
+	v.KeyAlgorithm = u.KeyAlgorithm
+	v.ExpirationHeight = u.ExpirationHeight
 	return nil
 }
 
 func (v *KeySpecParams) UnmarshalJSON(data []byte) error {
 	u := struct {
-		PublicKey *string `json:"publicKey,omitempty"`
+		PublicKey    *string      `json:"publicKey,omitempty"`
+		KeyAlgorithm KeyAlgorithm `json:"keyAlgorithm,omitempty"`
 	}{}
 	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
+	u.KeyAlgorithm = v.KeyAlgorithm
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -3827,6 +5602,7 @@ func (v *KeySpecParams) UnmarshalJSON(data []byte) error {
 	} else {
 		v.PublicKey = x
 	}
+	v.KeyAlgorithm = u.KeyAlgorithm
 	return nil
 }
 
@@ -3868,6 +5644,67 @@ func (v *MetricsRequest) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *PendingTransactionSet) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Transactions []string `json:"transactions,omitempty"`
+	}{}
+	u.Transactions = encoding.ChainSetToJSON(v.Transactions)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if x, err := encoding.ChainSetFromJSON(u.Transactions); err != nil {
+		return fmt.Errorf("error decoding Transactions: %w", err)
+	} else {
+		v.Transactions = x
+	}
+	return nil
+}
+
+func (v *Receipt) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Start   string         `json:"start,omitempty"`
+		Result  string         `json:"result,omitempty"`
+		Entries []ReceiptEntry `json:"entries,omitempty"`
+	}{}
+	u.Start = encoding.ChainToJSON(v.Start)
+	u.Result = encoding.ChainToJSON(v.Result)
+	u.Entries = v.Entries
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if x, err := encoding.ChainFromJSON(u.Start); err != nil {
+		return fmt.Errorf("error decoding Start: %w", err)
+	} else {
+		v.Start = x
+	}
+	if x, err := encoding.ChainFromJSON(u.Result); err != nil {
+		return fmt.Errorf("error decoding Result: %w", err)
+	} else {
+		v.Result = x
+	}
+	v.Entries = u.Entries
+	return nil
+}
+
+func (v *ReceiptEntry) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Right bool   `json:"right,omitempty"`
+		Hash  string `json:"hash,omitempty"`
+	}{}
+	u.Right = v.Right
+	u.Hash = encoding.ChainToJSON(v.Hash)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Right = u.Right
+	if x, err := encoding.ChainFromJSON(u.Hash); err != nil {
+		return fmt.Errorf("error decoding Hash: %w", err)
+	} else {
+		v.Hash = x
+	}
+	return nil
+}
+
 func (v *RequestDataEntry) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Url       string `json:"url,omitempty"`
@@ -3906,6 +5743,29 @@ func (v *ResponseDataEntry) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *ScratchEntry) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Chain     string `json:"chain,omitempty"`
+		EntryHash string `json:"entryHash,omitempty"`
+	}{}
+	u.Chain = encoding.ChainToJSON(v.Chain)
+	u.EntryHash = encoding.ChainToJSON(v.EntryHash)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if x, err := encoding.ChainFromJSON(u.Chain); err != nil {
+		return fmt.Errorf("error decoding Chain: %w", err)
+	} else {
+		v.Chain = x
+	}
+	if x, err := encoding.ChainFromJSON(u.EntryHash); err != nil {
+		return fmt.Errorf("error decoding EntryHash: %w", err)
+	} else {
+		v.EntryHash = x
+	}
+	return nil
+}
+
 func (v *SegWitDataEntry) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Cause     string `json:"cause,omitempty"`
@@ -3954,16 +5814,44 @@ func (v *SendTokens) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (v *SubnetAnchor) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Source         string `json:"source,omitempty"`
+		SynthTxnAnchor string `json:"synthTxnAnchor,omitempty"`
+		ChainAnchor    string `json:"chainAnchor,omitempty"`
+	}{}
+	u.Source = v.Source
+	u.SynthTxnAnchor = encoding.ChainToJSON(v.SynthTxnAnchor)
+	u.ChainAnchor = encoding.ChainToJSON(v.ChainAnchor)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Source = u.Source
+	if x, err := encoding.ChainFromJSON(u.SynthTxnAnchor); err != nil {
+		return fmt.Errorf("error decoding SynthTxnAnchor: %w", err)
+	} else {
+		v.SynthTxnAnchor = x
+	}
+	if x, err := encoding.ChainFromJSON(u.ChainAnchor); err != nil {
+		return fmt.Errorf("error decoding ChainAnchor: %w", err)
+	} else {
+		v.ChainAnchor = x
+	}
+	return nil
+}
+
 func (v *SyntheticAnchor) UnmarshalJSON(data []byte) error {
 	u := struct {
-		Source         string    `json:"source,omitempty"`
-		Major          bool      `json:"major,omitempty"`
-		Index          int64     `json:"index,omitempty"`
-		Timestamp      time.Time `json:"timestamp,omitempty"`
-		Root           string    `json:"root,omitempty"`
-		SynthTxnAnchor string    `json:"synthTxnAnchor,omitempty"`
-		ChainAnchor    string    `json:"chainAnchor,omitempty"`
-		Chains         []string  `json:"chains,omitempty"`
+		Source          string         `json:"source,omitempty"`
+		Major           bool           `json:"major,omitempty"`
+		Index           int64          `json:"index,omitempty"`
+		Timestamp       time.Time      `json:"timestamp,omitempty"`
+		Root            string         `json:"root,omitempty"`
+		SynthTxnAnchor  string         `json:"synthTxnAnchor,omitempty"`
+		ChainAnchor     string         `json:"chainAnchor,omitempty"`
+		Chains          []string       `json:"chains,omitempty"`
+		AcmeOraclePrice uint64         `json:"acmeOraclePrice,omitempty"`
+		SubnetAnchors   []SubnetAnchor `json:"subnetAnchors,omitempty"`
 	}{}
 	u.Source = v.Source
 	u.Major = v.Major
@@ -3973,6 +5861,8 @@ func (v *SyntheticAnchor) UnmarshalJSON(data []byte) error {
 	u.SynthTxnAnchor = encoding.ChainToJSON(v.SynthTxnAnchor)
 	u.ChainAnchor = encoding.ChainToJSON(v.ChainAnchor)
 	u.Chains = encoding.ChainSetToJSON(v.Chains)
+	u.AcmeOraclePrice = v.AcmeOraclePrice
+	u.SubnetAnchors = v.SubnetAnchors
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -4000,6 +5890,8 @@ func (v *SyntheticAnchor) UnmarshalJSON(data []byte) error {
 	} else {
 		v.Chains = x
 	}
+	v.AcmeOraclePrice = u.AcmeOraclePrice
+	v.SubnetAnchors = u.SubnetAnchors
 	return nil
 }
 
@@ -4007,9 +5899,11 @@ func (v *SyntheticBurnTokens) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Cause  string  `json:"cause,omitempty"`
 		Amount big.Int `json:"amount,omitempty"`
+		Source string  `json:"source,omitempty"`
 	}{}
 	u.Cause = encoding.ChainToJSON(v.Cause)
 	u.Amount = v.Amount
+	u.Source = v.Source
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -4019,6 +5913,7 @@ func (v *SyntheticBurnTokens) UnmarshalJSON(data []byte) error {
 		v.Cause = x
 	}
 	v.Amount = u.Amount
+	v.Source = u.Source
 	return nil
 }
 
@@ -4062,13 +5957,17 @@ func (v *SyntheticDepositCredits) UnmarshalJSON(data []byte) error {
 
 func (v *SyntheticDepositTokens) UnmarshalJSON(data []byte) error {
 	u := struct {
-		Cause  string  `json:"cause,omitempty"`
-		Token  string  `json:"token,omitempty"`
-		Amount big.Int `json:"amount,omitempty"`
+		Cause    string  `json:"cause,omitempty"`
+		Token    string  `json:"token,omitempty"`
+		Amount   big.Int `json:"amount,omitempty"`
+		Source   string  `json:"source,omitempty"`
+		IsRefund bool    `json:"isRefund,omitempty"`
 	}{}
 	u.Cause = encoding.ChainToJSON(v.Cause)
 	u.Token = v.Token
 	u.Amount = v.Amount
+	u.Source = v.Source
+	u.IsRefund = v.IsRefund
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -4079,6 +5978,37 @@ func (v *SyntheticDepositTokens) UnmarshalJSON(data []byte) error {
 	}
 	v.Token = u.Token
 	v.Amount = u.Amount
+	v.Source = u.Source
+	v.IsRefund = u.IsRefund
+	return nil
+}
+
+func (v *SyntheticExternalAnchor) UnmarshalJSON(data []byte) error {
+	u := struct {
+		Ledger string  `json:"ledger,omitempty"`
+		Index  int64   `json:"index,omitempty"`
+		Root   string  `json:"root,omitempty"`
+		TxID   *string `json:"txID,omitempty"`
+	}{}
+	u.Ledger = v.Ledger
+	u.Index = v.Index
+	u.Root = encoding.ChainToJSON(v.Root)
+	u.TxID = encoding.BytesToJSON(v.TxID)
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	v.Ledger = u.Ledger
+	v.Index = u.Index
+	if x, err := encoding.ChainFromJSON(u.Root); err != nil {
+		return fmt.Errorf("error decoding Root: %w", err)
+	} else {
+		v.Root = x
+	}
+	if x, err := encoding.BytesFromJSON(u.TxID); err != nil {
+		return fmt.Errorf("error decoding TxID: %w", err)
+	} else {
+		v.TxID = x
+	}
 	return nil
 }
 
@@ -4110,11 +6040,11 @@ func (v *SyntheticSignature) UnmarshalJSON(data []byte) error {
 
 func (v *SyntheticWriteData) UnmarshalJSON(data []byte) error {
 	u := struct {
-		Cause string  `json:"cause,omitempty"`
-		Data  *string `json:"data,omitempty"`
+		Cause string    `json:"cause,omitempty"`
+		Entry DataEntry `json:"entry,omitempty"`
 	}{}
 	u.Cause = encoding.ChainToJSON(v.Cause)
-	u.Data = encoding.BytesToJSON(v.Data)
+	u.Entry = v.Entry
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -4123,23 +6053,27 @@ func (v *SyntheticWriteData) UnmarshalJSON(data []byte) error {
 	} else {
 		v.Cause = x
 	}
-	if x, err := encoding.BytesFromJSON(u.Data); err != nil {
-		return fmt.Errorf("error decoding Data: %w", err)
-	} else {
-		v.Data = x
-	}
+	v.Entry = u.Entry
 	return nil
 }
 
 func (v *UpdateKeyPage) UnmarshalJSON(data []byte) error {
 	u := struct {
-		Operation KeyPageOperation `json:"operation,omitempty"`
-		Key       *string          `json:"key,omitempty"`
-		NewKey    *string          `json:"newKey,omitempty"`
+		Operation        KeyPageOperation `json:"operation,omitempty"`
+		Key              *string          `json:"key,omitempty"`
+		NewKey           *string          `json:"newKey,omitempty"`
+		Threshold        uint64           `json:"threshold,omitempty"`
+		KeyAlgorithm     KeyAlgorithm     `json:"keyAlgorithm,omitempty"`
+		ExpirationHeight uint64           `json:"expirationHeight,omitempty"`
+		LockBlocks       uint64           `json:"lockBlocks,omitempty"`
 	}{}
 	u.Operation = v.Operation
 	u.Key = encoding.BytesToJSON(v.Key)
 	u.NewKey = encoding.BytesToJSON(v.NewKey)
+	u.Threshold = v.Threshold
+	u.KeyAlgorithm = v.KeyAlgorithm
+	u.ExpirationHeight = v.ExpirationHeight
+	u.LockBlocks = v.LockBlocks
 	if err := json.Unmarshal(data, &u); err != nil {
 		return err
 	}
@@ -4154,5 +6088,28 @@ func (v *UpdateKeyPage) UnmarshalJSON(data []byte) error {
 	} else {
 		v.NewKey = x
 	}
+	v.Threshold = u.Threshold
+	v.KeyAlgorithm = u.KeyAlgorithm
+	v.ExpirationHeight = u.ExpirationHeight
+	v.LockBlocks = u.LockBlocks
+	return nil
+}
+
+func (v *UpdateValidator) UnmarshalJSON(data []byte) error {
+	u := struct {
+		PublicKey *string `json:"publicKey,omitempty"`
+		Power     int64   `json:"power,omitempty"`
+	}{}
+	u.PublicKey = encoding.BytesToJSON(v.PublicKey)
+	u.Power = v.Power
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if x, err := encoding.BytesFromJSON(u.PublicKey); err != nil {
+		return fmt.Errorf("error decoding PublicKey: %w", err)
+	} else {
+		v.PublicKey = x
+	}
+	v.Power = u.Power
 	return nil
 }
@@ -1,6 +1,8 @@
 package pmt
 
 import (
+	"fmt"
+
 	"github.com/AccumulateNetwork/accumulate/smt/storage"
 	"github.com/AccumulateNetwork/accumulate/smt/storage/database"
 )
@@ -76,3 +78,49 @@ func (m *Manager) FlushNode(node *Node) { //   Flush a Byte Block
 func (m *Manager) InsertKV(key, value [32]byte) {
 	m.Bpt.Insert(key, value)
 }
+
+// ForEach calls fn for every value in the BPT, loading each Byte Block from the
+// database as it is reached. ForEach does not trust what it loads: a missing,
+// malformed, or repeated Byte Block is returned as an error instead of causing
+// a panic, so it can walk a BPT that has not yet been verified.
+func (m *Manager) ForEach(fn func(key, hash [32]byte) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid byte block: %v", r)
+		}
+	}()
+
+	loaded := map[[32]byte]bool{m.Bpt.Root.BBKey: true}
+	var walk func(Entry) error
+	walk = func(e Entry) error {
+		switch e := e.(type) {
+		case nil:
+			return nil
+		case *Value:
+			return fn(e.Key, e.Hash)
+		case *Node:
+			if e.Left != nil && e.Left.T() == TNotLoaded ||
+				e.Right != nil && e.Right.T() == TNotLoaded {
+				if loaded[e.BBKey] {
+					return fmt.Errorf("byte block %X is reached more than once", e.BBKey)
+				}
+				loaded[e.BBKey] = true
+
+				data, err := m.DBManager.Get(kBpt.Append(e.BBKey[:]))
+				if err != nil {
+					return fmt.Errorf("failed to load byte block %X: %v", e.BBKey, err)
+				}
+				m.Bpt.UnMarshalByteBlock(e, data)
+			}
+
+			err := walk(e.Left)
+			if err != nil {
+				return err
+			}
+			return walk(e.Right)
+		default:
+			return fmt.Errorf("unexpected entry of type %d", e.T())
+		}
+	}
+	return walk(m.Bpt.Root)
+}
@@ -115,3 +115,59 @@ func TestManagerSeries(t *testing.T) {
 		}
 	}
 }
+
+func TestManagerForEach(t *testing.T) {
+	dbManager, err := database.NewDBManager("memory", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Enough values to span several Byte Blocks
+	values := map[[32]byte][32]byte{}
+	bptManager := NewBPTManager(dbManager)
+	for i := 0; i < 1000; i++ {
+		key := sha256.Sum256([]byte(fmt.Sprintf("key %d", i)))
+		value := sha256.Sum256([]byte(fmt.Sprintf("value %d", i)))
+		values[key] = value
+		bptManager.InsertKV(key, value)
+	}
+	bptManager.Bpt.Update()
+
+	// Every value is visited once when the BPT is loaded from the database
+	seen := map[[32]byte][32]byte{}
+	err = NewBPTManager(dbManager).ForEach(func(key, hash [32]byte) error {
+		if _, ok := seen[key]; ok {
+			t.Errorf("key %X visited twice", key)
+		}
+		seen[key] = hash
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != len(values) {
+		t.Fatalf("visited %d values, want %d", len(seen), len(values))
+	}
+	for k, v := range values {
+		if seen[k] != v {
+			t.Errorf("key %X has hash %X, want %X", k, seen[k], v)
+		}
+	}
+
+	// A missing Byte Block is an error, not a panic
+	for _, e := range []Entry{bptManager.Bpt.Root.Left, bptManager.Bpt.Root.Right} {
+		for e != nil && e.T() == TNode && e.(*Node).Height&bptManager.Bpt.mask != 0 {
+			e = e.(*Node).Left
+		}
+		if e == nil || e.T() != TNode {
+			continue
+		}
+		dbManager.PutBatch(kBpt.Append(e.(*Node).BBKey[:]), []byte{TNode})
+		err = NewBPTManager(dbManager).ForEach(func(key, hash [32]byte) error { return nil })
+		if err == nil {
+			t.Fatal("expected an error for a malformed byte block")
+		}
+		return
+	}
+	t.Fatal("no border node found")
+}
@@ -209,6 +209,78 @@ func (d *DB) EndBatch(TXCache map[storage.Key][]byte) error {
 	return nil
 }
 
+// View
+// Opens a read transaction. The view sees the state of the database when it
+// was opened, even if the database is written to concurrently.
+func (d *DB) View() (storage.KeyValueView, error) {
+	if l, err := d.lock(false); err != nil {
+		return nil, err
+	} else {
+		defer l.Unlock()
+	}
+
+	return &view{db: d, txn: d.badgerDB.NewTransaction(false)}, nil
+}
+
+// Clear
+// Removes every key/value pair from the database
+func (d *DB) Clear() error {
+	if l, err := d.lock(false); err != nil {
+		return err
+	} else {
+		defer l.Unlock()
+	}
+
+	return d.badgerDB.DropAll()
+}
+
+// view is a read-only view of a DB, backed by a read transaction.
+type view struct {
+	db  *DB
+	txn *badger.Txn
+}
+
+// ForEach
+// Calls the function for every key/value pair in the view, sorted by key.
+// ForEach stops if the function returns an error.
+func (v *view) ForEach(fn func(storage.Key, []byte) error) error {
+	if l, err := v.db.lock(false); err != nil {
+		return err
+	} else {
+		defer l.Unlock()
+	}
+
+	it := v.txn.NewIterator(badger.DefaultIteratorOptions)
+	defer it.Close()
+
+	for it.Rewind(); it.Valid(); it.Next() {
+		item := it.Item()
+
+		var key storage.Key
+		if len(item.Key()) != len(key) {
+			continue
+		}
+		copy(key[:], item.Key())
+
+		value, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+
+		err = fn(key, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Discard
+// Discards the read transaction
+func (v *view) Discard() {
+	v.txn.Discard()
+}
+
 type badgerLogger struct {
 	storage.Logger
 }
@@ -6,7 +6,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
 	"github.com/dgraph-io/badger/v3"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDatabase(t *testing.T) {
@@ -102,3 +104,30 @@ func TestDatabase2(t *testing.T) {
 		}
 	}
 }
+
+func TestDB_View(t *testing.T) {
+	db := new(DB)
+	require.NoError(t, db.InitDB(t.TempDir(), nil))
+	defer db.Close()
+
+	k1, k2 := storage.MakeKey("one"), storage.MakeKey("two")
+	require.NoError(t, db.Put(k1, []byte{1}))
+
+	// Writes after the view is opened are not visible to it
+	view, err := db.View()
+	require.NoError(t, err)
+	defer view.Discard()
+	require.NoError(t, db.Put(k2, []byte{2}))
+
+	entries := map[storage.Key][]byte{}
+	require.NoError(t, view.ForEach(func(key storage.Key, value []byte) error {
+		entries[key] = value
+		return nil
+	}))
+	require.Equal(t, map[storage.Key][]byte{k1: {1}}, entries)
+
+	// Clear removes everything
+	require.NoError(t, db.Clear())
+	_, err = db.Get(k1)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
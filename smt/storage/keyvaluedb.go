@@ -17,6 +17,15 @@ type KeyValueDB interface {
 	Get(key Key) (value []byte, err error)       // Get key from database, returns ErrNotFound if the key is not found
 	Put(key Key, value []byte) error             // Put the value in the database, throws an error if fails
 	EndBatch(map[Key][]byte) error               // End and commit a batch of transactions
+	View() (KeyValueView, error)                 // Open a consistent, read-only view of the database
+	Clear() error                                // Remove every key/value pair from the database
+}
+
+// KeyValueView is a consistent, read-only view of a KeyValueDB. Writes to the
+// database made after the view is opened are not visible to it.
+type KeyValueView interface {
+	ForEach(func(Key, []byte) error) error // Call the function for every key/value pair, in order of the keys
+	Discard()                              // Release the view
 }
 
 // Logger defines a generic logging interface compatible with Tendermint (stolen from Tendermint).
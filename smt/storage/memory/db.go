@@ -152,6 +152,59 @@ func (m *DB) Put(key storage.Key, value []byte) error {
 	return nil
 }
 
+// View
+// Returns a view of a copy of the database, so that the view is not affected
+// by later writes.
+func (m *DB) View() (storage.KeyValueView, error) {
+	if !m.Ready() {
+		return nil, storage.ErrNotOpen
+	}
+	return view(m.Export()), nil
+}
+
+// Clear
+// Removes every key/value pair from the database
+func (m *DB) Clear() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if !m.Ready() {
+		return storage.ErrNotOpen
+	}
+
+	for k := range m.entries {
+		delete(m.entries, k)
+	}
+	return nil
+}
+
+// view is a read-only view of a copy of the entries of a DB.
+type view map[storage.Key][]byte
+
+// ForEach
+// Calls the function for every key/value pair in the view, sorted by key.
+// ForEach stops if the function returns an error.
+func (v view) ForEach(fn func(storage.Key, []byte) error) error {
+	keys := make([]storage.Key, 0, len(v))
+	for key := range v {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+
+	for _, key := range keys {
+		err := fn(key, v[key])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Discard
+// Nothing to do, the copy is garbage collected
+func (v view) Discard() {}
+
 type jsonDB []jsonEntry
 
 type jsonEntry struct {
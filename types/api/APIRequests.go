@@ -16,6 +16,14 @@ type Signer struct {
 	Nonce     uint64        `json:"nonce" form:"nonce" query:"nonce" validate:"required"`
 }
 
+// SignerInfo holds the public key and nonce of the signer of a transaction.
+// The public key is not always 32 bytes, since the size depends on the
+// algorithm of the key.
+type SignerInfo struct {
+	PublicKey types.Bytes `json:"publicKey" form:"publicKey" query:"publicKey"`
+	Nonce     uint64      `json:"nonce" form:"nonce" query:"nonce"`
+}
+
 // APIRequestRaw will leave the data payload intact which is required for signature verification
 type APIRequestRaw struct {
 	Wait bool             `json:"wait" form:"wait" query:"wait"`
@@ -76,8 +84,8 @@ type APIDataResponse struct {
 	KeyPage     *APIRequestKeyPage `json:"keyPage" form:"keyPage" query:"keyPage" validate:"required"`
 	TxId        *types.Bytes       `json:"txid" form:"txid" query:"txid"`
 	//the following are optional available only if pending chain has not been purged
-	Signer *Signer          `json:"signer,omitempty" form:"signer" query:"signer"`
-	Sig    *types.Bytes64   `json:"sig,omitempty" form:"sig" query:"sig"`
+	Signer *SignerInfo      `json:"signer,omitempty" form:"signer" query:"signer"`
+	Sig    *types.Bytes     `json:"sig,omitempty" form:"sig" query:"sig"`
 	Status *json.RawMessage `json:"status,omitempty" form:"status" query:"status"`
 }
 
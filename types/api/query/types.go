@@ -5,3 +5,5 @@ import "github.com/AccumulateNetwork/accumulate/types"
 //go:generate go run ../../../tools/cmd/gentypes --package query types.yml
 
 func (*RequestKeyPageIndex) Type() types.QueryType { return types.QueryTypeKeyPageIndex }
+
+func (*RequestPendingTransactions) Type() types.QueryType { return types.QueryTypePendingTransactions }
@@ -10,17 +10,62 @@ import (
 	"github.com/AccumulateNetwork/accumulate/internal/encoding"
 )
 
+type PendingTransactionStatus struct {
+	TxId          [32]byte `json:"txId,omitempty" form:"txId" query:"txId" validate:"required"`
+	Origin        string   `json:"origin,omitempty" form:"origin" query:"origin" validate:"required"`
+	KeyPageHeight uint64   `json:"keyPageHeight" form:"keyPageHeight" query:"keyPageHeight" validate:"required"`
+	Signers       [][]byte `json:"signers,omitempty" form:"signers" query:"signers" validate:"required"`
+}
+
 type RequestKeyPageIndex struct {
 	Url string `json:"url,omitempty" form:"url" query:"url" validate:"required,acc-url"`
 	Key []byte `json:"key,omitempty" form:"key" query:"key" validate:"required"`
 }
 
+type RequestPendingTransactions struct {
+	Url string `json:"url,omitempty" form:"url" query:"url" validate:"required,acc-url"`
+}
+
 type ResponseKeyPageIndex struct {
 	KeyBook string `json:"keyBook,omitempty" form:"keyBook" query:"keyBook" validate:"required"`
 	KeyPage string `json:"keyPage,omitempty" form:"keyPage" query:"keyPage" validate:"required"`
 	Index   uint64 `json:"index" form:"index" query:"index" validate:"required"`
 }
 
+type ResponsePendingTransactions struct {
+	KeyPage      string                      `json:"keyPage,omitempty" form:"keyPage" query:"keyPage" validate:"required"`
+	Threshold    uint64                      `json:"threshold" form:"threshold" query:"threshold" validate:"required"`
+	Transactions []*PendingTransactionStatus `json:"transactions,omitempty" form:"transactions" query:"transactions" validate:"required"`
+}
+
+func (v *PendingTransactionStatus) Equal(u *PendingTransactionStatus) bool {
+	if !(v.TxId == u.TxId) {
+		return false
+	}
+
+	if !(v.Origin == u.Origin) {
+		return false
+	}
+
+	if !(v.KeyPageHeight == u.KeyPageHeight) {
+		return false
+	}
+
+	if !(len(v.Signers) == len(u.Signers)) {
+		return false
+	}
+
+	for i := range v.Signers {
+		v, u := v.Signers[i], u.Signers[i]
+		if !(bytes.Equal(v, u)) {
+			return false
+		}
+
+	}
+
+	return true
+}
+
 func (v *RequestKeyPageIndex) Equal(u *RequestKeyPageIndex) bool {
 	if !(v.Url == u.Url) {
 		return false
@@ -33,6 +78,14 @@ func (v *RequestKeyPageIndex) Equal(u *RequestKeyPageIndex) bool {
 	return true
 }
 
+func (v *RequestPendingTransactions) Equal(u *RequestPendingTransactions) bool {
+	if !(v.Url == u.Url) {
+		return false
+	}
+
+	return true
+}
+
 func (v *ResponseKeyPageIndex) Equal(u *ResponseKeyPageIndex) bool {
 	if !(v.KeyBook == u.KeyBook) {
 		return false
@@ -49,6 +102,49 @@ func (v *ResponseKeyPageIndex) Equal(u *ResponseKeyPageIndex) bool {
 	return true
 }
 
+func (v *ResponsePendingTransactions) Equal(u *ResponsePendingTransactions) bool {
+	if !(v.KeyPage == u.KeyPage) {
+		return false
+	}
+
+	if !(v.Threshold == u.Threshold) {
+		return false
+	}
+
+	if !(len(v.Transactions) == len(u.Transactions)) {
+		return false
+	}
+
+	for i := range v.Transactions {
+		v, u := v.Transactions[i], u.Transactions[i]
+		if !(v.Equal(u)) {
+			return false
+		}
+
+	}
+
+	return true
+}
+
+func (v *PendingTransactionStatus) BinarySize() int {
+	var n int
+
+	n += encoding.ChainBinarySize(&v.TxId)
+
+	n += encoding.StringBinarySize(v.Origin)
+
+	n += encoding.UvarintBinarySize(v.KeyPageHeight)
+
+	n += encoding.UvarintBinarySize(uint64(len(v.Signers)))
+
+	for _, v := range v.Signers {
+		n += encoding.BytesBinarySize(v)
+
+	}
+
+	return n
+}
+
 func (v *RequestKeyPageIndex) BinarySize() int {
 	var n int
 
@@ -59,6 +155,14 @@ func (v *RequestKeyPageIndex) BinarySize() int {
 	return n
 }
 
+func (v *RequestPendingTransactions) BinarySize() int {
+	var n int
+
+	n += encoding.StringBinarySize(v.Url)
+
+	return n
+}
+
 func (v *ResponseKeyPageIndex) BinarySize() int {
 	var n int
 
@@ -71,6 +175,42 @@ func (v *ResponseKeyPageIndex) BinarySize() int {
 	return n
 }
 
+func (v *ResponsePendingTransactions) BinarySize() int {
+	var n int
+
+	n += encoding.StringBinarySize(v.KeyPage)
+
+	n += encoding.UvarintBinarySize(v.Threshold)
+
+	n += encoding.UvarintBinarySize(uint64(len(v.Transactions)))
+
+	for _, v := range v.Transactions {
+		n += v.BinarySize()
+
+	}
+
+	return n
+}
+
+func (v *PendingTransactionStatus) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.ChainMarshalBinary(&v.TxId))
+
+	buffer.Write(encoding.StringMarshalBinary(v.Origin))
+
+	buffer.Write(encoding.UvarintMarshalBinary(v.KeyPageHeight))
+
+	buffer.Write(encoding.UvarintMarshalBinary(uint64(len(v.Signers))))
+	for i, v := range v.Signers {
+		_ = i
+		buffer.Write(encoding.BytesMarshalBinary(v))
+
+	}
+
+	return buffer.Bytes(), nil
+}
+
 func (v *RequestKeyPageIndex) MarshalBinary() ([]byte, error) {
 	var buffer bytes.Buffer
 
@@ -81,6 +221,14 @@ func (v *RequestKeyPageIndex) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+func (v *RequestPendingTransactions) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.StringMarshalBinary(v.Url))
+
+	return buffer.Bytes(), nil
+}
+
 func (v *ResponseKeyPageIndex) MarshalBinary() ([]byte, error) {
 	var buffer bytes.Buffer
 
@@ -93,6 +241,71 @@ func (v *ResponseKeyPageIndex) MarshalBinary() ([]byte, error) {
 	return buffer.Bytes(), nil
 }
 
+func (v *ResponsePendingTransactions) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.Write(encoding.StringMarshalBinary(v.KeyPage))
+
+	buffer.Write(encoding.UvarintMarshalBinary(v.Threshold))
+
+	buffer.Write(encoding.UvarintMarshalBinary(uint64(len(v.Transactions))))
+	for i, v := range v.Transactions {
+		_ = i
+		if b, err := v.MarshalBinary(); err != nil {
+			return nil, fmt.Errorf("error encoding Transactions[%d]: %w", i, err)
+		} else {
+			buffer.Write(b)
+		}
+
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func (v *PendingTransactionStatus) UnmarshalBinary(data []byte) error {
+	if x, err := encoding.ChainUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding TxId: %w", err)
+	} else {
+		v.TxId = x
+	}
+	data = data[encoding.ChainBinarySize(&v.TxId):]
+
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Origin: %w", err)
+	} else {
+		v.Origin = x
+	}
+	data = data[encoding.StringBinarySize(v.Origin):]
+
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding KeyPageHeight: %w", err)
+	} else {
+		v.KeyPageHeight = x
+	}
+	data = data[encoding.UvarintBinarySize(v.KeyPageHeight):]
+
+	var lenSigners uint64
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Signers: %w", err)
+	} else {
+		lenSigners = x
+	}
+	data = data[encoding.UvarintBinarySize(lenSigners):]
+
+	v.Signers = make([][]byte, lenSigners)
+	for i := range v.Signers {
+		if x, err := encoding.BytesUnmarshalBinary(data); err != nil {
+			return fmt.Errorf("error decoding Signers[%d]: %w", i, err)
+		} else {
+			v.Signers[i] = x
+		}
+		data = data[encoding.BytesBinarySize(v.Signers[i]):]
+
+	}
+
+	return nil
+}
+
 func (v *RequestKeyPageIndex) UnmarshalBinary(data []byte) error {
 	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
 		return fmt.Errorf("error decoding Url: %w", err)
@@ -111,6 +324,17 @@ func (v *RequestKeyPageIndex) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+func (v *RequestPendingTransactions) UnmarshalBinary(data []byte) error {
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Url: %w", err)
+	} else {
+		v.Url = x
+	}
+	data = data[encoding.StringBinarySize(v.Url):]
+
+	return nil
+}
+
 func (v *ResponseKeyPageIndex) UnmarshalBinary(data []byte) error {
 	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
 		return fmt.Errorf("error decoding KeyBook: %w", err)
@@ -136,6 +360,60 @@ func (v *ResponseKeyPageIndex) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+func (v *ResponsePendingTransactions) UnmarshalBinary(data []byte) error {
+	if x, err := encoding.StringUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding KeyPage: %w", err)
+	} else {
+		v.KeyPage = x
+	}
+	data = data[encoding.StringBinarySize(v.KeyPage):]
+
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Threshold: %w", err)
+	} else {
+		v.Threshold = x
+	}
+	data = data[encoding.UvarintBinarySize(v.Threshold):]
+
+	var lenTransactions uint64
+	if x, err := encoding.UvarintUnmarshalBinary(data); err != nil {
+		return fmt.Errorf("error decoding Transactions: %w", err)
+	} else {
+		lenTransactions = x
+	}
+	data = data[encoding.UvarintBinarySize(lenTransactions):]
+
+	v.Transactions = make([]*PendingTransactionStatus, lenTransactions)
+	for i := range v.Transactions {
+		x := new(PendingTransactionStatus)
+		if err := x.UnmarshalBinary(data); err != nil {
+			return fmt.Errorf("error decoding Transactions[%d]: %w", i, err)
+		}
+		data = data[x.BinarySize():]
+
+		v.Transactions[i] = x
+	}
+
+	return nil
+}
+
+func (v *PendingTransactionStatus) MarshalJSON() ([]byte, error) {
+	u := struct {
+		TxId          string    `json:"txId,omitempty"`
+		Origin        string    `json:"origin,omitempty"`
+		KeyPageHeight uint64    `json:"keyPageHeight"`
+		Signers       []*string `json:"signers,omitempty"`
+	}{}
+	u.TxId = encoding.ChainToJSON(v.TxId)
+	u.Origin = v.Origin
+	u.KeyPageHeight = v.KeyPageHeight
+	u.Signers = make([]*string, len(v.Signers))
+	for i, x := range v.Signers {
+		u.Signers[i] = encoding.BytesToJSON(x)
+	}
+	return json.Marshal(&u)
+}
+
 func (v *RequestKeyPageIndex) MarshalJSON() ([]byte, error) {
 	u := struct {
 		Url string  `json:"url,omitempty"`
@@ -146,6 +424,41 @@ func (v *RequestKeyPageIndex) MarshalJSON() ([]byte, error) {
 	return json.Marshal(&u)
 }
 
+func (v *PendingTransactionStatus) UnmarshalJSON(data []byte) error {
+	u := struct {
+		TxId          string    `json:"txId,omitempty"`
+		Origin        string    `json:"origin,omitempty"`
+		KeyPageHeight uint64    `json:"keyPageHeight"`
+		Signers       []*string `json:"signers,omitempty"`
+	}{}
+	u.TxId = encoding.ChainToJSON(v.TxId)
+	u.Origin = v.Origin
+	u.KeyPageHeight = v.KeyPageHeight
+	u.Signers = make([]*string, len(v.Signers))
+	for i, x := range v.Signers {
+		u.Signers[i] = encoding.BytesToJSON(x)
+	}
+	if err := json.Unmarshal(data, &u); err != nil {
+		return err
+	}
+	if x, err := encoding.ChainFromJSON(u.TxId); err != nil {
+		return fmt.Errorf("error decoding TxId: %w", err)
+	} else {
+		v.TxId = x
+	}
+	v.Origin = u.Origin
+	v.KeyPageHeight = u.KeyPageHeight
+	v.Signers = make([][]byte, len(u.Signers))
+	for i, x := range u.Signers {
+		if x, err := encoding.BytesFromJSON(x); err != nil {
+			return fmt.Errorf("error decoding Signers[%d]: %w", i, err)
+		} else {
+			v.Signers[i] = x
+		}
+	}
+	return nil
+}
+
 func (v *RequestKeyPageIndex) UnmarshalJSON(data []byte) error {
 	u := struct {
 		Url string  `json:"url,omitempty"`
@@ -1,11 +1,14 @@
 package transactions
 
-// ED25519Sig
-// Implements signing and validating ED25519 signatures
+// Signature
+// Implements signing and validating signatures. Every implementation is
+// identified by a SignatureType, which is written before the signature when
+// it is marshaled (see MarshalSignature).
 type Signature interface {
+	Type() SignatureType                                        // The type tag of this signature
 	GetNonce() uint64                                           // The nonce used by this signature
-	GetPublicKey() []byte                                       // 32 byte public key
-	GetSignature() []byte                                       // a set of 64 byte signatures
+	GetPublicKey() []byte                                       // The public key, encoded as defined by the type
+	GetSignature() []byte                                       // The signature of the nonce and message hash
 	Equal(s2 Signature) bool                                    //
 	Sign(nonce uint64, privateKey []byte, msghash []byte) error // sign the msghash with the nonce and privateKey
 	CanVerify(keyHash []byte) bool                              // Verify signature meets a KeyPage public key hash
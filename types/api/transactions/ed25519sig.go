@@ -20,6 +20,12 @@ type ED25519Sig struct {
 
 var _ Signature = (*ED25519Sig)(nil) // Verify at compile time that ED25519Sig implements the Signature interface
 
+// Type
+// Returns ED25519SignatureType
+func (e *ED25519Sig) Type() SignatureType {
+	return ED25519SignatureType
+}
+
 // GetNonce
 // Returns the nonce for this signature.  All signatures use a nonce, and this
 // is done to avoid replay attacks.
@@ -43,7 +49,8 @@ func (e *ED25519Sig) GetSignature() []byte {
 // Return true if the given Signature has the same Nonce, PublicKey,
 // and Signature
 func (e *ED25519Sig) Equal(e2 Signature) bool {
-	return e.Nonce == e2.GetNonce() &&
+	return e2.Type() == ED25519SignatureType &&
+		e.Nonce == e2.GetNonce() &&
 		bytes.Equal(e.PublicKey, e2.GetPublicKey()) && //  the publickey is the same and
 		bytes.Equal(e.Signature, e2.GetSignature()) //        the signature is the same
 }
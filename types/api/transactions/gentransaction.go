@@ -28,7 +28,7 @@ type GenTransaction struct {
 	Routing uint64 //            first 8 bytes of hash of identity [NOT marshaled]
 	ChainID []byte //            hash of chain URL [NOT marshaled]
 
-	Signature   []*ED25519Sig  // Signature(s) of the transaction
+	Signature   []Signature    // Signature(s) of the transaction
 	TxHash      []byte         // Hash of the Transaction
 	SigInfo     *SignatureInfo // Information that is included with the Transaction
 	Transaction []byte         // The transaction that follows
@@ -41,6 +41,9 @@ type GenTransaction struct {
 func (t *GenTransaction) Equal(t2 *GenTransaction) bool {
 	t.TransactionHash() //                                              make sure both have TransactionHashes computed
 	t2.TransactionHash()
+	if len(t.Signature) != len(t2.Signature) { //                       must have the same number of signatures
+		return false
+	}
 	for i, sig := range t.Signature { //                                for every signature
 		if !sig.Equal(t2.Signature[i]) { //                             check they are the same
 			return false //                                             return false if they are not.
@@ -80,8 +83,8 @@ func (t *GenTransaction) Marshal() (data []byte, err error) {
 		return nil, fmt.Errorf("must have 1 to 100 signatures") //          Otherwise we don't have a nonce to
 	} //                                                                    make the translation unique
 	data = common.Uint64Bytes(sLen) //                                      marshal the length, then each
-	for _, v := range t.Signature { //                                      signature struct.
-		if sig, err := v.Marshal(); err == nil { //
+	for _, v := range t.Signature { //                                      signature struct,
+		if sig, err := MarshalSignature(v); err == nil { //                     prefixed by its type.
 			data = append(data, sig...) //
 		} else { //
 			return data, err //
@@ -107,9 +110,9 @@ func (t *GenTransaction) UnMarshal(data []byte) (nextData []byte, err error) {
 		return nil, fmt.Errorf("signature length out of range") //
 	} //
 	for i := uint64(0); i < sLen; i++ { //                  Okay, now cycle for every signature
-		sig := new(ED25519Sig)                           // And unmarshal a signature
-		if data, err = sig.Unmarshal(data); err != nil { // If bad data is encountered,
-			return nil, err //                              complain
+		var sig Signature                                          // And unmarshal a signature
+		if sig, data, err = UnmarshalSignature(data); err != nil { // of whatever type it is. If bad data
+			return nil, err //                                      is encountered, complain
 		} //
 		t.Signature = append(t.Signature, sig) //           Add each signature to list, and repeat until all done
 	} //
@@ -173,14 +176,14 @@ func (t *GenTransaction) As(subTx encoding.BinaryUnmarshaler) error {
 	return subTx.UnmarshalBinary(t.Transaction)
 }
 
-func New(url string, height uint64, signer func(hash []byte) (*ED25519Sig, error), subTx encoding.BinaryMarshaler) (*GenTransaction, error) {
+func New(url string, height uint64, signer func(hash []byte) (Signature, error), subTx encoding.BinaryMarshaler) (*GenTransaction, error) {
 	return NewWith(&SignatureInfo{
 		URL:           url,
 		KeyPageHeight: height,
 	}, signer, subTx)
 }
 
-func NewWith(info *SignatureInfo, signer func(hash []byte) (*ED25519Sig, error), subTx encoding.BinaryMarshaler) (*GenTransaction, error) {
+func NewWith(info *SignatureInfo, signer func(hash []byte) (Signature, error), subTx encoding.BinaryMarshaler) (*GenTransaction, error) {
 	payload, err := subTx.MarshalBinary()
 	if err != nil {
 		return nil, err
@@ -188,7 +191,7 @@ func NewWith(info *SignatureInfo, signer func(hash []byte) (*ED25519Sig, error),
 
 	tx := new(GenTransaction)
 	tx.SigInfo = info
-	tx.Signature = make([]*ED25519Sig, 1)
+	tx.Signature = make([]Signature, 1)
 	tx.Transaction = payload
 
 	err = tx.SetRoutingChainID()
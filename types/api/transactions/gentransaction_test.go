@@ -100,7 +100,7 @@ func TestTokenTransaction(t *testing.T) {
 	if !to.ValidateSig() {
 		t.Error("failed to validate signature")
 	}
-	to.Signature[0].Nonce++
+	to.Signature[0].(*ED25519Sig).Nonce++
 	if to.ValidateSig() {
 		t.Error("failed to invalidate signature")
 	}
@@ -0,0 +1,135 @@
+package transactions
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/smt/common"
+	"github.com/AccumulateNetwork/accumulate/smt/managed"
+)
+
+// ReceiptSig
+// Proves that a synthetic transaction was produced by its source subnet. The
+// receipt ties the transaction ID to a root of the source's synthetic
+// transaction chain. It is attached to a synthetic transaction alongside the
+// signature of a validator of the source.
+type ReceiptSig struct {
+	Source  string          // URL of the subnet that produced the transaction
+	Receipt managed.Receipt // Receipt of the transaction ID in the source's synthetic transaction chain
+}
+
+var _ Signature = (*ReceiptSig)(nil) // Verify at compile time that ReceiptSig implements the Signature interface
+
+// Type
+// Returns ReceiptSignatureType
+func (e *ReceiptSig) Type() SignatureType {
+	return ReceiptSignatureType
+}
+
+// GetNonce
+// Receipts are not signed, so they do not have a nonce
+func (e *ReceiptSig) GetNonce() uint64 {
+	return 0
+}
+
+// GetPublicKey
+// Receipts are not signed, so they do not have a public key
+func (e *ReceiptSig) GetPublicKey() []byte {
+	return nil
+}
+
+// GetSignature
+// Receipts are not signed, so they do not have a signature
+func (e *ReceiptSig) GetSignature() []byte {
+	return nil
+}
+
+// Equal
+// Return true if the given Signature is a receipt with the same source and
+// receipt
+func (e *ReceiptSig) Equal(e2 Signature) bool {
+	r, ok := e2.(*ReceiptSig)
+	if !ok {
+		return false
+	}
+	d1, err1 := e.Marshal()
+	d2, err2 := r.Marshal()
+	return err1 == nil && err2 == nil && bytes.Equal(d1, d2)
+}
+
+// Sign
+// Receipts are built from the source's synthetic transaction chain, they
+// cannot be signed
+func (e *ReceiptSig) Sign(nonce uint64, privateKey []byte, hash []byte) error {
+	return errors.New("a receipt cannot be signed")
+}
+
+// CanVerify
+// Receipts are not signed by a key
+func (e *ReceiptSig) CanVerify(keyHash []byte) bool {
+	return false
+}
+
+// Verify
+// Returns true if the receipt is for the given hash and the receipt path
+// leads to its root. Verify does not check that the root is anchored.
+func (e *ReceiptSig) Verify(hash []byte) bool {
+	return bytes.Equal(e.Receipt.Element, hash) && e.Receipt.Validate()
+}
+
+// Marshal
+// Marshal a receipt.  The data can be unmarshaled
+func (e *ReceiptSig) Marshal() (data []byte, err error) {
+	if len(e.Source) == 0 || len(e.Receipt.Element) != 32 || len(e.Receipt.MDRoot) != 32 {
+		return nil, fmt.Errorf("poorly formed receipt")
+	}
+	data = append(data, common.SliceBytes([]byte(e.Source))...)
+	data = append(data, e.Receipt.Element...)
+	data = append(data, e.Receipt.MDRoot...)
+	data = append(data, common.Uint64Bytes(uint64(len(e.Receipt.Nodes)))...)
+	for _, node := range e.Receipt.Nodes {
+		if len(node.Hash) != 32 {
+			return nil, fmt.Errorf("poorly formed receipt")
+		}
+		if node.Right {
+			data = append(data, 1)
+		} else {
+			data = append(data, 0)
+		}
+		data = append(data, node.Hash...)
+	}
+	return data, nil
+}
+
+// Unmarshal
+// UnMarshal a receipt
+// further unmarshalling can be done with the returned data
+func (e *ReceiptSig) Unmarshal(data []byte) (nextData []byte, err error) {
+	defer func() {
+		if rErr := recover(); rErr != nil {
+			err = fmt.Errorf("error unmarshaling ReceiptSig %v", rErr)
+		}
+	}()
+	var source []byte
+	source, data = common.BytesSlice(data)
+	e.Source = string(source)
+	e.Receipt.Element = append(managed.Hash{}, data[:32]...)
+	data = data[32:]
+	e.Receipt.MDRoot = append(managed.Hash{}, data[:32]...)
+	data = data[32:]
+	var count uint64
+	count, data = common.BytesUint64(data)
+	if count > uint64(len(data))/33 {
+		return nil, fmt.Errorf("error unmarshaling ReceiptSig: too many nodes")
+	}
+	e.Receipt.Nodes = make([]*managed.Node, count)
+	for i := range e.Receipt.Nodes {
+		node := new(managed.Node)
+		node.Right = data[0] != 0
+		node.Hash = append(managed.Hash{}, data[1:33]...)
+		data = data[33:]
+		e.Receipt.Nodes[i] = node
+	}
+	return data, nil
+}
@@ -0,0 +1,72 @@
+package transactions
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/AccumulateNetwork/accumulate/smt/managed"
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
+	"github.com/AccumulateNetwork/accumulate/smt/storage/database"
+)
+
+func TestReceiptSig(t *testing.T) {
+	db, err := database.NewDBManager("memory", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager, err := managed.NewMerkleManager(db, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.SetKey(storage.MakeKey("receipt")); err != nil {
+		t.Fatal(err)
+	}
+
+	var hashes [][]byte
+	for i := 0; i < 7; i++ {
+		h := sha256.Sum256([]byte{byte(i)})
+		hashes = append(hashes, h[:])
+		manager.AddHash(h[:])
+	}
+	root := manager.MS.GetMDRoot()
+
+	receipt, err := managed.GetReceipt(manager, hashes[2], hashes[6])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !receipt.MDRoot.Equal(root) {
+		t.Fatal("receipt root does not match the root of the chain")
+	}
+
+	rs1 := &ReceiptSig{Source: "acc://bvn-test", Receipt: *receipt}
+	if !rs1.Verify(hashes[2]) {
+		t.Error("verify receipt failed")
+	}
+	if rs1.Verify(hashes[3]) {
+		t.Error("verify receipt of the wrong element succeeded")
+	}
+
+	sigData, err := MarshalSignature(rs1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs2, _, err := UnmarshalSignature(sigData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs2.Type() != ReceiptSignatureType || !rs2.Equal(rs1) {
+		t.Error("unmarshaled receipt does not match")
+	}
+	if !rs2.Verify(hashes[2]) {
+		t.Error("verify marshaled receipt failed")
+	}
+
+	rs2.(*ReceiptSig).Receipt.Nodes[0].Hash[0]++
+	if rs2.Verify(hashes[2]) {
+		t.Error("verify tampered receipt succeeded")
+	}
+
+	if rs1.Sign(1, nil, hashes[2]) == nil {
+		t.Error("signing a receipt succeeded")
+	}
+}
@@ -0,0 +1,149 @@
+package transactions
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/smt/common"
+)
+
+// RSAPSSSig
+// Implements signing and validating RSA-PSS signatures. The public key is
+// PKCS #1 DER encoded, and the signature is of the SHA-256 hash of the
+// nonce+hash.
+type RSAPSSSig struct {
+	Nonce     uint64 // Nonce of Signature
+	PublicKey []byte // PKCS #1 DER encoded public key
+	Signature []byte // RSA-PSS signature, the size of the modulus
+}
+
+var _ Signature = (*RSAPSSSig)(nil) // Verify at compile time that RSAPSSSig implements the Signature interface
+
+// The bounds of the size of the modulus of an RSA key. Keys smaller than the
+// minimum are not secure, and keys larger than the maximum are too expensive to
+// verify.
+const (
+	RSAMinKeyBits = 2048
+	RSAMaxKeyBits = 4096
+)
+
+// ParseRSAPublicKey
+// Parses a PKCS #1 DER encoded public key and checks that the size of its
+// modulus is between RSAMinKeyBits and RSAMaxKeyBits.
+func ParseRSAPublicKey(der []byte) (*rsa.PublicKey, error) {
+	key, err := x509.ParsePKCS1PublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("not an RSA public key: %v", err)
+	}
+	if n := key.N.BitLen(); n < RSAMinKeyBits || n > RSAMaxKeyBits {
+		return nil, fmt.Errorf("RSA key is %d bits, must be between %d and %d", n, RSAMinKeyBits, RSAMaxKeyBits)
+	}
+	return key, nil
+}
+
+// Type
+// Returns RSAPSSSignatureType
+func (e *RSAPSSSig) Type() SignatureType {
+	return RSAPSSSignatureType
+}
+
+// GetNonce
+// Returns the nonce for this signature.
+func (e *RSAPSSSig) GetNonce() uint64 {
+	return e.Nonce
+}
+
+// GetPublicKey
+// Return the DER encoded public key used by this signature
+func (e *RSAPSSSig) GetPublicKey() []byte {
+	return e.PublicKey
+}
+
+// GetSignature
+// Returns the signature used to sign the hash of some transaction
+func (e *RSAPSSSig) GetSignature() []byte {
+	return e.Signature
+}
+
+// Equal
+// Return true if the given Signature is an RSA-PSS signature with the same
+// Nonce, PublicKey, and Signature
+func (e *RSAPSSSig) Equal(e2 Signature) bool {
+	return e2.Type() == RSAPSSSignatureType &&
+		e.Nonce == e2.GetNonce() &&
+		bytes.Equal(e.PublicKey, e2.GetPublicKey()) &&
+		bytes.Equal(e.Signature, e2.GetSignature())
+}
+
+// Sign
+// Signs the nonce+hash with the given PKCS #1 DER encoded private key.
+func (e *RSAPSSSig) Sign(nonce uint64, privateKey []byte, hash []byte) error {
+	key, err := x509.ParsePKCS1PrivateKey(privateKey)
+	if err != nil {
+		return fmt.Errorf("privateKey is not an RSA private key: %v", err)
+	}
+	digest := sha256.Sum256(append(common.Uint64Bytes(nonce), hash...))
+	s, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], nil)
+	if err != nil {
+		return err
+	}
+	e.Nonce = nonce
+	e.PublicKey = x509.MarshalPKCS1PublicKey(&key.PublicKey)
+	e.Signature = s
+	return nil
+}
+
+// CanVerify
+// will check to see if the keyHash of the public key matches the key hash
+// of the key that is part of this transaction.
+func (e *RSAPSSSig) CanVerify(keyHash []byte) bool {
+	if keyHash == nil { //if no key hash is provided assume the caller has no specification to compare
+		return true
+	}
+	pubKeyHash := sha256.Sum256(e.PublicKey)
+	return bytes.Equal(keyHash, pubKeyHash[:])
+}
+
+// Verify
+// Returns true if the signature matches the nonce+hash and the size of the key
+// is within bounds.
+func (e *RSAPSSSig) Verify(hash []byte) bool {
+	key, err := ParseRSAPublicKey(e.PublicKey)
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256(append(common.Uint64Bytes(e.Nonce), hash...))
+	return rsa.VerifyPSS(key, crypto.SHA256, digest[:], e.Signature, nil) == nil
+}
+
+// Marshal
+// Marshal a signature.  The data can be unmarshaled
+func (e *RSAPSSSig) Marshal() (data []byte, err error) {
+	if len(e.PublicKey) == 0 || len(e.Signature) == 0 {
+		return nil, fmt.Errorf("poorly formed signature")
+	}
+	data = append(data, common.Uint64Bytes(e.Nonce)...)
+	data = append(data, common.SliceBytes(e.PublicKey)...)
+	data = append(data, common.SliceBytes(e.Signature)...)
+	return data, nil
+}
+
+// Unmarshal
+// UnMarshal a signature
+// further unmarshalling can be done with the returned data
+func (e *RSAPSSSig) Unmarshal(data []byte) (nextData []byte, err error) {
+	defer func() {
+		if rErr := recover(); rErr != nil {
+			err = fmt.Errorf("error unmarshaling RSAPSSSig %v", rErr)
+		}
+	}()
+	e.Nonce, data = common.BytesUint64(data)
+	e.PublicKey, data = common.BytesSlice(data)
+	e.Signature, data = common.BytesSlice(data)
+	return data, nil
+}
@@ -0,0 +1,68 @@
+package transactions
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"testing"
+)
+
+func TestRSAPSSSig(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey := x509.MarshalPKCS1PrivateKey(key)
+	message := []byte("this is a message of some import")
+	var nonce uint64 = 1
+
+	es1 := new(RSAPSSSig)
+	mh := sha256.Sum256(message)
+	if err := es1.Sign(nonce, privateKey, mh[:]); err != nil {
+		t.Error("signature failed")
+	}
+	if !es1.Verify(mh[:]) {
+		t.Error("verify signature message failed")
+	}
+
+	sigData, err := MarshalSignature(es1)
+	if err != nil {
+		t.Error(err)
+	}
+	es2, _, err := UnmarshalSignature(sigData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if es2.Type() != RSAPSSSignatureType || !es2.Equal(es1) {
+		t.Error("unmarshaled signature does not match")
+	}
+	if !es2.Verify(mh[:]) {
+		t.Error("verify signature marshaled message failed")
+	}
+
+	es1.Nonce++
+	if es1.Verify(mh[:]) {
+		t.Error("verify signature with the wrong nonce succeeded")
+	}
+}
+
+func TestRSAPSSSig_KeySize(t *testing.T) {
+	message := sha256.Sum256([]byte("this is a message of some import"))
+	for _, bits := range []int{1024, 2048, 4608} {
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sig := new(RSAPSSSig)
+		if err := sig.Sign(1, x509.MarshalPKCS1PrivateKey(key), message[:]); err != nil {
+			t.Fatal(err)
+		}
+
+		valid := bits >= RSAMinKeyBits && bits <= RSAMaxKeyBits
+		if sig.Verify(message[:]) != valid {
+			t.Errorf("a %d bit key should verify: %v", bits, valid)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package transactions
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/AccumulateNetwork/accumulate/smt/common"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// Secp256k1Sig
+// Implements signing and validating ECDSA signatures over the secp256k1
+// curve. The public key is the 33 byte compressed point, and the signature is
+// the 64 byte R || S of the SHA-256 hash of the nonce+hash, with S in the
+// lower half of the curve order.
+type Secp256k1Sig struct {
+	Nonce     uint64 // Nonce of Signature
+	PublicKey []byte // 33 byte compressed public key
+	Signature []byte // 64 byte R || S signature
+}
+
+var _ Signature = (*Secp256k1Sig)(nil) // Verify at compile time that Secp256k1Sig implements the Signature interface
+
+// Type
+// Returns Secp256k1SignatureType
+func (e *Secp256k1Sig) Type() SignatureType {
+	return Secp256k1SignatureType
+}
+
+// GetNonce
+// Returns the nonce for this signature.
+func (e *Secp256k1Sig) GetNonce() uint64 {
+	return e.Nonce
+}
+
+// GetPublicKey
+// Return the compressed public key used by this signature
+func (e *Secp256k1Sig) GetPublicKey() []byte {
+	return e.PublicKey
+}
+
+// GetSignature
+// Returns the signature used to sign the hash of some transaction
+func (e *Secp256k1Sig) GetSignature() []byte {
+	return e.Signature
+}
+
+// Equal
+// Return true if the given Signature is a secp256k1 signature with the same
+// Nonce, PublicKey, and Signature
+func (e *Secp256k1Sig) Equal(e2 Signature) bool {
+	return e2.Type() == Secp256k1SignatureType &&
+		e.Nonce == e2.GetNonce() &&
+		bytes.Equal(e.PublicKey, e2.GetPublicKey()) &&
+		bytes.Equal(e.Signature, e2.GetSignature())
+}
+
+// Sign
+// Signs the nonce+hash with the given 32 byte secp256k1 private key.
+func (e *Secp256k1Sig) Sign(nonce uint64, privateKey []byte, hash []byte) error {
+	if len(privateKey) != 32 {
+		return errors.New("privateKey is not a secp256k1 private key")
+	}
+	key := secp256k1.PrivKey(privateKey)
+	s, err := key.Sign(append(common.Uint64Bytes(nonce), hash...))
+	if err != nil {
+		return err
+	}
+	e.Nonce = nonce
+	e.PublicKey = key.PubKey().Bytes()
+	e.Signature = s
+	return nil
+}
+
+// CanVerify
+// will check to see if the keyHash of the public key matches the key hash
+// of the key that is part of this transaction.
+func (e *Secp256k1Sig) CanVerify(keyHash []byte) bool {
+	if keyHash == nil { //if no key hash is provided assume the caller has no specification to compare
+		return true
+	}
+	pubKeyHash := sha256.Sum256(e.PublicKey)
+	return bytes.Equal(keyHash, pubKeyHash[:])
+}
+
+// Verify
+// Returns true if the signature matches the nonce+hash.
+func (e *Secp256k1Sig) Verify(hash []byte) bool {
+	if len(e.PublicKey) != secp256k1.PubKeySize {
+		return false
+	}
+	return secp256k1.PubKey(e.PublicKey).VerifySignature(append(common.Uint64Bytes(e.Nonce), hash...), e.Signature)
+}
+
+// Marshal
+// Marshal a signature.  The data can be unmarshaled
+func (e *Secp256k1Sig) Marshal() (data []byte, err error) {
+	if len(e.PublicKey) != secp256k1.PubKeySize || len(e.Signature) != 64 {
+		return nil, fmt.Errorf("poorly formed signature")
+	}
+	data = append(data, common.Uint64Bytes(e.Nonce)...)
+	data = append(data, e.PublicKey...)
+	data = append(data, e.Signature...)
+	return data, nil
+}
+
+// Unmarshal
+// UnMarshal a signature
+// further unmarshalling can be done with the returned data
+func (e *Secp256k1Sig) Unmarshal(data []byte) (nextData []byte, err error) {
+	defer func() {
+		if rErr := recover(); rErr != nil {
+			err = fmt.Errorf("error unmarshaling Secp256k1Sig %v", rErr)
+		}
+	}()
+	e.Nonce, data = common.BytesUint64(data)
+	e.PublicKey = append([]byte{}, data[:secp256k1.PubKeySize]...)
+	data = data[secp256k1.PubKeySize:]
+	e.Signature = append([]byte{}, data[:64]...)
+	data = data[64:]
+	return data, nil
+}
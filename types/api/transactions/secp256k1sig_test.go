@@ -0,0 +1,43 @@
+package transactions
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func TestSecp256k1Sig(t *testing.T) {
+	privateKey := secp256k1.GenPrivKeySecp256k1([]byte{17, 26, 35, 44, 53, 62})
+	message := []byte("this is a message of some import")
+	var nonce uint64 = 1
+
+	es1 := new(Secp256k1Sig)
+	mh := sha256.Sum256(message)
+	if err := es1.Sign(nonce, privateKey, mh[:]); err != nil {
+		t.Error("signature failed")
+	}
+	if !es1.Verify(mh[:]) {
+		t.Error("verify signature message failed")
+	}
+
+	sigData, err := MarshalSignature(es1)
+	if err != nil {
+		t.Error(err)
+	}
+	es2, _, err := UnmarshalSignature(sigData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if es2.Type() != Secp256k1SignatureType || !es2.Equal(es1) {
+		t.Error("unmarshaled signature does not match")
+	}
+	if !es2.Verify(mh[:]) {
+		t.Error("verify signature marshaled message failed")
+	}
+
+	es1.Nonce++
+	if es1.Verify(mh[:]) {
+		t.Error("verify signature with the wrong nonce succeeded")
+	}
+}
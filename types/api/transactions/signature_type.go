@@ -0,0 +1,111 @@
+package transactions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SignatureType is the type tag that precedes every marshalled signature.
+type SignatureType uint8
+
+const (
+	UnknownSignatureType SignatureType = iota
+	ED25519SignatureType
+	Secp256k1SignatureType
+	RSAPSSSignatureType
+	ReceiptSignatureType
+)
+
+func SignatureTypeByName(s string) SignatureType {
+	switch strings.ToLower(s) {
+	case "ed25519":
+		return ED25519SignatureType
+	case "secp256k1":
+		return Secp256k1SignatureType
+	case "rsa-pss", "rsapss":
+		return RSAPSSSignatureType
+	case "receipt":
+		return ReceiptSignatureType
+	default:
+		return UnknownSignatureType
+	}
+}
+
+func (st SignatureType) String() string {
+	switch st {
+	case ED25519SignatureType:
+		return "ed25519"
+	case Secp256k1SignatureType:
+		return "secp256k1"
+	case RSAPSSSignatureType:
+		return "rsa-pss"
+	case ReceiptSignatureType:
+		return "receipt"
+	default:
+		return fmt.Sprintf("SignatureType:%d", st)
+	}
+}
+
+func (st SignatureType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(st.String())
+}
+
+func (st *SignatureType) UnmarshalJSON(b []byte) error {
+	var s *string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		*st = UnknownSignatureType
+		return nil
+	}
+
+	*st = SignatureTypeByName(*s)
+	if *st == UnknownSignatureType {
+		return fmt.Errorf("invalid signature type: %q", *s)
+	}
+	return nil
+}
+
+// NewSignature returns an empty signature of the given type.
+func NewSignature(typ SignatureType) (Signature, error) {
+	switch typ {
+	case ED25519SignatureType:
+		return new(ED25519Sig), nil
+	case Secp256k1SignatureType:
+		return new(Secp256k1Sig), nil
+	case RSAPSSSignatureType:
+		return new(RSAPSSSig), nil
+	case ReceiptSignatureType:
+		return new(ReceiptSig), nil
+	default:
+		return nil, fmt.Errorf("unknown signature type %v", typ)
+	}
+}
+
+// MarshalSignature marshals a signature prefixed with its type tag.
+func MarshalSignature(sig Signature) ([]byte, error) {
+	data, err := sig.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{byte(sig.Type())}, data...), nil
+}
+
+// UnmarshalSignature unmarshals a signature prefixed with its type tag.
+func UnmarshalSignature(data []byte) (Signature, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("missing signature type")
+	}
+	sig, err := NewSignature(SignatureType(data[0]))
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err = sig.Unmarshal(data[1:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return sig, data, nil
+}
@@ -54,8 +54,15 @@ const (
 	// ChainTypeSyntheticTransactions is a chain of synthetic transactions.
 	ChainTypeSyntheticTransactions ChainType = 13
 
+	// ChainTypeAcmeOracle is the ACME price oracle of a subnet.
+	ChainTypeAcmeOracle ChainType = 14
+
+	// ChainTypeExternalAnchor records the anchors of major blocks to an
+	// external ledger.
+	ChainTypeExternalAnchor ChainType = 15
+
 	// chainMax needs to be set to the last type in the list above
-	chainMax = ChainTypeSyntheticTransactions
+	chainMax = ChainTypeExternalAnchor
 )
 
 // ID returns the chain type ID
@@ -100,6 +107,10 @@ func (t ChainType) String() string {
 		return "liteDataAccount"
 	case ChainTypeSyntheticTransactions:
 		return "syntheticTransactions"
+	case ChainTypeAcmeOracle:
+		return "acmeOracle"
+	case ChainTypeExternalAnchor:
+		return "externalAnchor"
 	default:
 		return fmt.Sprintf("ChainType:%d", t)
 	}
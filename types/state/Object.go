@@ -1,7 +1,10 @@
 package state
 
 import (
+	"crypto/sha256"
 	"encoding"
+
+	"github.com/AccumulateNetwork/accumulate/smt/managed"
 )
 
 //maybe we should have Chain header then entry, rather than entry containing all the Headers
@@ -9,3 +12,34 @@ import (
 func (o *Object) As(entry encoding.BinaryUnmarshaler) error {
 	return entry.UnmarshalBinary(o.Entry)
 }
+
+// MerkleState returns the Merkle state of the object's chain, as recorded when
+// the object was stored.
+func (o *Object) MerkleState() *managed.MerkleState {
+	ms := new(managed.MerkleState)
+	ms.InitSha256()
+	ms.Count = int64(o.Height)
+	ms.Pending = make([]managed.Hash, len(o.Roots))
+	for i, root := range o.Roots {
+		if len(root) > 0 {
+			ms.Pending[i] = append(managed.Hash{}, root...)
+		}
+	}
+	return ms
+}
+
+// Anchor returns the entry of the anchor chain for the object. The entry is the
+// hash of the root of the object's chain and the hash of the object, so that
+// the object itself can be proven against the anchor chain.
+func (o *Object) Anchor() ([]byte, error) {
+	data, err := o.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	state := sha256.Sum256(data)
+
+	h := sha256.New()
+	h.Write(o.MerkleState().GetMDRoot())
+	h.Write(state[:])
+	return h.Sum(nil), nil
+}
@@ -184,6 +184,11 @@ func (s *StateDB) GetChainDataByEntryHash(chainId []byte, entryHash []byte) ([]b
 		return nil, nil, err
 	}
 
+	//the body of a pruned entry is gone, only its hash remains on the chain
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("data entry %X has been pruned: %w", entryHash, storage.ErrNotFound)
+	}
+
 	return entryHash, data, nil
 }
 
@@ -289,6 +294,35 @@ func (tx *DBTransaction) AddDataEntry(chainId *types.Bytes32, txid []byte, entry
 	return nil
 }
 
+// PruneDataEntry removes the body of a data entry. The entry hash remains on
+// the data chain, so the Merkle state is not affected. Pruning is local to the
+// node, so the BPT still records the hash of the body.
+func (tx *DBTransaction) PruneDataEntry(chainId []byte, entryHash []byte) {
+	tx.state.logDebug("PruneDataEntry", "chainId", logging.AsHex(chainId), "entryHash", logging.AsHex(entryHash))
+	tx.writeLocal(storage.MakeKey(bucketDataEntry, chainId, entryHash), []byte{})
+}
+
+// PrunePendingTx replaces the pending state of a transaction with a pruned
+// copy, which is written in place of the original. Pruning is local to the
+// node, so the BPT still records the digest of the original, which the pruned
+// copy shares. See pendingDigest.
+func (tx *DBTransaction) PrunePendingTx(txId []byte, txPending *Object) error {
+	tx.state.logDebug("PrunePendingTx", "txid", logging.AsHex(txId))
+
+	pendingHash, err := tx.state.dbMgr.Get(storage.MakeKey(bucketMainToPending, txId))
+	if err != nil {
+		return err
+	}
+
+	data, err := txPending.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	tx.writeLocal(storage.MakeKey(bucketPendingTx, pendingHash), data)
+	return nil
+}
+
 // AddTransaction queues (pending) transaction signatures and (optionally) an
 // accepted transaction for storage to their respective chains.
 func (tx *DBTransaction) AddTransaction(chainId *types.Bytes32, txId types.Bytes, txPending, txAccepted *Object) error {
@@ -529,7 +563,9 @@ func (tx *DBTransaction) writeTxs(mutex *sync.Mutex, group *sync.WaitGroup) erro
 				}
 			}
 			//store a list of txid to list of synth txid's
-			tx.state.dbMgr.PutBatch(storage.MakeKey(bucketTxToSynthTx, txn.TxId), synthData)
+			mutex.Lock()
+			tx.state.putState(storage.MakeKey(bucketTxToSynthTx, txn.TxId), synthData)
+			mutex.Unlock()
 		}
 
 		mutex.Lock()
@@ -546,14 +582,20 @@ func (tx *DBTransaction) writeTxs(mutex *sync.Mutex, group *sync.WaitGroup) erro
 		data, _ := txn.Object.MarshalBinary()
 		//hash it and add to the merkle state for the pending chain
 		pendingHash := sha256.Sum256(data)
+		digest, err := pendingDigest(data)
+		if err != nil {
+			return fmt.Errorf("invalid pending transaction %X: %v", txn.TxId, err)
+		}
 
 		mutex.Lock()
 		//Store the mapping of the Transaction hash to the pending transaction hash which can be used for
 		// validation so we can find the pending transaction
-		tx.state.dbMgr.PutBatch(storage.MakeKey("MainToPending", txn.TxId), pendingHash[:])
+		tx.state.putState(storage.MakeKey("MainToPending", txn.TxId), pendingHash[:])
 
 		//store the pending transaction by the pending tx hash
-		tx.state.dbMgr.PutBatch(storage.MakeKey(bucketPendingTx, pendingHash[:]), data)
+		key := storage.MakeKey(bucketPendingTx, pendingHash[:])
+		tx.state.dbMgr.PutBatch(key, data)
+		tx.state.bptMgr.Bpt.Insert(key, digest)
 		mutex.Unlock()
 	}
 
@@ -641,7 +683,7 @@ func (tx *DBTransaction) writeDataState(chainId *types.Bytes32) error {
 		//store the entry hash for the data
 		tx.state.logDebug("AddHash", "hash", logging.AsHex(entry.EntryHash))
 		mgr.AddEntry(entry.EntryHash)
-		tx.GetDB().PutBatch(storage.MakeKey(bucketDataEntry, chainId.Bytes(), entry.EntryHash), entry.Data)
+		tx.state.putState(storage.MakeKey(bucketDataEntry, chainId.Bytes(), entry.EntryHash), entry.Data)
 	}
 
 	// The bpt stores the root of the data merkle state
@@ -697,6 +739,7 @@ func (tx *DBTransaction) writeSynthTxnSigs() error {
 
 	var changeCount int
 	for _, sig := range sigs {
+		sig := sig // Do not alias the loop variable
 		if delMap[sig.Txid] {
 			tx.state.logInfo("Removing synth txn sig", "txid", logging.AsHex(sig.Txid))
 			changeCount++
@@ -752,6 +795,13 @@ func (tx *DBTransaction) writeBatches() {
 	tx.state.bptMgr.DBManager.EndBatch()
 }
 
+// WriteParameter stores a parameter of the subnet that is set at genesis, such
+// as its ID. Like every other consensus value, the parameter is recorded in the
+// BPT.
+func (s *StateDB) WriteParameter(name string, value []byte) {
+	s.putState(storage.MakeKey(name), value)
+}
+
 func (s *StateDB) SubnetID() (string, error) {
 	b, err := s.GetDB().Get(storage.MakeKey("SubnetID"))
 	if err != nil {
@@ -760,6 +810,19 @@ func (s *StateDB) SubnetID() (string, error) {
 	return string(b), nil
 }
 
+// PendingExpiration returns the number of blocks a transaction may wait for
+// signatures before it expires, as set at genesis.
+func (s *StateDB) PendingExpiration() (uint64, error) {
+	b, err := s.GetDB().Get(storage.MakeKey("PendingExpiration"))
+	if err != nil {
+		return 0, err
+	}
+	if len(b) != 8 {
+		return 0, fmt.Errorf("invalid pending expiration: want 8 bytes, got %d", len(b))
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
 func (s *StateDB) BlockIndex() (int64, error) {
 	mgr, err := s.MinorAnchorChain()
 	if err != nil {
@@ -909,10 +972,21 @@ func (tx *DBTransaction) commitTxWrites() {
 		return bytes.Compare(writeOrder[i][:], writeOrder[j][:]) < 0
 	})
 	for _, k := range writeOrder {
-		tx.state.GetDB().PutBatch(storage.MakeKey(k), tx.writes[k])
+		if tx.localWrites[k] {
+			tx.state.GetDB().PutBatch(storage.MakeKey(k), tx.writes[k])
+		} else {
+			tx.state.putState(k, tx.writes[k])
+		}
 	}
 }
 
+// putState writes a value to the database and records its hash in the BPT
+// under the same key.
+func (s *StateDB) putState(key storage.Key, value []byte) {
+	s.dbMgr.PutBatch(key, value)
+	s.bptMgr.Bpt.Insert(key, sha256.Sum256(value))
+}
+
 func (s *StateDB) RootHash() []byte {
 	h := s.bptMgr.Bpt.Root.Hash // Make a copy
 	return h[:]                 // Return a reference to the copy
@@ -926,6 +1000,9 @@ func (tx *DBTransaction) cleanupTx() {
 	for k := range tx.writes {
 		delete(tx.writes, k)
 	}
+	for k := range tx.localWrites {
+		delete(tx.localWrites, k)
+	}
 
 	//clear out the transactions after they have been processed
 	tx.transactions.validatedTx = nil
@@ -1,8 +1,11 @@
 package state
 
 import (
+	"bytes"
 	"crypto/sha256"
+	"sort"
 	"testing"
+	"time"
 
 	"github.com/AccumulateNetwork/accumulate/smt/storage"
 	"github.com/AccumulateNetwork/accumulate/types"
@@ -66,3 +69,32 @@ func TestDBTransaction_writeSynthTxnSigs(t *testing.T) {
 		})
 	}
 }
+
+func TestDBTransaction_KeepSynthTxnSigs(t *testing.T) {
+	db := new(StateDB)
+	require.NoError(t, db.Open("", true, false, nil))
+
+	sigs := make([]SyntheticSignature, 3)
+	for i := range sigs {
+		sigs[i].Txid = sha256.Sum256([]byte{byte(i)})
+		sigs[i].Nonce = uint64(i)
+	}
+	sort.Slice(sigs, func(i, j int) bool { return bytes.Compare(sigs[i].Txid[:], sigs[j].Txid[:]) < 0 })
+
+	tx := db.Begin()
+	for i := range sigs {
+		tx.AddSynthTxnSig(&sigs[i])
+	}
+	_, err := tx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	// Signatures that are not deleted are kept as they are
+	tx = db.Begin()
+	tx.DeleteSynthTxnSig(sigs[1].Txid)
+	_, err = tx.Commit(2, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	kept, err := db.GetSynthTxnSigs()
+	require.NoError(t, err)
+	require.Equal(t, []SyntheticSignature{sigs[0], sigs[2]}, kept)
+}
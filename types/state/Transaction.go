@@ -1,6 +1,8 @@
 package state
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 
@@ -55,7 +57,7 @@ type Transaction struct {
 
 type PendingTransaction struct {
 	ChainHeader
-	Signature        []*transactions.ED25519Sig
+	Signature        []transactions.Signature
 	TransactionState *TxState
 	Status           json.RawMessage `json:"status" form:"status" query:"status" validate:"required"`
 }
@@ -148,7 +150,7 @@ func (t *PendingTransaction) MarshalBinary() (data []byte, err error) {
 	sLen := uint64(len(t.Signature))
 	data = append(data, common.Uint64Bytes(sLen)...)
 	for _, v := range t.Signature {
-		if sig, err := v.Marshal(); err == nil {
+		if sig, err := transactions.MarshalSignature(v); err == nil {
 			data = append(data, sig...)
 		} else {
 			return data, err
@@ -190,8 +192,8 @@ func (t *PendingTransaction) UnmarshalBinary(data []byte) (err error) {
 	var sLen uint64                       //                Get how many signatures we have
 	sLen, data = common.BytesUint64(data) //                Of course, need it in an int of some sort
 	for i := uint64(0); i < sLen; i++ {   //                  Okay, now cycle for every signature
-		sig := new(transactions.ED25519Sig) // And unmarshal a signature
-		data, err = sig.Unmarshal(data)
+		var sig transactions.Signature // And unmarshal a signature
+		sig, data, err = transactions.UnmarshalSignature(data)
 		if err != nil { // If bad data is encountered,
 			return err //                              complain
 		} //
@@ -213,3 +215,64 @@ func (t *PendingTransaction) UnmarshalBinary(data []byte) (err error) {
 
 	return nil
 }
+
+// pendingDigest returns the hash of a pending transaction state object that is
+// recorded in the BPT. Nodes prune the signatures of a transaction once it is
+// no longer waiting for signatures and mark its status as pruned, each on its
+// own schedule. So the digest leaves out those signatures and the pruned flag,
+// and a pruned copy has the same digest as the original.
+func pendingDigest(data []byte) ([32]byte, error) {
+	obj := new(Object)
+	err := obj.UnmarshalBinary(data)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	pending := new(PendingTransaction)
+	err = pending.UnmarshalBinary(obj.Entry)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	var isPending bool
+	pending.Status, isPending = digestStatus(pending.Status)
+	if !isPending {
+		pending.Signature = nil
+	}
+
+	obj.Entry, err = pending.MarshalBinary()
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	data, err = obj.MarshalBinary()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
+
+// digestStatus removes the pruned flag from the status of a pending
+// transaction and re-encodes it with sorted keys, since pruning re-encodes the
+// status. It also returns whether the transaction is waiting for signatures.
+func digestStatus(status json.RawMessage) (json.RawMessage, bool) {
+	var fields map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(status))
+	dec.UseNumber()
+	err := dec.Decode(&fields)
+	if err != nil || fields == nil {
+		return status, false
+	}
+
+	isPending := fields["pending"] == true
+	delete(fields, "pruned")
+	if len(fields) == 0 {
+		return nil, isPending
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return status, isPending
+	}
+	return data, isPending
+}
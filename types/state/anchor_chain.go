@@ -46,7 +46,10 @@ func (ac *AnchorChainManager) AddSystemTxns(txids ...[32]byte) error {
 	return nil
 }
 
-func (ac *AnchorChainManager) Update(index int64, timestamp time.Time, chains [][32]byte) error {
+// Update adds an anchor for each updated chain to the anchor chain and updates
+// the record. The anchor of a chain whose state is given binds the state to
+// the root of the chain.
+func (ac *AnchorChainManager) Update(index int64, timestamp time.Time, chains [][32]byte, states map[[32]byte]*Object) error {
 	// Sort the chain IDs
 	sort.Slice(chains, func(i, j int) bool {
 		return bytes.Compare(chains[i][:], chains[j][:]) < 0
@@ -67,12 +70,21 @@ func (ac *AnchorChainManager) Update(index int64, timestamp time.Time, chains []
 
 	// Add an anchor for each updated chain to the anchor chain
 	for _, chainId := range chains {
-		chain, err := ac.Chain.state.ManageChain(chainId)
-		if err != nil {
-			return err
+		var anchor []byte
+		if obj := states[chainId]; obj != nil {
+			anchor, err = obj.Anchor()
+			if err != nil {
+				return fmt.Errorf("failed to anchor the state of %X: %v", chainId, err)
+			}
+		} else {
+			chain, err := ac.Chain.state.ManageChain(chainId)
+			if err != nil {
+				return err
+			}
+			anchor = chain.Anchor()
 		}
 
-		err = ac.Chain.AddEntry(chain.Anchor())
+		err = ac.Chain.AddEntry(anchor)
 		if err != nil {
 			return err
 		}
@@ -90,3 +102,30 @@ func (ac *AnchorChainManager) Update(index int64, timestamp time.Time, chains []
 
 	return nil
 }
+
+// Rollup adds the root of the minor anchor chain to the major anchor chain and
+// updates the record.
+func (ac *AnchorChainManager) Rollup(index int64, timestamp time.Time, minor *AnchorChainManager) error {
+	// Load the record
+	prev, err := ac.Record()
+	if errors.Is(err, storage.ErrNotFound) {
+		prev = new(Anchor)
+	} else if err != nil {
+		return err
+	}
+
+	// Make sure the block index is increasing
+	if prev.Index >= index {
+		panic(fmt.Errorf("Current major height is %d but the next major block height is %d!", prev.Index, index))
+	}
+
+	root := minor.Chain.Anchor()
+	err = ac.Chain.AddEntry(root)
+	if err != nil {
+		return err
+	}
+
+	record := &Anchor{Index: index, Timestamp: timestamp}
+	copy(record.ChainAnchor[:], root)
+	return ac.Chain.UpdateAs(record)
+}
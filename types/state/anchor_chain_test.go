@@ -0,0 +1,41 @@
+package state
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnchorChainManager_Rollup(t *testing.T) {
+	s := new(StateDB)
+	require.NoError(t, s.Open("", true, false, nil))
+
+	minor, err := s.MinorAnchorChain()
+	require.NoError(t, err)
+	major, err := s.MajorAnchorChain()
+	require.NoError(t, err)
+
+	for i := byte(0); i < 3; i++ {
+		h := sha256.Sum256([]byte{i})
+		require.NoError(t, minor.Chain.AddEntry(h[:]))
+	}
+
+	now := time.Now()
+	require.NoError(t, major.Rollup(3, now, minor))
+
+	// The major chain contains the root of the minor chain
+	require.Equal(t, int64(1), major.Height())
+	entry, err := major.Chain.Entry(0)
+	require.NoError(t, err)
+	require.Equal(t, minor.Chain.Anchor(), entry)
+
+	head, err := major.Record()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), head.Index)
+	require.Equal(t, minor.Chain.Anchor(), head.ChainAnchor[:])
+
+	// The height of major blocks must increase
+	require.Panics(t, func() { _ = major.Rollup(3, now, minor) })
+}
@@ -99,6 +99,13 @@ func (c *ChainManager) HeightOf(hash []byte) (int64, error) {
 	return c.merkle.GetElementIndex(hash)
 }
 
+// Receipt builds a receipt that proves that the entry is part of the chain as
+// of the anchor entry. The root of the receipt is the anchor of the chain at
+// the height of the anchor entry.
+func (c *ChainManager) Receipt(entry, anchor []byte) (*managed.Receipt, error) {
+	return managed.GetReceipt(c.merkle, entry, anchor)
+}
+
 // Anchor calculates the anchor of the current Merkle state.
 func (c *ChainManager) Anchor() []byte {
 	return c.merkle.MS.GetMDRoot()
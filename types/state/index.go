@@ -10,12 +10,40 @@ type Index string
 
 const (
 	DirectoryIndex Index = "Directory"
+	PendingIndex   Index = "Pending"
+	ScratchIndex   Index = "Scratch"
+	ReceiptIndex   Index = "Receipt"
+	MirrorIndex    Index = "Mirror"
+	PendingTxIndex Index = "PendingTx"
+
+	ExternalAnchorIndex Index = "ExternalAnchor"
+
+	// PruneIndex records how far the node has pruned. Pruning is configured
+	// per node, so the index is local to the node. It is not recorded in the
+	// BPT and it is not restored from a snapshot.
+	PruneIndex Index = "Prune"
 )
 
+// IsLocal returns true if the index is local to the node. The entries of every
+// other index are recorded in the BPT.
+func (i Index) IsLocal() bool {
+	return i == PruneIndex
+}
+
 func (tx *DBTransaction) Write(key storage.Key, value []byte) {
 	tx.state.mutex.Lock()
 	tx.state.mutex.Unlock()
 	tx.writes[key] = value
+	delete(tx.localWrites, key)
+}
+
+// writeLocal writes a value that is local to the node. Unlike Write, the value
+// is not recorded in the BPT.
+func (tx *DBTransaction) writeLocal(key storage.Key, value []byte) {
+	tx.state.mutex.Lock()
+	tx.state.mutex.Unlock()
+	tx.writes[key] = value
+	tx.localWrites[key] = true
 }
 
 func (tx *DBTransaction) Read(key storage.Key) ([]byte, error) {
@@ -35,7 +63,11 @@ func (db *StateDB) Read(key storage.Key) ([]byte, error) {
 func (tx *DBTransaction) WriteIndex(index Index, chain []byte, key interface{}, value []byte) {
 	k := storage.MakeKey(string(index), chain, key)
 	tx.state.logDebug("WriteIndex", "index", string(index), "chain", hex.EncodeToString(chain), "key", key, "value", hex.EncodeToString(value), "computed", hex.EncodeToString(k[:]))
-	tx.Write(k, value)
+	if index.IsLocal() {
+		tx.writeLocal(k, value)
+	} else {
+		tx.Write(k, value)
+	}
 }
 
 func (tx *DBTransaction) GetIndex(index Index, chain []byte, key interface{}) ([]byte, error) {
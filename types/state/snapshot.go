@@ -0,0 +1,400 @@
+package state
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/AccumulateNetwork/accumulate/smt/managed"
+	"github.com/AccumulateNetwork/accumulate/smt/pmt"
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
+	"github.com/AccumulateNetwork/accumulate/smt/storage/database"
+	"github.com/AccumulateNetwork/accumulate/smt/storage/memory"
+)
+
+// snapshotBatchSize is the number of key-value pairs that are written to the
+// database at once when a snapshot is restored.
+const snapshotBatchSize = 10000
+
+// snapshotMaxValueSize is the largest value a snapshot may contain. Snapshots
+// are received from peers, so the length of a value cannot be trusted.
+const snapshotMaxValueSize = 16 << 20
+
+// Snapshot captures the current state of the database. The returned function
+// writes every key-value pair of the captured state to the writer, sorted by
+// key. Each pair is written as the key, the length of the value as a uvarint,
+// and the value. The function can be called while later blocks are committed,
+// and it must be called exactly once.
+func (s *StateDB) Snapshot() (func(io.Writer) error, error) {
+	s.Sync()
+	s.mutex.Lock()
+	view, err := s.dbMgr.DB.View()
+	s.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer) error {
+		defer view.Discard()
+
+		var buf [binary.MaxVarintLen64]byte
+		return view.ForEach(func(key storage.Key, value []byte) error {
+			_, err := w.Write(key[:])
+			if err != nil {
+				return err
+			}
+
+			n := binary.PutUvarint(buf[:], uint64(len(value)))
+			_, err = w.Write(buf[:n])
+			if err != nil {
+				return err
+			}
+
+			_, err = w.Write(value)
+			return err
+		})
+	}, nil
+}
+
+// ReadSnapshot replaces the contents of the database with a snapshot written
+// by Snapshot and reloads the state. Only what the BPT covers is restored, so
+// the snapshot is verified against the root hash instead of trusted. If the
+// snapshot cannot be read or verified, the database is cleared.
+func (s *StateDB) ReadSnapshot(r io.Reader, rootHash []byte) error {
+	s.Sync()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.readSnapshot(r)
+	if err == nil && !bytes.Equal(s.RootHash(), rootHash) {
+		err = fmt.Errorf("root hash %X does not match %X", s.RootHash(), rootHash)
+	}
+	if err == nil {
+		return nil
+	}
+
+	if err := s.clear(); err != nil {
+		return fmt.Errorf("failed to clear the database: %v", err)
+	}
+	return err
+}
+
+func (s *StateDB) readSnapshot(r io.Reader) error {
+	// The snapshot is read into a staging database, and is then restored from
+	// there into an empty database
+	staged := new(memory.DB)
+	err := staged.InitDB("", nil)
+	if err != nil {
+		return err
+	}
+
+	rd := bufio.NewReader(r)
+	batch := make(map[storage.Key][]byte, snapshotBatchSize)
+	for {
+		var key storage.Key
+		_, err := io.ReadFull(rd, key[:])
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read key: %v", err)
+		}
+
+		n, err := binary.ReadUvarint(rd)
+		if err != nil {
+			return fmt.Errorf("failed to read value length of %X: %v", key, err)
+		}
+
+		if n > snapshotMaxValueSize {
+			return fmt.Errorf("value of %X is too large: %d > %d", key, n, snapshotMaxValueSize)
+		}
+
+		// Do not allocate more than what has actually been read
+		value := new(bytes.Buffer)
+		_, err = io.CopyN(value, rd, int64(n))
+		if err != nil {
+			return fmt.Errorf("failed to read value of %X: %v", key, err)
+		}
+
+		batch[key] = value.Bytes()
+		if len(batch) < snapshotBatchSize {
+			continue
+		}
+
+		err = staged.EndBatch(batch)
+		if err != nil {
+			return err
+		}
+		batch = make(map[storage.Key][]byte, snapshotBatchSize)
+	}
+
+	err = staged.EndBatch(batch)
+	if err != nil {
+		return err
+	}
+
+	err = s.clear()
+	if err != nil {
+		return err
+	}
+
+	err = s.restore(staged)
+	if err != nil {
+		return err
+	}
+
+	return s.reload()
+}
+
+// restore rebuilds the state from a snapshot that has been read into a staging
+// database. Every entry of the staged BPT is inserted into the BPT, and the
+// value it covers is restored once its hash matches:
+//
+//   - Chain state records, transactions, staged synthetic transactions, and
+//     the records of chain managers are restored, and the Merkle chains of the
+//     chain states and records are rebuilt from their entries.
+//   - Indexes and other values that are recorded under their own key are
+//     restored, including pending transactions whose signatures have been
+//     pruned, which match by their digest.
+//   - The Merkle chains of data chains are rebuilt, and the bodies of their
+//     entries are restored. A body that has been pruned is restored empty.
+//
+// Anything else in the snapshot, such as what is local to the node that wrote
+// it, is left out. If any entry of the BPT is not matched, the snapshot is
+// rejected. The caller compares the resulting root hash.
+func (s *StateDB) restore(staged storage.KeyValueDB) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid snapshot: %v", r)
+		}
+	}()
+
+	rs := &snapshotRestorer{state: s, staged: new(database.Manager)}
+	rs.staged.InitWithDB(staged)
+
+	rs.leaves = map[storage.Key][32]byte{}
+	err = pmt.NewBPTManager(rs.staged).ForEach(func(key, hash [32]byte) error {
+		rs.leaves[key] = hash
+		s.bptMgr.Bpt.Insert(key, hash)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for key, hash := range rs.leaves {
+		ok, err := rs.restoreEntry(key, hash)
+		if err != nil {
+			return err
+		}
+		if ok {
+			delete(rs.leaves, key)
+		}
+	}
+
+	for _, chainId := range rs.chains {
+		err = rs.restoreDataChain(chainId)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(rs.leaves) > 0 {
+		return fmt.Errorf("%d entries of the BPT do not match any value of the snapshot", len(rs.leaves))
+	}
+
+	s.bptMgr.Bpt.Update()
+	s.dbMgr.EndBatch()
+	return nil
+}
+
+// snapshotRestorer restores the values of a staged snapshot that match the
+// entries of its BPT.
+type snapshotRestorer struct {
+	state  *StateDB
+	staged *database.Manager
+	leaves map[storage.Key][32]byte
+	chains [][32]byte
+	count  int
+}
+
+// restoreEntry restores the value covered by an entry of the BPT. It returns
+// false if no value matches, which is the case for the entries of data chains.
+func (rs *snapshotRestorer) restoreEntry(key, hash [32]byte) (bool, error) {
+	// A chain state record
+	if value, ok := rs.match(storage.MakeKey(bucketEntry, key), hash); ok {
+		rs.chains = append(rs.chains, key)
+		return true, rs.restoreChain(storage.MakeKey(key[:]), value)
+	}
+
+	// A transaction or a staged synthetic transaction
+	if _, ok := rs.match(storage.MakeKey(bucketTx, key), hash); ok {
+		return true, nil
+	}
+	if _, ok := rs.match(storage.MakeKey(bucketStagedSynthTx, "", key), hash); ok {
+		return true, nil
+	}
+
+	// The record of a chain manager
+	if value, ok := rs.match(storage.Key(key).Append("Record"), hash); ok {
+		return true, rs.restoreChain(key, value)
+	}
+
+	// A value recorded under its own key
+	if _, ok := rs.match(key, hash); ok {
+		return true, nil
+	}
+
+	// A pending transaction, which may have been pruned
+	value, err := rs.staged.Get(key)
+	if err != nil {
+		return false, nil
+	}
+	digest, err := pendingDigest(value)
+	if err != nil || digest != hash {
+		return false, nil
+	}
+	rs.put(key, value)
+	return true, nil
+}
+
+// restoreDataChain rebuilds the Merkle chain of a data chain and restores the
+// bodies of its entries that have been pruned. It does nothing if the chain is
+// not a data chain.
+func (rs *snapshotRestorer) restoreDataChain(chainId [32]byte) error {
+	key := storage.MakeKey(bucketDataEntry, chainId)
+	anchor, ok := rs.leaves[key]
+	if !ok {
+		return nil
+	}
+
+	data, err := rs.staged.Get(key.Append("Head"))
+	if err != nil {
+		return fmt.Errorf("missing head of data chain %X: %v", chainId, err)
+	}
+	head := new(managed.MerkleState)
+	err = head.UnMarshal(data)
+	if err != nil {
+		return fmt.Errorf("invalid head of data chain %X: %v", chainId, err)
+	}
+
+	ms, err := rs.replayChain(key, head.Count)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(ms.GetMDRoot(), anchor[:]) {
+		return fmt.Errorf("data chain %X does not match its anchor", chainId)
+	}
+	delete(rs.leaves, key)
+
+	for i := int64(0); i < head.Count; i++ {
+		entryHash, err := rs.staged.Get(key.Append("Element", i))
+		if err != nil {
+			return err
+		}
+
+		key := storage.MakeKey(bucketDataEntry, chainId, entryHash)
+		if _, ok := rs.leaves[key]; !ok {
+			continue
+		}
+		body, err := rs.staged.Get(key)
+		if err != nil || len(body) > 0 {
+			continue
+		}
+		rs.put(key, body)
+		delete(rs.leaves, key)
+	}
+	return nil
+}
+
+// restoreChain rebuilds the Merkle chain of a chain state record or the record
+// of a chain manager, and checks it against the roots of the record.
+func (rs *snapshotRestorer) restoreChain(key storage.Key, data []byte) error {
+	obj := new(Object)
+	err := obj.UnmarshalBinary(data)
+	if err != nil {
+		return fmt.Errorf("invalid record %X: %v", key, err)
+	}
+
+	want := obj.MerkleState()
+	got, err := rs.replayChain(key, want.Count)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(got.GetMDRoot(), want.GetMDRoot()) {
+		return fmt.Errorf("chain %X does not match its record", key)
+	}
+	return nil
+}
+
+// replayChain adds the staged entries of a Merkle chain to the chain, up to the
+// given height.
+func (rs *snapshotRestorer) replayChain(key storage.Key, height int64) (*managed.MerkleState, error) {
+	mm, err := rs.state.merkleMgr.ManageChain(key)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := int64(0); i < height; i++ {
+		entry, err := rs.staged.Get(key.Append("Element", i))
+		if err != nil {
+			return nil, fmt.Errorf("missing entry %d of chain %X: %v", i, key, err)
+		}
+		if len(entry) != sha256.Size {
+			return nil, fmt.Errorf("invalid entry %d of chain %X", i, key)
+		}
+
+		mm.AddHash(entry)
+		rs.flush()
+	}
+	return mm.MS, nil
+}
+
+// match restores the staged value of the key if its hash matches.
+func (rs *snapshotRestorer) match(key storage.Key, hash [32]byte) ([]byte, bool) {
+	value, err := rs.staged.Get(key)
+	if err != nil || sha256.Sum256(value) != hash {
+		return nil, false
+	}
+
+	rs.put(key, value)
+	return value, true
+}
+
+func (rs *snapshotRestorer) put(key storage.Key, value []byte) {
+	rs.state.dbMgr.PutBatch(key, value)
+	rs.flush()
+}
+
+// flush writes the restored values to the database every snapshotBatchSize
+// writes.
+func (rs *snapshotRestorer) flush() {
+	rs.count++
+	if rs.count < snapshotBatchSize {
+		return
+	}
+	rs.state.dbMgr.EndBatch()
+	rs.count = 0
+}
+
+// clear removes everything from the database and reloads the state.
+func (s *StateDB) clear() error {
+	err := s.dbMgr.DB.Clear()
+	if err != nil {
+		return err
+	}
+
+	return s.reload()
+}
+
+// reload reloads the BPT and the Merkle state from the database.
+func (s *StateDB) reload() (err error) {
+	s.dbMgr.ClearCache()
+	s.bptMgr = pmt.NewBPTManager(s.dbMgr)
+	s.merkleMgr, err = managed.NewMerkleManager(s.dbMgr, markPower)
+	return err
+}
@@ -0,0 +1,220 @@
+package state
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/AccumulateNetwork/accumulate/smt/storage"
+	"github.com/AccumulateNetwork/accumulate/types"
+	"github.com/AccumulateNetwork/accumulate/types/api/transactions"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateDB_Snapshot(t *testing.T) {
+	src := new(StateDB)
+	require.NoError(t, src.Open("", true, false, nil))
+
+	adi := NewIdentityState("foo")
+	data, err := adi.MarshalBinary()
+	require.NoError(t, err)
+	chainId := types.Bytes32(sha256.Sum256([]byte(t.Name())))
+
+	tx := src.Begin()
+	tx.AddStateEntry(&chainId, new(types.Bytes32), &Object{Entry: data})
+	_, err = tx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	// Write a snapshot
+	write := func() *bytes.Buffer {
+		fn, err := src.Snapshot()
+		require.NoError(t, err)
+		buf := new(bytes.Buffer)
+		require.NoError(t, fn(buf))
+		return buf
+	}
+	buf := write()
+
+	// Snapshots are deterministic
+	require.Equal(t, buf.Bytes(), write().Bytes())
+
+	// A snapshot is not affected by blocks committed after it is captured
+	fn, err := src.Snapshot()
+	require.NoError(t, err)
+	root := src.RootHash()
+	tx = src.Begin()
+	otherId := types.Bytes32(sha256.Sum256([]byte("other")))
+	tx.AddStateEntry(&otherId, new(types.Bytes32), &Object{Entry: data})
+	_, err = tx.Commit(2, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+	buf2 := new(bytes.Buffer)
+	require.NoError(t, fn(buf2))
+	require.Equal(t, buf.Bytes(), buf2.Bytes())
+
+	// Restore the snapshot to an empty database
+	dst := new(StateDB)
+	require.NoError(t, dst.Open("", true, false, nil))
+	require.NoError(t, dst.ReadSnapshot(bytes.NewReader(buf.Bytes()), root))
+	require.Equal(t, root, dst.RootHash())
+
+	height, err := dst.BlockIndex()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), height)
+
+	restored := new(AdiState)
+	_, err = dst.Begin().LoadChainAs(chainId[:], restored)
+	require.NoError(t, err)
+	require.Equal(t, adi.ChainUrl, restored.ChainUrl)
+
+	// A truncated snapshot is rejected and the database is cleared
+	require.Error(t, dst.ReadSnapshot(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), root))
+	_, err = dst.BlockIndex()
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	// A snapshot with a value that is too large is rejected without
+	// allocating it
+	var huge bytes.Buffer
+	huge.Write(make([]byte, len(storage.Key{})))
+	huge.Write([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01})
+	require.Error(t, dst.ReadSnapshot(&huge, root))
+	_, err = dst.BlockIndex()
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	// A snapshot that does not match the root hash is rejected and the
+	// database is cleared
+	require.Error(t, dst.ReadSnapshot(bytes.NewReader(buf.Bytes()), make([]byte, 32)))
+	_, err = dst.BlockIndex()
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestStateDB_SnapshotVerify(t *testing.T) {
+	src := new(StateDB)
+	require.NoError(t, src.Open("", true, false, nil))
+
+	adi := NewIdentityState("foo")
+	data, err := adi.MarshalBinary()
+	require.NoError(t, err)
+	chainId := types.Bytes32(sha256.Sum256([]byte(t.Name())))
+	txid := sha256.Sum256([]byte("txn"))
+	kept, pruned := []byte("kept"), []byte("pruned")
+	keptHash, prunedHash := sha256.Sum256(kept), sha256.Sum256(pruned)
+
+	pending := new(PendingTransaction)
+	pending.ChainHeader.SetHeader("acc://foo", types.ChainTypePendingTransaction)
+	pending.Signature = []transactions.Signature{&transactions.ED25519Sig{Nonce: 1, PublicKey: make([]byte, 32), Signature: make([]byte, 64)}}
+	pending.TransactionState = &TxState{SigInfo: &transactions.SignatureInfo{URL: "acc://foo"}, Transaction: &types.Bytes{1}}
+	pending.Status = []byte(`{"code":"1"}`)
+	pendingObj := new(Object)
+	pendingObj.Entry, err = pending.MarshalBinary()
+	require.NoError(t, err)
+
+	// Write a data chain, a pending transaction, and indexes
+	tx := src.Begin()
+	require.NoError(t, tx.AddDataEntry(&chainId, txid[:], keptHash[:], kept, &Object{Entry: data}))
+	require.NoError(t, tx.AddDataEntry(&chainId, txid[:], prunedHash[:], pruned, &Object{Entry: data}))
+	require.NoError(t, tx.AddTransaction(&chainId, txid[:], pendingObj, nil))
+	tx.WriteIndex(ReceiptIndex, chainId[:], "foo", []byte("receipt"))
+	_, err = tx.Commit(1, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	// Prune the data entry and the pending transaction
+	pending.Signature = nil
+	pending.Status = []byte(`{"code":"1","pruned":true}`)
+	pendingObj.Entry, err = pending.MarshalBinary()
+	require.NoError(t, err)
+	tx = src.Begin()
+	tx.PruneDataEntry(chainId[:], prunedHash[:])
+	require.NoError(t, tx.PrunePendingTx(txid[:], pendingObj))
+	tx.WriteIndex(PruneIndex, nil, "Scratch", []byte("local"))
+	_, err = tx.Commit(2, time.Unix(0, 0), nil)
+	require.NoError(t, err)
+
+	fn, err := src.Snapshot()
+	require.NoError(t, err)
+	buf := new(bytes.Buffer)
+	require.NoError(t, fn(buf))
+	root := src.RootHash()
+
+	// Forge a value that the BPT does not cover
+	forged := readSnapshotPairs(t, buf.Bytes())
+	forged[storage.MakeKey("Forged")] = []byte("forged")
+
+	dst := new(StateDB)
+	require.NoError(t, dst.Open("", true, false, nil))
+	require.NoError(t, dst.ReadSnapshot(writeSnapshotPairs(forged), root))
+	require.Equal(t, root, dst.RootHash())
+
+	// The pruned values are restored as they are
+	_, body, err := dst.GetChainDataByEntryHash(chainId[:], keptHash[:])
+	require.NoError(t, err)
+	require.Equal(t, kept, body)
+	_, _, err = dst.GetChainDataByEntryHash(chainId[:], prunedHash[:])
+	require.ErrorIs(t, err, storage.ErrNotFound)
+	data, err = dst.GetPendingTx(txid[:])
+	require.NoError(t, err)
+	restored := new(Object)
+	require.NoError(t, restored.UnmarshalBinary(data))
+	require.Equal(t, pendingObj.Entry, restored.Entry)
+
+	// Consensus indexes are restored, while local indexes and values that
+	// are not covered are not
+	receipt, err := dst.GetIndex(ReceiptIndex, chainId[:], "foo")
+	require.NoError(t, err)
+	require.Equal(t, []byte("receipt"), receipt)
+	_, err = dst.GetIndex(PruneIndex, nil, "Scratch")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+	_, err = dst.Read(storage.MakeKey("Forged"))
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	// A snapshot with a tampered index is rejected
+	tampered := readSnapshotPairs(t, buf.Bytes())
+	tampered[storage.MakeKey(string(ReceiptIndex), chainId[:], "foo")] = []byte("tampered")
+	require.Error(t, dst.ReadSnapshot(writeSnapshotPairs(tampered), root))
+
+	// A snapshot with a tampered data entry is rejected
+	tampered = readSnapshotPairs(t, buf.Bytes())
+	tampered[storage.MakeKey(bucketDataEntry, chainId[:], keptHash[:])] = []byte("tampered")
+	require.Error(t, dst.ReadSnapshot(writeSnapshotPairs(tampered), root))
+	_, err = dst.BlockIndex()
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+// readSnapshotPairs reads the key-value pairs of a snapshot.
+func readSnapshotPairs(t *testing.T, data []byte) map[storage.Key][]byte {
+	pairs := map[storage.Key][]byte{}
+	rd := bytes.NewReader(data)
+	for rd.Len() > 0 {
+		var key storage.Key
+		_, err := io.ReadFull(rd, key[:])
+		require.NoError(t, err)
+		n, err := binary.ReadUvarint(rd)
+		require.NoError(t, err)
+		value := make([]byte, n)
+		_, err = io.ReadFull(rd, value)
+		require.NoError(t, err)
+		pairs[key] = value
+	}
+	return pairs
+}
+
+// writeSnapshotPairs writes key-value pairs as a snapshot.
+func writeSnapshotPairs(pairs map[storage.Key][]byte) *bytes.Buffer {
+	keys := make([]storage.Key, 0, len(pairs))
+	for key := range pairs {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i][:], keys[j][:]) < 0 })
+
+	buf := new(bytes.Buffer)
+	var n [binary.MaxVarintLen64]byte
+	for _, key := range keys {
+		buf.Write(key[:])
+		buf.Write(n[:binary.PutUvarint(n[:], uint64(len(pairs[key])))])
+		buf.Write(pairs[key])
+	}
+	return buf
+}
@@ -1,6 +1,8 @@
 package state
 
 import (
+	"bytes"
+
 	"github.com/AccumulateNetwork/accumulate/smt/storage"
 	"github.com/AccumulateNetwork/accumulate/smt/storage/database"
 	"github.com/AccumulateNetwork/accumulate/types"
@@ -11,6 +13,7 @@ type DBTransaction struct {
 	dataUpdates  map[types.Bytes32]*dataBlockUpdates
 	updates      map[types.Bytes32]*blockUpdates
 	writes       map[storage.Key][]byte
+	localWrites  map[storage.Key]bool
 	addSynthSigs []*SyntheticSignature
 	delSynthSigs [][32]byte
 	transactions transactionLists
@@ -23,6 +26,7 @@ func (s *StateDB) Begin() *DBTransaction {
 	dbTx.updates = make(map[types.Bytes32]*blockUpdates)
 	dbTx.dataUpdates = make(map[types.Bytes32]*dataBlockUpdates)
 	dbTx.writes = map[storage.Key][]byte{}
+	dbTx.localWrites = map[storage.Key]bool{}
 	dbTx.transactions.reset()
 	return dbTx
 }
@@ -38,6 +42,22 @@ func (tx *DBTransaction) DeleteSynthTxnSig(txid [32]byte) {
 	tx.delSynthSigs = append(tx.delSynthSigs, txid)
 }
 
+// GetPendingTx returns the pending state of a transaction. Pending states that
+// have been added to the DB transaction but not committed take precedence.
+func (tx *DBTransaction) GetPendingTx(txId []byte) ([]byte, error) {
+	tx.state.mutex.Lock()
+	for i := len(tx.transactions.pendingTx) - 1; i >= 0; i-- {
+		txn := tx.transactions.pendingTx[i]
+		if bytes.Equal(txn.TxId, txId) {
+			tx.state.mutex.Unlock()
+			return txn.Object.MarshalBinary()
+		}
+	}
+	tx.state.mutex.Unlock()
+
+	return tx.state.GetPendingTx(txId)
+}
+
 // GetPersistentEntry calls StateDB.GetPersistentEntry(...).
 func (tx *DBTransaction) GetPersistentEntry(chainId []byte, verify bool) (*Object, error) {
 	return tx.state.GetPersistentEntry(chainId, verify)
@@ -12,6 +12,14 @@ func (s *StateDB) MinorAnchorChain() (*AnchorChainManager, error) {
 	return &AnchorChainManager{*mgr}, nil
 }
 
+func (s *StateDB) MajorAnchorChain() (*AnchorChainManager, error) {
+	mgr, err := s.ManageChain("Anchor", "Major")
+	if err != nil {
+		return nil, err
+	}
+	return &AnchorChainManager{*mgr}, nil
+}
+
 func (s *StateDB) SynthTxidChain() (*SynthChainManager, error) {
 	mgr, err := s.ManageChain("Synthetic", "Txid")
 	if err != nil {
@@ -46,10 +54,12 @@ func (tx *DBTransaction) writeSynthChain(blockIndex int64) error {
 }
 
 func (tx *DBTransaction) writeAnchorChain(blockIndex int64, timestamp time.Time) error {
-	// Collect chain IDs
+	// Collect chain IDs and states
 	chains := make([][32]byte, 0, len(tx.updates))
-	for id := range tx.updates {
+	states := make(map[[32]byte]*Object, len(tx.updates))
+	for id, update := range tx.updates {
 		chains = append(chains, id)
+		states[id] = update.stateData
 	}
 
 	// Load the chain
@@ -61,5 +71,5 @@ func (tx *DBTransaction) writeAnchorChain(blockIndex int64, timestamp time.Time)
 	// Update the chain. Even if no records changed, we need to update the
 	// anchor record. Otherwise, the block height does not get updated and
 	// everything breaks.
-	return mgr.Update(blockIndex, timestamp, chains)
+	return mgr.Update(blockIndex, timestamp, chains, states)
 }
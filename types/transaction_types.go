@@ -17,11 +17,15 @@ const (
 	// TxTypeUnknown represents an unknown transaction type.
 	TxTypeUnknown TransactionType = 0x00
 
-	// txSynthetic marks the boundary between user and system transactions.
+	// txSystem marks the boundary between user and system transactions.
+	txSystem = TxTypeUpdateValidator
+
+	// txSynthetic marks the boundary between system and synthetic
+	// transactions.
 	txSynthetic = TxTypeSyntheticSignTransactions
 
 	// txMax is the last defined transaction type.
-	txMax = TxTypeSegWitDataEntry
+	txMax = TxTypeSyntheticExternalAnchor
 )
 
 // User transactions
@@ -81,9 +85,40 @@ const (
 	// TxTypeUpdateKeyPage adds, removes, or updates keys in a key page, which
 	// *does not* produce a synthetic transaction.
 	TxTypeUpdateKeyPage TransactionType = 0x0F
+
+	// TxTypeUpdateKeyBook adds, removes, or reorders the pages of a key book,
+	// which *does not* produce a synthetic transaction.
+	TxTypeUpdateKeyBook TransactionType = 0x10
+
+	// TxTypeUpdateAccountAuth assigns an account to a different key book,
+	// which *does not* produce a synthetic transaction.
+	TxTypeUpdateAccountAuth TransactionType = 0x11
+
+	// TxTypeUpdateManager sets or removes the manager key book of a data
+	// account, which *does not* produce a synthetic transaction.
+	TxTypeUpdateManager TransactionType = 0x12
+
+	// TxTypeTransferCredits moves credits from a key page or lite token
+	// account to another, which produces a synthetic deposit credits
+	// transaction.
+	TxTypeTransferCredits TransactionType = 0x14
 )
 
 // System transactions
+const (
+	// TxTypeUpdateValidator adds, removes, or changes the voting power of a
+	// validator of the subnet, which *does not* produce a synthetic
+	// transaction. The change is applied to the validator set at the end of
+	// the block.
+	TxTypeUpdateValidator TransactionType = 0x20
+
+	// TxTypeUpdateAcmeOracle sets the ACME price of the DN's oracle, which
+	// *does not* produce a synthetic transaction. The new price is sent to the
+	// BVNs with the next anchor.
+	TxTypeUpdateAcmeOracle TransactionType = 0x21
+)
+
+// Synthetic transactions
 const (
 	// TxTypeSyntheticCreateChain propagates signatures for synthetic
 	// transactions so they can be submitted to the network.
@@ -117,8 +152,17 @@ const (
 	// TxTypeSegWitDataEntry is a surrogate transaction segregated witness for
 	// a WriteData transaction
 	TxTypeSegWitDataEntry TransactionType = 0x39
+
+	// TxTypeSyntheticExternalAnchor records the ID of the external transaction
+	// that anchored the root of a major block to an external ledger.
+	TxTypeSyntheticExternalAnchor TransactionType = 0x3A
 )
 
+// IsSystem returns true if the transaction type is a system transaction.
+// System transactions are sent by the validators to the subnet's ADI and are
+// not charged a fee.
+func (t TransactionType) IsSystem() bool { return t >= txSystem && t < txSynthetic }
+
 // IsSynthetic returns true if the transaction type is synthetic.
 func (t TransactionType) IsSynthetic() bool { return t >= txSynthetic }
 
@@ -158,6 +202,18 @@ func (t TransactionType) String() string {
 		return "addCredits"
 	case TxTypeUpdateKeyPage:
 		return "updateKeyPage"
+	case TxTypeUpdateKeyBook:
+		return "updateKeyBook"
+	case TxTypeUpdateAccountAuth:
+		return "updateAccountAuth"
+	case TxTypeUpdateManager:
+		return "updateManager"
+	case TxTypeTransferCredits:
+		return "transferCredits"
+	case TxTypeUpdateValidator:
+		return "updateValidator"
+	case TxTypeUpdateAcmeOracle:
+		return "updateAcmeOracle"
 	case TxTypeSyntheticSignTransactions:
 		return "syntheticSignTransactions"
 	case TxTypeSyntheticCreateChain:
@@ -178,6 +234,8 @@ func (t TransactionType) String() string {
 		return "syntheticMirror"
 	case TxTypeSegWitDataEntry:
 		return "segWitDataEntry"
+	case TxTypeSyntheticExternalAnchor:
+		return "syntheticExternalAnchor"
 	default:
 		return fmt.Sprintf("TransactionType:%d", t)
 	}